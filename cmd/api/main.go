@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/SimpleBookRental/backend/internal/api"
+	"github.com/SimpleBookRental/backend/internal/jobs"
 	"github.com/SimpleBookRental/backend/internal/repository"
 	"github.com/SimpleBookRental/backend/internal/service"
 	"github.com/SimpleBookRental/backend/pkg/auth"
 	"github.com/SimpleBookRental/backend/pkg/config"
 	"github.com/SimpleBookRental/backend/pkg/logger"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	_ "github.com/SimpleBookRental/backend/docs" // Import generated docs
 )
 
@@ -75,16 +77,30 @@ func main() {
 	repos := repository.NewRepository(dbConn)
 
 	// Initialize JWT service
-	jwtService := auth.NewJWTService(&cfg.JWT)
+	jwtService, err := auth.NewJWTService(&cfg.JWT)
+	if err != nil {
+		appLogger.Fatal("Failed to initialize JWT service", err)
+	}
 
 	// Initialize services
-	services := service.NewService(repos, cfg, jwtService, appLogger)
+	services, err := service.NewService(repos, cfg, jwtService, appLogger)
+	if err != nil {
+		appLogger.Fatal("Failed to initialize services", err)
+	}
 
 	// Initialize handlers
 	handlers := api.NewHandler(services, cfg, jwtService, appLogger)
 
 	// Initialize middleware
-	middleware := api.NewMiddleware(jwtService, appLogger)
+	middleware := api.NewMiddleware(jwtService, services.Auth, services.APIKey, services.Authz, cfg.RateLimit, appLogger)
+
+	// Initialize background jobs. Each job takes a database lock before it runs, so only
+	// one replica runs it at a time.
+	scheduler := jobs.NewScheduler(repos.JobLock, appLogger.Named("jobs"))
+	if cfg.Jobs.Enabled {
+		jobs.RegisterAll(scheduler, services, cfg)
+		scheduler.Start()
+	}
 
 	// Initialize router
 	router := gin.New()
@@ -126,7 +142,13 @@ func main() {
 
 	// Shutdown server
 	if err := server.Shutdown(ctx); err != nil {
-		appLogger.Fatal("Server forced to shutdown", err)
+		appLogger.Error("Server forced to shutdown", zap.Error(err))
+	}
+
+	// Stop background jobs, letting running ones finish within the same deadline, even when
+	// the server could not shut down cleanly
+	if err := scheduler.Stop(ctx); err != nil {
+		appLogger.Error("Background jobs did not stop in time", zap.Error(err))
 	}
 
 	appLogger.Info("Server exiting")
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler handles API key management requests
+type APIKeyHandler struct {
+	apiKeyService domain.APIKeyService
+	jwtService    *auth.JWTService
+	logger        *logger.Logger
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyService domain.APIKeyService, jwtService *auth.JWTService, logger *logger.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		jwtService:    jwtService,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKeyRequest represents a request to issue an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"Catalog sync"`
+	UserID    int64      `json:"user_id" binding:"required" example:"2"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1" example:"books:read,books:write"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// CreateAPIKeyResponse represents a newly issued API key. The key is only returned once.
+type CreateAPIKeyResponse struct {
+	*domain.APIKey
+	Key string `json:"key" example:"sbr_3f2a..."`
+}
+
+// GetByID handles getting an API key by ID
+// @Summary      Get an API key
+// @Description  Get an API key by ID. Only admins can manage API keys.
+// @Tags         api-keys
+// @Produce      json
+// @Param        id   path      int  true  "API key ID"
+// @Success      200  {object}  Response{data=domain.APIKey}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /api-keys/{id} [get]
+func (h *APIKeyHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid API key ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid API key ID"))
+		return
+	}
+
+	key, err := h.apiKeyService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get API key by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, key, "API key retrieved successfully")
+}
+
+// List handles listing API keys
+// @Summary      List API keys
+// @Description  Get a paginated list of API keys. Only admins can manage API keys.
+// @Tags         api-keys
+// @Produce      json
+// @Param        limit  query    int     false  "Limit"  default(10)
+// @Param        offset query    int     false  "Offset" default(0)
+// @Success      200    {object} PaginatedResponse{data=[]domain.APIKey}
+// @Failure      401    {object} domain.ErrorResponse
+// @Failure      403    {object} domain.ErrorResponse
+// @Failure      500    {object} domain.ErrorResponse
+// @Security     Bearer
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	keys, err := h.apiKeyService.List(int32(limit), int32(offset))
+	if err != nil {
+		h.logger.Error("Failed to list API keys", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendPaginated(c, keys, int64(len(keys)), int32(limit), int32(offset), "API keys retrieved successfully")
+}
+
+// Create handles issuing an API key
+// @Summary      Create an API key
+// @Description  Issue an API key that acts as the given user, limited to the given scopes ("<resource>:read" or "<resource>:write"). When two-factor authentication is required for staff, keys of admins and librarians are limited to read scopes. The key is only shown in this response.
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Param        key  body      CreateAPIKeyRequest  true  "API key details"
+// @Success      201  {object}  Response{data=CreateAPIKeyResponse}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	key := &domain.APIKey{
+		Name:      req.Name,
+		UserID:    req.UserID,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: userID.(int64),
+	}
+
+	createdKey, rawKey, err := h.apiKeyService.Create(key)
+	if err != nil {
+		h.logger.Error("Failed to create API key", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, CreateAPIKeyResponse{APIKey: createdKey, Key: rawKey}, "API key created successfully. Store the key now, it will not be shown again")
+}
+
+// Revoke handles revoking an API key
+// @Summary      Revoke an API key
+// @Description  Revoke an API key so it can no longer be used. Only admins can manage API keys.
+// @Tags         api-keys
+// @Produce      json
+// @Param        id   path      int  true  "API key ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid API key ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid API key ID"))
+		return
+	}
+
+	err = h.apiKeyService.Revoke(id)
+	if err != nil {
+		h.logger.Error("Failed to revoke API key", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
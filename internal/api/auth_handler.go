@@ -1,7 +1,11 @@
 package api
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SimpleBookRental/backend/internal/service"
 	"github.com/SimpleBookRental/backend/internal/domain"
@@ -27,11 +31,18 @@ func NewAuthHandler(authService service.AuthService, jwtService *auth.JWTService
 	}
 }
 
+// ImpersonationResponse represents a token that acts as another user on behalf of an admin
+type ImpersonationResponse struct {
+	AccessToken string       `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt   time.Time    `json:"expires_at" example:"2025-05-01T10:15:00Z"`
+	User        *domain.User `json:"user"`
+}
+
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
 	Username  string `json:"username" binding:"required,min=3,max=50" example:"johndoe"`
 	Email     string `json:"email" binding:"required,email" example:"john.doe@example.com"`
-	Password  string `json:"password" binding:"required,min=6" example:"password123"`
+	Password  string `json:"password" binding:"required" example:"Password123"`
 	FirstName string `json:"first_name" example:"John"`
 	LastName  string `json:"last_name" example:"Doe"`
 }
@@ -47,12 +58,49 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
-// TokenResponse represents a token response
-type TokenResponse struct {
-	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+// LogoutRequest represents a logout request
+type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
 
+// ForgotPasswordRequest represents a password reset request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"john.doe@example.com"`
+}
+
+// ResetPasswordRequest represents a request to set a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required" example:"3f2a..."`
+	NewPassword string `json:"new_password" binding:"required" example:"NewPassword123"`
+}
+
+// VerifyMFARequest represents the second step of a two-factor login
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// ConfirmMFARequest represents a request to confirm two-factor enrollment
+type ConfirmMFARequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// RecoveryCodesResponse represents the recovery codes issued when two-factor authentication is enabled
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3j9d-p2m7q"`
+}
+
+// TokenResponse represents a token response.
+// When two-factor authentication is required only mfa_required and mfa_token are set.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refresh_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	MFARequired  bool   `json:"mfa_required,omitempty" example:"false"`
+	MFAToken     string `json:"mfa_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// Set when the account still has a generated password, which should be changed before anything else
+	PasswordChangeRequired bool `json:"password_change_required,omitempty" example:"false"`
+}
+
 // Register handles user registration
 // @Summary      Register a new user
 // @Description  Register a new user with member role
@@ -111,16 +159,122 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.authService.Login(req.Username, req.Password)
+	result, err := h.authService.Login(req.Username, req.Password, c.Request.UserAgent(), c.ClientIP())
 	if err != nil {
 		h.logger.Error("Failed to login", zap.Error(err))
 		SendError(c, err)
 		return
 	}
 
+	if result.MFARequired {
+		SendSuccess(c, TokenResponse{MFARequired: true, MFAToken: result.MFAToken}, "Two-factor authentication required")
+		return
+	}
+
 	response := TokenResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:            result.AccessToken,
+		RefreshToken:           result.RefreshToken,
+		PasswordChangeRequired: result.PasswordChangeRequired,
+	}
+
+	SendSuccess(c, response, "Login successful")
+}
+
+// OIDCProviders handles listing the external identity providers
+// @Summary      List identity providers
+// @Description  Names of the configured OpenID Connect providers that can be used with /auth/oidc/{provider}
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  map[string][]string
+// @Router       /auth/oidc [get]
+func (h *AuthHandler) OIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"providers": h.authService.OIDCProviders()})
+}
+
+// oidcStateCookie binds the state of an OpenID Connect login to the browser that started it
+const oidcStateCookie = "oidc_state"
+
+// OIDCLogin handles starting a login with an external identity provider
+// @Summary      Start identity provider login
+// @Description  Redirect the browser to the identity provider using the authorization code flow with PKCE. The state is also set in an HttpOnly cookie, which the callback must be called with.
+// @Tags         auth
+// @Param        provider  path  string  true  "Provider name"
+// @Success      302
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Router       /auth/oidc/{provider} [get]
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	authURL, state, err := h.authService.OIDCAuthorize(c.Param("provider"))
+	if err != nil {
+		h.logger.Error("Failed to start OIDC login", zap.String("provider", c.Param("provider")), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	// Lax so that the cookie is sent on the provider's top-level redirect back to the callback
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, state, 0, c.Request.URL.Path, "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDCCallback handles the redirect back from an external identity provider
+// @Summary      Complete identity provider login
+// @Description  Exchange the authorization code for tokens. First sign-ins create a member account; existing accounts are linked when both sides have verified the email address.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Param        code      query     string  true  "Authorization code"
+// @Param        state     query     string  true  "State returned by the provider"
+// @Success      200       {object}  TokenResponse
+// @Failure      400       {object}  domain.ErrorResponse
+// @Failure      401       {object}  domain.ErrorResponse
+// @Failure      404       {object}  domain.ErrorResponse
+// @Failure      409       {object}  domain.ErrorResponse
+// @Failure      500       {object}  domain.ErrorResponse
+// @Router       /auth/oidc/{provider}/callback [get]
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	if providerError := c.Query("error"); providerError != "" {
+		h.logger.Warn("Identity provider returned an error",
+			zap.String("provider", c.Param("provider")), zap.String("error", providerError),
+			zap.String("description", c.Query("error_description")))
+		SendError(c, domain.ErrOIDCLoginFailed)
+		return
+	}
+
+	code := c.Query("code")
+	state := c.Query("state")
+	if code == "" || state == "" {
+		SendError(c, domain.NewInvalidInputError("code and state are required"))
+		return
+	}
+
+	// The state must come back to the browser the login was started in, otherwise an attacker
+	// could have a victim complete a login the attacker started
+	cookiePath := strings.TrimSuffix(c.Request.URL.Path, "/callback")
+	cookieState, err := c.Cookie(oidcStateCookie)
+	c.SetCookie(oidcStateCookie, "", -1, cookiePath, "", c.Request.TLS != nil, true)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		h.logger.Warn("OIDC state does not match the browser", zap.String("provider", c.Param("provider")))
+		SendError(c, domain.ErrInvalidOIDCState)
+		return
+	}
+
+	result, err := h.authService.OIDCCallback(c.Param("provider"), code, state, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		h.logger.Error("Failed to complete OIDC login", zap.String("provider", c.Param("provider")), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	if result.MFARequired {
+		SendSuccess(c, TokenResponse{MFARequired: true, MFAToken: result.MFAToken}, "Two-factor authentication required")
+		return
+	}
+
+	response := TokenResponse{
+		AccessToken:            result.AccessToken,
+		RefreshToken:           result.RefreshToken,
+		PasswordChangeRequired: result.PasswordChangeRequired,
 	}
 
 	SendSuccess(c, response, "Login successful")
@@ -163,10 +317,11 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout handles user logout
 // @Summary      Logout user
-// @Description  Invalidate the current user's token
+// @Description  Revoke the current access token and, if provided, the refresh token
 // @Tags         auth
 // @Accept       json
 // @Produce      json
+// @Param        token  body      LogoutRequest  false  "Refresh token to revoke"
 // @Success      200  {object}  map[string]string
 // @Failure      401  {object}  domain.ErrorResponse
 // @Failure      500  {object}  domain.ErrorResponse
@@ -182,7 +337,17 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	// Extract token from header
 	token := authHeader[7:] // Remove "Bearer " prefix
 
-	err := h.authService.Logout(token)
+	// The refresh token is optional, but should be sent so it is revoked too
+	var req LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Invalid request body", zap.Error(err))
+			SendError(c, domain.NewInvalidInputError(err.Error()))
+			return
+		}
+	}
+
+	err := h.authService.Logout(token, req.RefreshToken)
 	if err != nil {
 		h.logger.Error("Failed to logout", zap.Error(err))
 		SendError(c, err)
@@ -191,3 +356,357 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
 }
+
+// ListSessions handles listing the current user's sessions
+// @Summary      List sessions
+// @Description  List the active sessions (logged-in devices) of the current user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  []domain.Session
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to list sessions", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	// Mark the session the request was made from
+	if currentSessionID, ok := c.Get("sessionID"); ok {
+		for _, session := range sessions {
+			session.Current = session.ID == currentSessionID.(int64)
+		}
+	}
+
+	SendSuccess(c, sessions, "Sessions retrieved successfully")
+}
+
+// RevokeSession handles revoking one of the current user's sessions
+// @Summary      Revoke a session
+// @Description  Revoke one of the current user's sessions, invalidating its tokens
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Session ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid session ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid session ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err = h.authService.RevokeSession(userID.(int64), id)
+	if err != nil {
+		h.logger.Error("Failed to revoke session", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// ForgotPassword handles password reset requests
+// @Summary      Request a password reset
+// @Description  Email a single-use password reset link. Always succeeds so it cannot be used to discover accounts.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ForgotPasswordRequest  true  "Account email"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Router       /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	err := h.authService.ForgotPassword(req.Email)
+	if err != nil {
+		h.logger.Error("Failed to process password reset request", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered, a password reset link has been sent"})
+}
+
+// VerifyMFA handles the second step of a two-factor login
+// @Summary      Verify two-factor code
+// @Description  Complete a login with the MFA token returned by /auth/login and a TOTP or recovery code
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      VerifyMFARequest  true  "MFA token and code"
+// @Success      200      {object}  TokenResponse
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      423      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Router       /auth/2fa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	result, err := h.authService.VerifyMFA(req.MFAToken, req.Code, c.Request.UserAgent(), c.ClientIP())
+	if err != nil {
+		h.logger.Error("Failed to verify two-factor code", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	response := TokenResponse{
+		AccessToken:            result.AccessToken,
+		RefreshToken:           result.RefreshToken,
+		PasswordChangeRequired: result.PasswordChangeRequired,
+	}
+
+	SendSuccess(c, response, "Login successful")
+}
+
+// EnrollMFA handles starting two-factor enrollment
+// @Summary      Start two-factor enrollment
+// @Description  Generate a TOTP secret and the otpauth:// URI to show as a QR code. Confirm it with /auth/2fa/confirm.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  service.MFAEnrollment
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      409  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /auth/2fa/enroll [post]
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	enrollment, err := h.authService.EnrollMFA(userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to start two-factor enrollment", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, enrollment, "Scan the QR code and confirm with a code from your authenticator app")
+}
+
+// ConfirmMFA handles confirming two-factor enrollment
+// @Summary      Confirm two-factor enrollment
+// @Description  Enable two-factor authentication with a code from the authenticator app. Returns recovery codes that are shown only once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ConfirmMFARequest  true  "TOTP code"
+// @Success      200      {object}  RecoveryCodesResponse
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      403      {object}  domain.ErrorResponse
+// @Failure      409      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /auth/2fa/confirm [post]
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	var req ConfirmMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	codes, err := h.authService.ConfirmMFA(userID.(int64), req.Code)
+	if err != nil {
+		h.logger.Error("Failed to confirm two-factor enrollment", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, RecoveryCodesResponse{RecoveryCodes: codes}, "Two-factor authentication enabled")
+}
+
+// VerifyEmail handles email verification links
+// @Summary      Verify email address
+// @Description  Confirm the email address of an account using the token sent at registration, when the address was changed or when a new link was requested
+// @Tags         auth
+// @Produce      json
+// @Param        token  query     string  true  "Verification token"
+// @Success      200    {object}  map[string]string
+// @Failure      400    {object}  domain.ErrorResponse
+// @Failure      500    {object}  domain.ErrorResponse
+// @Router       /auth/verify [get]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		SendError(c, domain.NewInvalidInputError("token is required"))
+		return
+	}
+
+	err := h.authService.VerifyEmail(token)
+	if err != nil {
+		h.logger.Error("Failed to verify email", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification handles sending the current user a new email verification link
+// @Summary      Resend verification email
+// @Description  Email the current user a new link to verify their email address, e.g. when the previous link expired or the address was changed
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  map[string]string
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      409  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /auth/verify/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err := h.authService.ResendVerificationEmail(userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to resend verification email", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+}
+
+// ResetPassword handles setting a new password with a reset token
+// @Summary      Reset password
+// @Description  Set a new password using a reset token. The token can only be used once and all sessions are logged out.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      ResetPasswordRequest  true  "Reset token and new password"
+// @Success      200      {object}  map[string]string
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Router       /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	err := h.authService.ResetPassword(req.Token, req.NewPassword)
+	if err != nil {
+		h.logger.Error("Failed to reset password", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// JWKS handles publishing the public token signing keys
+// @Summary      JSON Web Key Set
+// @Description  Public keys for verifying access tokens offline, identified by the kid token header. Retired keys stay listed until their grace period ends. Empty when tokens are signed with a shared secret.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  auth.JWKS
+// @Router       /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwtService.JWKS())
+}
+
+// Impersonate handles issuing a token to act as another user
+// @Summary      Impersonate a user
+// @Description  Issue a short-lived access token that acts as the given user, to see the API as they do. The token carries the admin as actor and every request made with it is logged. Admins cannot be impersonated.
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  Response{data=ImpersonationResponse}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /admin/users/{id}/impersonate [post]
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// An impersonation token cannot be used to start another impersonation
+	if _, impersonating := c.Get("actorID"); impersonating {
+		SendError(c, domain.ErrImpersonationForbidden)
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.authService.Impersonate(userID.(int64), c.GetInt64("sessionID"), id)
+	if err != nil {
+		h.logger.Error("Failed to impersonate user", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, ImpersonationResponse{
+		AccessToken: result.AccessToken,
+		ExpiresAt:   result.ExpiresAt,
+		User:        result.User,
+	}, "Impersonation token issued")
+}
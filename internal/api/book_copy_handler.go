@@ -0,0 +1,190 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BookCopyHandler handles requests for the physical copies of books
+type BookCopyHandler struct {
+	copyService domain.BookCopyService
+	logger      *logger.Logger
+}
+
+// NewBookCopyHandler creates a new BookCopyHandler
+func NewBookCopyHandler(copyService domain.BookCopyService, logger *logger.Logger) *BookCopyHandler {
+	return &BookCopyHandler{
+		copyService: copyService,
+		logger:      logger,
+	}
+}
+
+// BookCopyRequest represents a request to add a copy to a book
+type BookCopyRequest struct {
+	Barcode       string                   `json:"barcode" binding:"required,max=50" example:"BK000001-003"`
+	Condition     domain.BookCopyCondition `json:"condition" example:"good"`
+	ShelfLocation string                   `json:"shelf_location" example:"A3-12"`
+	Status        domain.BookCopyStatus    `json:"status" example:"available"`
+}
+
+// UpdateBookCopyRequest represents a request to update a copy
+type UpdateBookCopyRequest struct {
+	Condition     domain.BookCopyCondition `json:"condition" binding:"required" example:"fair"`
+	ShelfLocation string                   `json:"shelf_location" example:"A3-12"`
+	Status        domain.BookCopyStatus    `json:"status" binding:"required" example:"maintenance"`
+}
+
+// ListByBook handles listing the copies of a book
+// @Summary      List copies of a book
+// @Description  Get every physical copy of a book with its barcode, condition, shelf location and status
+// @Tags         book-copies
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Success      200  {object}  Response{data=[]domain.BookCopy}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /books/{id}/copies [get]
+func (h *BookCopyHandler) ListByBook(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid book ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid book ID"))
+		return
+	}
+
+	copies, err := h.copyService.ListByBook(id)
+	if err != nil {
+		h.logger.Error("Failed to list book copies", zap.Int64("bookID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, copies, "Book copies retrieved successfully")
+}
+
+// Create handles adding a copy to a book
+// @Summary      Add a copy to a book
+// @Description  Register a physical copy of a book under its barcode
+// @Tags         book-copies
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Book ID"
+// @Param        copy  body      BookCopyRequest  true  "Book copy object"
+// @Success      201   {object}  domain.BookCopy
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      404   {object}  domain.ErrorResponse
+// @Failure      409   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /books/{id}/copies [post]
+func (h *BookCopyHandler) Create(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid book ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid book ID"))
+		return
+	}
+
+	var req BookCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	bookCopy := &domain.BookCopy{
+		BookID:        id,
+		Barcode:       req.Barcode,
+		Condition:     req.Condition,
+		ShelfLocation: req.ShelfLocation,
+		Status:        req.Status,
+	}
+
+	createdCopy, err := h.copyService.Create(bookCopy)
+	if err != nil {
+		h.logger.Error("Failed to create book copy", zap.Int64("bookID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, createdCopy, "Book copy created successfully")
+}
+
+// GetByBarcode handles getting a copy by its barcode
+// @Summary      Get a copy by barcode
+// @Description  Look up a scanned copy together with the title of its book
+// @Tags         book-copies
+// @Produce      json
+// @Param        barcode  path      string  true  "Barcode"
+// @Success      200      {object}  domain.BookCopy
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      403      {object}  domain.ErrorResponse
+// @Failure      404      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /copies/{barcode} [get]
+func (h *BookCopyHandler) GetByBarcode(c *gin.Context) {
+	barcode := c.Param("barcode")
+
+	bookCopy, err := h.copyService.GetByBarcode(barcode)
+	if err != nil {
+		h.logger.Error("Failed to get book copy by barcode", zap.String("barcode", barcode), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, bookCopy, "Book copy retrieved successfully")
+}
+
+// Update handles updating a copy
+// @Summary      Update a copy
+// @Description  Update the condition, shelf location and status of a copy. Checked out copies keep their status until they are returned.
+// @Tags         book-copies
+// @Accept       json
+// @Produce      json
+// @Param        barcode  path      string                 true  "Barcode"
+// @Param        copy     body      UpdateBookCopyRequest  true  "Updated book copy object"
+// @Success      200      {object}  domain.BookCopy
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      403      {object}  domain.ErrorResponse
+// @Failure      404      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /copies/{barcode} [put]
+func (h *BookCopyHandler) Update(c *gin.Context) {
+	barcode := c.Param("barcode")
+
+	var req UpdateBookCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	bookCopy := &domain.BookCopy{
+		Barcode:       barcode,
+		Condition:     req.Condition,
+		ShelfLocation: req.ShelfLocation,
+		Status:        req.Status,
+	}
+
+	updatedCopy, err := h.copyService.Update(bookCopy)
+	if err != nil {
+		h.logger.Error("Failed to update book copy", zap.String("barcode", barcode), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, updatedCopy, "Book copy updated successfully")
+}
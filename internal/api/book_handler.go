@@ -35,16 +35,9 @@ type BookRequest struct {
 	Description   string `json:"description"`
 	PublishedYear int32  `json:"published_year"`
 	Publisher     string `json:"publisher"`
-	TotalCopies   int32  `json:"total_copies" binding:"required,min=1"`
 	CategoryID    int64  `json:"category_id"`
 }
 
-// BookCopiesRequest represents a book copies update request
-type BookCopiesRequest struct {
-	TotalCopies     int32 `json:"total_copies" binding:"required,min=1"`
-	AvailableCopies int32 `json:"available_copies" binding:"required,min=0"`
-}
-
 // BookSearchRequest represents a book search request
 type BookSearchRequest struct {
 	Title         string `form:"title"`
@@ -210,19 +203,6 @@ func (h *BookHandler) Search(c *gin.Context) {
 // @Security     Bearer
 // @Router       /books [post]
 func (h *BookHandler) Create(c *gin.Context) {
-	// Only admins and librarians can create books
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	var req BookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
@@ -231,15 +211,13 @@ func (h *BookHandler) Create(c *gin.Context) {
 	}
 
 	book := &domain.Book{
-		Title:           req.Title,
-		Author:          req.Author,
-		ISBN:            req.ISBN,
-		Description:     req.Description,
-		PublishedYear:   req.PublishedYear,
-		Publisher:       req.Publisher,
-		TotalCopies:     req.TotalCopies,
-		AvailableCopies: req.TotalCopies, // Initially all copies are available
-		CategoryID:      req.CategoryID,
+		Title:         req.Title,
+		Author:        req.Author,
+		ISBN:          req.ISBN,
+		Description:   req.Description,
+		PublishedYear: req.PublishedYear,
+		Publisher:     req.Publisher,
+		CategoryID:    req.CategoryID,
 	}
 
 	createdBook, err := h.bookService.Create(book)
@@ -269,19 +247,6 @@ func (h *BookHandler) Create(c *gin.Context) {
 // @Security     Bearer
 // @Router       /books/{id} [put]
 func (h *BookHandler) Update(c *gin.Context) {
-	// Only admins and librarians can update books
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		h.logger.Error("Invalid book ID", zap.Error(err))
@@ -323,60 +288,6 @@ func (h *BookHandler) Update(c *gin.Context) {
 	SendSuccess(c, updatedBook, "Book updated successfully")
 }
 
-// UpdateCopies handles updating book copies
-// @Summary      Update book copies
-// @Description  Update the number of total and available copies of a book
-// @Tags         books
-// @Accept       json
-// @Produce      json
-// @Param        id    path      int                true  "Book ID"
-// @Param        copies body     BookCopiesRequest  true  "Updated copies information"
-// @Success      200   {object}  domain.Book
-// @Failure      400   {object}  domain.ErrorResponse
-// @Failure      401   {object}  domain.ErrorResponse
-// @Failure      403   {object}  domain.ErrorResponse
-// @Failure      404   {object}  domain.ErrorResponse
-// @Failure      500   {object}  domain.ErrorResponse
-// @Security     Bearer
-// @Router       /books/{id}/copies [patch]
-func (h *BookHandler) UpdateCopies(c *gin.Context) {
-	// Only admins and librarians can update book copies
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
-	if err != nil {
-		h.logger.Error("Invalid book ID", zap.Error(err))
-		SendError(c, domain.NewInvalidInputError("invalid book ID"))
-		return
-	}
-
-	var req BookCopiesRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Invalid request body", zap.Error(err))
-		SendError(c, domain.NewInvalidInputError(err.Error()))
-		return
-	}
-
-	updatedBook, err := h.bookService.UpdateCopies(id, req.TotalCopies, req.AvailableCopies)
-	if err != nil {
-		h.logger.Error("Failed to update book copies", zap.Int64("id", id), zap.Error(err))
-		SendError(c, err)
-		return
-	}
-
-	SendSuccess(c, updatedBook, "Book copies updated successfully")
-}
-
 // Delete handles deleting a book
 // @Summary      Delete a book
 // @Description  Delete a book from the catalog
@@ -393,19 +304,6 @@ func (h *BookHandler) UpdateCopies(c *gin.Context) {
 // @Security     Bearer
 // @Router       /books/{id} [delete]
 func (h *BookHandler) Delete(c *gin.Context) {
-	// Only admins and librarians can delete books
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		h.logger.Error("Invalid book ID", zap.Error(err))
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxCalendarFileSize caps the size of an uploaded iCalendar file
+const maxCalendarFileSize = 1 << 20
+
+// CalendarHandler handles library calendar requests
+type CalendarHandler struct {
+	calendarService domain.CalendarService
+	logger          *logger.Logger
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(calendarService domain.CalendarService, logger *logger.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		logger:          logger,
+	}
+}
+
+// OpeningHoursRequest represents the opening hours of a day of the week
+type OpeningHoursRequest struct {
+	Weekday  *int   `json:"weekday" binding:"required,min=0,max=6" example:"0"` // 0 is Sunday
+	OpensAt  string `json:"opens_at" example:"10:00"`
+	ClosesAt string `json:"closes_at" example:"16:00"`
+	Closed   bool   `json:"closed" example:"false"`
+}
+
+// UpdateOpeningHoursRequest represents a request to update the weekly opening hours
+type UpdateOpeningHoursRequest struct {
+	Hours []OpeningHoursRequest `json:"hours" binding:"required,min=1,dive"`
+}
+
+// ClosureRequest represents a request to close the library on a date
+type ClosureRequest struct {
+	Date   string `json:"date" binding:"required" example:"2025-12-25"`
+	Reason string `json:"reason" binding:"required,max=255" example:"Christmas Day"`
+}
+
+// GetOpeningHours handles getting the weekly opening hours
+// @Summary      Get opening hours
+// @Description  Get the opening hours of each day of the week, starting on Sunday
+// @Tags         calendar
+// @Produce      json
+// @Success      200  {object}  Response{data=[]domain.OpeningHours}
+// @Failure      500  {object}  domain.ErrorResponse
+// @Router       /calendar/hours [get]
+func (h *CalendarHandler) GetOpeningHours(c *gin.Context) {
+	hours, err := h.calendarService.GetOpeningHours()
+	if err != nil {
+		h.logger.Error("Failed to get opening hours", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, hours, "Opening hours retrieved successfully")
+}
+
+// UpdateOpeningHours handles updating the weekly opening hours
+// @Summary      Update opening hours
+// @Description  Set the opening hours of the given days of the week, the other days keep theirs. Due dates of new rentals and extensions roll forward past the days the library is closed, and closed days are not fined.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        hours  body      UpdateOpeningHoursRequest  true  "Opening hours by day of the week"
+// @Success      200    {object}  Response{data=[]domain.OpeningHours}
+// @Failure      400    {object}  domain.ErrorResponse
+// @Failure      401    {object}  domain.ErrorResponse
+// @Failure      403    {object}  domain.ErrorResponse
+// @Failure      500    {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /calendar/hours [put]
+func (h *CalendarHandler) UpdateOpeningHours(c *gin.Context) {
+	var req UpdateOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	hours := make([]*domain.OpeningHours, len(req.Hours))
+	for i, day := range req.Hours {
+		hours[i] = &domain.OpeningHours{
+			Weekday:  time.Weekday(*day.Weekday),
+			OpensAt:  day.OpensAt,
+			ClosesAt: day.ClosesAt,
+			Closed:   day.Closed,
+		}
+	}
+
+	updatedHours, err := h.calendarService.UpdateOpeningHours(hours)
+	if err != nil {
+		h.logger.Error("Failed to update opening hours", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, updatedHours, "Opening hours updated successfully")
+}
+
+// ListClosures handles listing the dates the library is closed
+// @Summary      List closures
+// @Description  Get the dates the library is closed on from one date up to and including another, by default over the coming year
+// @Tags         calendar
+// @Produce      json
+// @Param        from  query     string  false  "Start date (YYYY-MM-DD), today by default"
+// @Param        to    query     string  false  "End date (YYYY-MM-DD), a year after the start by default"
+// @Success      200   {object}  Response{data=[]domain.Closure}
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Router       /calendar/closures [get]
+func (h *CalendarHandler) ListClosures(c *gin.Context) {
+	from := time.Now()
+	if value := c.Query("from"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			SendError(c, domain.NewInvalidInputError("invalid start date, use YYYY-MM-DD"))
+			return
+		}
+		from = date
+	}
+
+	to := from.AddDate(1, 0, 0)
+	if value := c.Query("to"); value != "" {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			SendError(c, domain.NewInvalidInputError("invalid end date, use YYYY-MM-DD"))
+			return
+		}
+		to = date
+	}
+
+	closures, err := h.calendarService.ListClosures(from, to)
+	if err != nil {
+		h.logger.Error("Failed to list closures", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, closures, "Closures retrieved successfully")
+}
+
+// CreateClosure handles closing the library on a date
+// @Summary      Create a closure
+// @Description  Close the library on a date. Rentals already due that day keep their due date, but the day is not fined.
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        closure  body      ClosureRequest  true  "Date and reason"
+// @Success      201      {object}  domain.Closure
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      403      {object}  domain.ErrorResponse
+// @Failure      409      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /calendar/closures [post]
+func (h *CalendarHandler) CreateClosure(c *gin.Context) {
+	var req ClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		SendError(c, domain.NewInvalidInputError("invalid date, use YYYY-MM-DD"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+	createdBy := userID.(int64)
+
+	closure, err := h.calendarService.CreateClosure(&domain.Closure{
+		Date:      date,
+		Reason:    req.Reason,
+		CreatedBy: &createdBy,
+	})
+	if err != nil {
+		h.logger.Error("Failed to create closure", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, closure, "Closure created successfully")
+}
+
+// ImportClosures handles importing closures from an iCalendar file
+// @Summary      Import closures from iCalendar
+// @Description  Close the library on every day covered by the events of an iCalendar (.ics) file, such as a public holiday calendar, with the event summary as the reason. Recurring and cancelled events are skipped, as are the dates the library is already closed on.
+// @Tags         calendar
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "iCalendar file"
+// @Success      200   {object}  Response{data=domain.ClosureImportResult}
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /calendar/closures/import [post]
+func (h *CalendarHandler) ImportClosures(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		SendError(c, domain.NewInvalidInputError("an iCalendar file is required in the file field"))
+		return
+	}
+	if fileHeader.Size > maxCalendarFileSize {
+		SendError(c, domain.NewInvalidInputError("the calendar file must be at most 1 MB"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("Failed to open calendar file", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+	defer file.Close()
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.calendarService.ImportICal(file, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to import closures", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, result, "Closures imported successfully")
+}
+
+// DeleteClosure handles reopening the library on the date of a closure
+// @Summary      Delete a closure
+// @Description  Reopen the library on the date of a closure
+// @Tags         calendar
+// @Produce      json
+// @Param        id   path      int  true  "Closure ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /calendar/closures/{id} [delete]
+func (h *CalendarHandler) DeleteClosure(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid closure ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid closure ID"))
+		return
+	}
+
+	err = h.calendarService.DeleteClosure(id)
+	if err != nil {
+		h.logger.Error("Failed to delete closure", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Closure deleted successfully"})
+}
@@ -124,19 +124,6 @@ func (h *CategoryHandler) ListAll(c *gin.Context) {
 // @Security     Bearer
 // @Router       /categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
-	// Only admins and librarians can create categories
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	var req CategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
@@ -176,19 +163,6 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 // @Security     Bearer
 // @Router       /categories/{id} [put]
 func (h *CategoryHandler) Update(c *gin.Context) {
-	// Only admins and librarians can update categories
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		h.logger.Error("Invalid category ID", zap.Error(err))
@@ -241,19 +215,6 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 // @Security     Bearer
 // @Router       /categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
-	// Only admins and librarians can delete categories
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
 		h.logger.Error("Invalid category ID", zap.Error(err))
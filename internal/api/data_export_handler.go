@@ -0,0 +1,139 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DataExportHandler handles personal data export requests
+type DataExportHandler struct {
+	exportService domain.DataExportService
+	logger        *logger.Logger
+}
+
+// NewDataExportHandler creates a new DataExportHandler
+func NewDataExportHandler(exportService domain.DataExportService, logger *logger.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// Export handles exporting the personal data of the current user
+// @Summary      Export my data
+// @Description  Download everything stored about the current user as a ZIP of JSON and CSV files: profile, rentals with book titles, payments, sessions and audit events. Large exports, or any export with async=true, are generated in the background; the response then holds the export with a download link that expires. A new background export is refused while the previous one is being generated or can still be downloaded.
+// @Tags         users
+// @Produce      application/zip
+// @Produce      json
+// @Param        async  query     bool  false  "Always generate the export in the background"
+// @Success      200    {file}    file
+// @Success      202    {object}  Response{data=domain.DataExport}
+// @Failure      401    {object}  domain.ErrorResponse
+// @Failure      403    {object}  domain.ErrorResponse
+// @Failure      409    {object}  domain.ErrorResponse
+// @Failure      500    {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/me/export [get]
+func (h *DataExportHandler) Export(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	async, _ := strconv.ParseBool(c.Query("async"))
+
+	archive, export, err := h.exportService.Export(userID.(int64), async)
+	if err != nil {
+		h.logger.Error("Failed to export user data", zap.Int64("userID", userID.(int64)), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	if export != nil {
+		SendAccepted(c, export, "Data export started, download it from the link once it is ready")
+		return
+	}
+
+	sendExportArchive(c, userID.(int64), archive)
+}
+
+// GetByID handles checking on a background export
+// @Summary      Get the status of my data export
+// @Description  Check whether a background export of the current user is ready for download
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "Data export ID"
+// @Success      200  {object}  Response{data=domain.DataExport}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/me/export/{id} [get]
+func (h *DataExportHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("exportId"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid data export ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid data export ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	export, err := h.exportService.GetByID(id, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to get data export", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, export, "Data export retrieved successfully")
+}
+
+// Download handles downloading a background export through its link
+// @Summary      Download a data export
+// @Description  Download a finished background export. The token from the download link authenticates the request.
+// @Tags         users
+// @Produce      application/zip
+// @Param        token  query     string  true  "Download token"
+// @Success      200    {file}    file
+// @Failure      400    {object}  domain.ErrorResponse
+// @Failure      404    {object}  domain.ErrorResponse
+// @Failure      409    {object}  domain.ErrorResponse
+// @Failure      500    {object}  domain.ErrorResponse
+// @Router       /exports/download [get]
+func (h *DataExportHandler) Download(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		SendError(c, domain.NewInvalidInputError("download token is required"))
+		return
+	}
+
+	export, err := h.exportService.Download(token)
+	if err != nil {
+		h.logger.Error("Failed to download data export", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	sendExportArchive(c, export.UserID, export.Archive)
+}
+
+// sendExportArchive sends a data export as a ZIP attachment
+func sendExportArchive(c *gin.Context, userID int64, archive []byte) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-export.zip"`, userID))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", archive)
+}
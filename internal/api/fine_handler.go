@@ -0,0 +1,182 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FineHandler handles fine requests
+type FineHandler struct {
+	fineService  domain.FineService
+	authzService domain.AuthorizationService
+	logger       *logger.Logger
+}
+
+// NewFineHandler creates a new FineHandler
+func NewFineHandler(fineService domain.FineService, authzService domain.AuthorizationService, logger *logger.Logger) *FineHandler {
+	return &FineHandler{
+		fineService:  fineService,
+		authzService: authzService,
+		logger:       logger,
+	}
+}
+
+// WaiveFineRequest represents a request to waive a fine
+type WaiveFineRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Book returned late because the library was closed for repairs"`
+}
+
+// List handles listing fines with pagination
+// @Summary      List all fines
+// @Description  Get a paginated list of all fines, optionally only those with the given status. Only admins and librarians can access this endpoint.
+// @Tags         fines
+// @Produce      json
+// @Param        status  query    string  false  "Status (outstanding, paid or waived)"
+// @Param        limit   query    int     false  "Limit"  default(10)
+// @Param        offset  query    int     false  "Offset" default(0)
+// @Success      200     {object} PaginatedResponse{data=[]domain.Fine}
+// @Failure      400     {object} domain.ErrorResponse
+// @Failure      401     {object} domain.ErrorResponse
+// @Failure      403     {object} domain.ErrorResponse
+// @Failure      500     {object} domain.ErrorResponse
+// @Security     Bearer
+// @Router       /fines [get]
+func (h *FineHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	status := domain.FineStatus(c.Query("status"))
+
+	fines, err := h.fineService.List(status, int32(limit), int32(offset))
+	if err != nil {
+		h.logger.Error("Failed to list fines", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendPaginated(c, fines, int64(len(fines)), int32(limit), int32(offset), "Fines retrieved successfully")
+}
+
+// ListByUser handles listing the fines of a user
+// @Summary      List fines by user
+// @Description  Get the fines of a user with what is left to pay on each. Users can only view their own fines and those of their dependents unless they are admins/librarians.
+// @Tags         fines
+// @Produce      json
+// @Param        userId  path      int  true  "User ID"
+// @Success      200     {object}  Response{data=[]domain.Fine}
+// @Failure      400     {object}  domain.ErrorResponse
+// @Failure      401     {object}  domain.ErrorResponse
+// @Failure      403     {object}  domain.ErrorResponse
+// @Failure      500     {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /fines/user/{userId} [get]
+func (h *FineHandler) ListByUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// Fines are read with the same permission as payments
+	if !authorizeAccess(c, h.authzService, domain.PermPaymentsRead, userID) {
+		return
+	}
+
+	fines, err := h.fineService.ListByUser(userID)
+	if err != nil {
+		h.logger.Error("Failed to list fines by user", zap.Int64("userID", userID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, fines, "Fines retrieved successfully")
+}
+
+// GetByID handles getting a fine by ID
+// @Summary      Get a fine by ID
+// @Description  Retrieve a single fine. Users can only view their own fines and those of their dependents unless they are admins/librarians.
+// @Tags         fines
+// @Produce      json
+// @Param        id   path      int  true  "Fine ID"
+// @Success      200  {object}  domain.Fine
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /fines/{id} [get]
+func (h *FineHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid fine ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid fine ID"))
+		return
+	}
+
+	fine, err := h.fineService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get fine by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	// Check if user is requesting their own fine or may read any payment
+	if !authorizeAccess(c, h.authzService, domain.PermPaymentsRead, fine.UserID) {
+		return
+	}
+
+	SendSuccess(c, fine, "Fine retrieved successfully")
+}
+
+// Waive handles waiving a fine
+// @Summary      Waive a fine
+// @Description  Waive what is left to pay on an outstanding fine. A reason is required and recorded with the librarian who waived the fine.
+// @Tags         fines
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int               true  "Fine ID"
+// @Param        waive  body      WaiveFineRequest  true  "Reason for the waiver"
+// @Success      200    {object}  domain.Fine
+// @Failure      400    {object}  domain.ErrorResponse
+// @Failure      401    {object}  domain.ErrorResponse
+// @Failure      403    {object}  domain.ErrorResponse
+// @Failure      404    {object}  domain.ErrorResponse
+// @Failure      409    {object}  domain.ErrorResponse
+// @Failure      500    {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /fines/{id}/waive [put]
+func (h *FineHandler) Waive(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid fine ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid fine ID"))
+		return
+	}
+
+	var req WaiveFineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	authUserID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	fine, err := h.fineService.Waive(id, authUserID.(int64), req.Reason)
+	if err != nil {
+		h.logger.Error("Failed to waive fine", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, fine, "Fine waived successfully")
+}
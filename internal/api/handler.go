@@ -13,14 +13,25 @@ import (
 
 // Handler is a factory for all API handlers
 type Handler struct {
-	AuthHandler     *AuthHandler
-	UserHandler     *UserHandler
-	CategoryHandler *CategoryHandler
-	BookHandler     *BookHandler
-	RentalHandler   *RentalHandler
-	PaymentHandler  *PaymentHandler
-	ReportHandler   *ReportHandler
-	Logger          *logger.Logger
+	AuthHandler           *AuthHandler
+	UserHandler           *UserHandler
+	CategoryHandler       *CategoryHandler
+	BookHandler           *BookHandler
+	BookCopyHandler       *BookCopyHandler
+	RentalHandler         *RentalHandler
+	ReservationHandler    *ReservationHandler
+	PaymentHandler        *PaymentHandler
+	FineHandler           *FineHandler
+	ReportHandler         *ReportHandler
+	APIKeyHandler         *APIKeyHandler
+	PermissionHandler     *PermissionHandler
+	MembershipPlanHandler *MembershipPlanHandler
+	LoanRuleHandler       *LoanRuleHandler
+	CalendarHandler       *CalendarHandler
+	DataExportHandler     *DataExportHandler
+	UserImportHandler     *UserImportHandler
+	HouseholdHandler      *HouseholdHandler
+	Logger                *logger.Logger
 }
 
 // NewHandler creates a new handler factory
@@ -28,14 +39,25 @@ func NewHandler(services *service.Service, cfg *config.Config, jwtService *auth.
 	handlerLogger := logger.Named("handler")
 
 	return &Handler{
-		AuthHandler:     NewAuthHandler(services.Auth, jwtService, handlerLogger.Named("auth")),
-		UserHandler:     NewUserHandler(services.User, jwtService, handlerLogger.Named("user")),
-		CategoryHandler: NewCategoryHandler(services.Category, jwtService, handlerLogger.Named("category")),
-		BookHandler:     NewBookHandler(services.Book, jwtService, handlerLogger.Named("book")),
-		RentalHandler:   NewRentalHandler(services.Rental, jwtService, handlerLogger.Named("rental")),
-		PaymentHandler:  NewPaymentHandler(services.Payment, jwtService, handlerLogger.Named("payment")),
-		ReportHandler:   NewReportHandler(services.Report, jwtService, handlerLogger.Named("report")),
-		Logger:          handlerLogger,
+		AuthHandler:           NewAuthHandler(services.Auth, jwtService, handlerLogger.Named("auth")),
+		UserHandler:           NewUserHandler(services.User, services.Authz, jwtService, handlerLogger.Named("user")),
+		CategoryHandler:       NewCategoryHandler(services.Category, jwtService, handlerLogger.Named("category")),
+		BookHandler:           NewBookHandler(services.Book, jwtService, handlerLogger.Named("book")),
+		BookCopyHandler:       NewBookCopyHandler(services.BookCopy, handlerLogger.Named("book_copy")),
+		RentalHandler:         NewRentalHandler(services.Rental, services.Authz, jwtService, handlerLogger.Named("rental")),
+		ReservationHandler:    NewReservationHandler(services.Reservation, services.Authz, handlerLogger.Named("reservation")),
+		PaymentHandler:        NewPaymentHandler(services.Payment, services.Authz, jwtService, handlerLogger.Named("payment")),
+		FineHandler:           NewFineHandler(services.Fine, services.Authz, handlerLogger.Named("fine")),
+		ReportHandler:         NewReportHandler(services.Report, jwtService, handlerLogger.Named("report")),
+		APIKeyHandler:         NewAPIKeyHandler(services.APIKey, jwtService, handlerLogger.Named("api_key")),
+		PermissionHandler:     NewPermissionHandler(services.Authz, handlerLogger.Named("permission")),
+		MembershipPlanHandler: NewMembershipPlanHandler(services.MembershipPlan, services.Authz, handlerLogger.Named("membership_plan")),
+		LoanRuleHandler:       NewLoanRuleHandler(services.LoanRule, handlerLogger.Named("loan_rule")),
+		CalendarHandler:       NewCalendarHandler(services.Calendar, handlerLogger.Named("calendar")),
+		DataExportHandler:     NewDataExportHandler(services.DataExport, handlerLogger.Named("data_export")),
+		UserImportHandler:     NewUserImportHandler(services.UserImport, handlerLogger.Named("user_import")),
+		HouseholdHandler:      NewHouseholdHandler(services.Household, services.Authz, handlerLogger.Named("household")),
+		Logger:                handlerLogger,
 	}
 }
 
@@ -46,21 +68,51 @@ func (h *Handler) RegisterRoutes(router *gin.Engine, middleware *Middleware) {
 	{
 		// Auth routes - public endpoints
 		auth := v1.Group("/auth")
+		auth.Use(middleware.RateLimitMiddleware())
 		{
 			auth.POST("/register", h.AuthHandler.Register)
 			auth.POST("/login", h.AuthHandler.Login)
 			auth.POST("/refresh", h.AuthHandler.RefreshToken)
-			auth.POST("/logout", middleware.AuthMiddleware(), h.AuthHandler.Logout)
+			auth.POST("/password/forgot", h.AuthHandler.ForgotPassword)
+			auth.POST("/password/reset", h.AuthHandler.ResetPassword)
+			auth.GET("/verify", h.AuthHandler.VerifyEmail)
+			auth.POST("/verify/resend", middleware.AuthMiddleware(), middleware.NoImpersonation(), h.AuthHandler.ResendVerification)
+			auth.GET("/oidc", h.AuthHandler.OIDCProviders)
+			auth.GET("/oidc/:provider", h.AuthHandler.OIDCLogin)
+			auth.GET("/oidc/:provider/callback", h.AuthHandler.OIDCCallback)
+			auth.POST("/2fa/verify", h.AuthHandler.VerifyMFA)
+			auth.POST("/2fa/enroll", middleware.EnrollmentAuthMiddleware(), middleware.NoImpersonation(), h.AuthHandler.EnrollMFA)
+			auth.POST("/2fa/confirm", middleware.EnrollmentAuthMiddleware(), middleware.NoImpersonation(), h.AuthHandler.ConfirmMFA)
+			auth.POST("/logout", middleware.EnrollmentAuthMiddleware(), h.AuthHandler.Logout)
+			auth.GET("/sessions", middleware.EnrollmentAuthMiddleware(), middleware.NoImpersonation(), h.AuthHandler.ListSessions)
+			auth.DELETE("/sessions/:id", middleware.EnrollmentAuthMiddleware(), middleware.NoImpersonation(), h.AuthHandler.RevokeSession)
 		}
 
 		// User routes - protected endpoints
 		users := v1.Group("/users")
 		users.Use(middleware.AuthMiddleware())
 		{
-			users.GET("", middleware.RoleMiddleware(domain.RoleAdmin), h.UserHandler.List)
-			users.GET("/:id", h.UserHandler.GetByID) // Handler checks if user is requesting their own profile or is admin
-			users.PUT("/:id", h.UserHandler.Update)  // Handler checks if user is updating their own profile or is admin
-			users.DELETE("/:id", middleware.RoleMiddleware(domain.RoleAdmin), h.UserHandler.Delete)
+			users.GET("", middleware.Require(domain.PermUsersRead.Any()), h.UserHandler.List)
+			users.GET("/me/export", middleware.NoImpersonation(), h.DataExportHandler.Export)
+			users.GET("/me/export/:exportId", middleware.NoImpersonation(), h.DataExportHandler.GetByID)
+			users.GET("/:id", h.UserHandler.GetByID) // Handler checks if user is requesting their own profile or may read any profile
+			users.PUT("/:id", h.UserHandler.Update)  // Handler checks if user is updating their own profile or may update any profile
+			users.DELETE("/:id", middleware.Require(domain.PermUsersDelete), h.UserHandler.Delete)
+			users.POST("/:id/unlock", middleware.Require(domain.PermUsersUnlock), h.UserHandler.Unlock)
+			users.POST("/:id/suspend", middleware.Require(domain.PermUsersSuspend), h.UserHandler.Suspend)
+			users.POST("/:id/reactivate", middleware.Require(domain.PermUsersSuspend), h.UserHandler.Reactivate)
+			users.POST("/:id/anonymize", middleware.Require(domain.PermUsersAnonymize), h.UserHandler.Anonymize)
+			users.GET("/:id/membership-plan", h.MembershipPlanHandler.GetUserPlan) // Handler checks if user is requesting their own plan or may read any profile
+			users.PUT("/:id/membership-plan", middleware.Require(domain.PermUsersAssignPlan), h.MembershipPlanHandler.AssignUserPlan)
+			users.GET("/:id/eligibility", h.RentalHandler.Eligibility) // Handler checks if user is checking themselves or may read any rental
+			users.GET("/:id/household", h.HouseholdHandler.GetUserHousehold) // Handler checks if user is requesting their own household or may read any profile
+		}
+
+		// Data export downloads - the token in the link authenticates the request
+		exports := v1.Group("/exports")
+		exports.Use(middleware.RateLimitMiddleware())
+		{
+			exports.GET("/download", h.DataExportHandler.Download)
 		}
 
 		// Category routes
@@ -73,7 +125,7 @@ func (h *Handler) RegisterRoutes(router *gin.Engine, middleware *Middleware) {
 			
 			// Protected endpoints for managing categories
 			categoriesProtected := categories.Group("")
-			categoriesProtected.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(domain.RoleLibrarian))
+			categoriesProtected.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermCategoriesManage))
 			{
 				categoriesProtected.POST("", h.CategoryHandler.Create)
 				categoriesProtected.PUT("/:id", h.CategoryHandler.Update)
@@ -90,59 +142,182 @@ func (h *Handler) RegisterRoutes(router *gin.Engine, middleware *Middleware) {
 			books.GET("/category/:id", h.BookHandler.ListByCategory)
 			books.GET("/:id", h.BookHandler.GetByID)
 			
+			// Reservation queue - members join it, staff review it
+			books.POST("/:id/reservations", middleware.AuthMiddleware(), h.ReservationHandler.Create)
+			books.GET("/:id/reservations", middleware.AuthMiddleware(), middleware.Require(domain.PermReservationsManage.Any()), h.ReservationHandler.ListByBook)
+			
 			// Protected endpoints for managing books
 			booksProtected := books.Group("")
-			booksProtected.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(domain.RoleLibrarian))
+			booksProtected.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermBooksManage))
 			{
 				booksProtected.POST("", h.BookHandler.Create)
 				booksProtected.PUT("/:id", h.BookHandler.Update)
-				booksProtected.PUT("/:id/copies", h.BookHandler.UpdateCopies)
 				booksProtected.DELETE("/:id", h.BookHandler.Delete)
+				booksProtected.GET("/:id/copies", h.BookCopyHandler.ListByBook)
+				booksProtected.POST("/:id/copies", h.BookCopyHandler.Create)
+			}
+		}
+
+		// Book copy routes - staff look up and maintain scanned copies
+		copies := v1.Group("/copies")
+		copies.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermBooksManage))
+		{
+			copies.GET("/:barcode", h.BookCopyHandler.GetByBarcode)
+			copies.PUT("/:barcode", h.BookCopyHandler.Update)
+		}
+
+		// Membership plan routes
+		membershipPlans := v1.Group("/membership-plans")
+		membershipPlans.Use(middleware.AuthMiddleware())
+		{
+			membershipPlans.GET("", h.MembershipPlanHandler.List)
+			membershipPlans.GET("/:id", h.MembershipPlanHandler.GetByID)
+			membershipPlans.POST("", middleware.Require(domain.PermMembershipPlansManage), h.MembershipPlanHandler.Create)
+			membershipPlans.PUT("/:id", middleware.Require(domain.PermMembershipPlansManage), h.MembershipPlanHandler.Update)
+			membershipPlans.DELETE("/:id", middleware.Require(domain.PermMembershipPlansManage), h.MembershipPlanHandler.Delete)
+		}
+
+		// Loan rule routes - librarians preview the rule for a rental, admins manage the rules
+		loanRules := v1.Group("/loan-rules")
+		loanRules.Use(middleware.AuthMiddleware())
+		{
+			loanRules.GET("", middleware.Require(domain.PermLoanRulesRead), h.LoanRuleHandler.List)
+			loanRules.GET("/preview", middleware.Require(domain.PermLoanRulesRead), h.LoanRuleHandler.Preview)
+			loanRules.GET("/:id", middleware.Require(domain.PermLoanRulesRead), h.LoanRuleHandler.GetByID)
+			loanRules.POST("", middleware.Require(domain.PermLoanRulesManage), h.LoanRuleHandler.Create)
+			loanRules.PUT("/:id", middleware.Require(domain.PermLoanRulesManage), h.LoanRuleHandler.Update)
+			loanRules.DELETE("/:id", middleware.Require(domain.PermLoanRulesManage), h.LoanRuleHandler.Delete)
+		}
+
+		// Library calendar routes
+		calendar := v1.Group("/calendar")
+		{
+			// Public endpoints for the opening hours and closures
+			calendar.GET("/hours", h.CalendarHandler.GetOpeningHours)
+			calendar.GET("/closures", h.CalendarHandler.ListClosures)
+			
+			// Protected endpoints for managing the calendar
+			calendarProtected := calendar.Group("")
+			calendarProtected.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermCalendarManage))
+			{
+				calendarProtected.PUT("/hours", h.CalendarHandler.UpdateOpeningHours)
+				calendarProtected.POST("/closures", h.CalendarHandler.CreateClosure)
+				calendarProtected.POST("/closures/import", h.CalendarHandler.ImportClosures)
+				calendarProtected.DELETE("/closures/:id", h.CalendarHandler.DeleteClosure)
 			}
 		}
 
+		// Household routes - staff link guardians to their dependents
+		households := v1.Group("/households")
+		households.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermHouseholdsManage))
+		{
+			households.GET("", h.HouseholdHandler.List)
+			households.GET("/:id", h.HouseholdHandler.GetByID)
+			households.POST("", h.HouseholdHandler.Create)
+			households.PUT("/:id", h.HouseholdHandler.Update)
+			households.DELETE("/:id", h.HouseholdHandler.Delete)
+			households.POST("/:id/dependents", h.HouseholdHandler.AddDependent)
+			households.DELETE("/:id/dependents/:userId", h.HouseholdHandler.RemoveDependent)
+		}
+
 		// Rental routes - all require authentication
 		rentals := v1.Group("/rentals")
 		rentals.Use(middleware.AuthMiddleware())
 		{
-			// Admin/Librarian endpoints
-			rentals.GET("", middleware.RoleMiddleware(domain.RoleLibrarian), h.RentalHandler.List)
+			// Staff endpoints
+			rentals.GET("", middleware.Require(domain.PermRentalsRead.Any()), h.RentalHandler.List)
 			
-			// Member endpoints (handlers check if user is requesting their own rentals or is admin/librarian)
+			// Member endpoints (handlers check if user is acting on their own rentals, their dependents' rentals or may act on any rental)
 			rentals.GET("/user/:userId", h.RentalHandler.ListByUser)
 			rentals.GET("/:id", h.RentalHandler.GetByID)
-			rentals.POST("", h.RentalHandler.Create)
+			rentals.POST("", middleware.Require(domain.PermRentalsCreate), h.RentalHandler.Create)
+			rentals.POST("/checkout", middleware.Require(domain.PermRentalsCheckout), h.RentalHandler.Checkout)
+			rentals.POST("/return", middleware.Require(domain.PermRentalsCheckout), h.RentalHandler.ReturnByBarcode)
 			rentals.PUT("/:id/return", h.RentalHandler.Return)
 			rentals.PUT("/:id/extend", h.RentalHandler.Extend)
 		}
 
+		// Reservation routes - all require authentication
+		reservations := v1.Group("/reservations")
+		reservations.Use(middleware.AuthMiddleware())
+		{
+			// Member endpoints (handlers check if user is acting on their own reservations, their dependents' reservations or may act on any reservation)
+			reservations.GET("/user/:userId", h.ReservationHandler.ListByUser)
+			reservations.GET("/:id", h.ReservationHandler.GetByID)
+			reservations.PUT("/:id/cancel", h.ReservationHandler.Cancel)
+		}
+
 		// Payment routes - all require authentication
 		payments := v1.Group("/payments")
 		payments.Use(middleware.AuthMiddleware())
 		{
-			// Admin/Librarian endpoints
-			payments.GET("", middleware.RoleMiddleware(domain.RoleAdmin), h.PaymentHandler.List)
+			// Staff endpoints
+			payments.GET("", middleware.Require(domain.PermPaymentsList), h.PaymentHandler.List)
 			
-			// Member endpoints (handlers check if user is requesting their own payments or is admin/librarian)
+			// Member endpoints (handlers check if user is requesting their own payments, their dependents' payments or may read any payment)
 			payments.GET("/user/:userId", h.PaymentHandler.ListByUser)
 			payments.GET("/:id", h.PaymentHandler.GetByID)
-			payments.POST("", h.PaymentHandler.Create)
-			payments.POST("/process", h.PaymentHandler.Process)
+			payments.POST("", middleware.Require(domain.PermPaymentsCreate), h.PaymentHandler.Create)
+			payments.POST("/process", middleware.Require(domain.PermPaymentsCreate), h.PaymentHandler.Process)
 			
-			// Admin/Librarian endpoints
-			payments.PUT("/:id/refund", middleware.RoleMiddleware(domain.RoleLibrarian), h.PaymentHandler.Refund)
+			// Staff endpoints
+			payments.PUT("/:id/refund", middleware.Require(domain.PermPaymentsRefund), h.PaymentHandler.Refund)
 		}
 
-		// Report routes - all require authentication and appropriate roles
+		// Fine routes - all require authentication, fines are paid through the payment routes
+		fines := v1.Group("/fines")
+		fines.Use(middleware.AuthMiddleware())
+		{
+			// Staff endpoints
+			fines.GET("", middleware.Require(domain.PermPaymentsList), h.FineHandler.List)
+			fines.PUT("/:id/waive", middleware.Require(domain.PermFinesWaive), h.FineHandler.Waive)
+
+			// Member endpoints (handlers check if user is requesting their own fines, their dependents' fines or may read any payment)
+			fines.GET("/user/:userId", h.FineHandler.ListByUser)
+			fines.GET("/:id", h.FineHandler.GetByID)
+		}
+
+		// Report routes - all require authentication and the matching permission
 		reports := v1.Group("/reports")
 		reports.Use(middleware.AuthMiddleware())
 		{
-			reports.GET("/books/popular", middleware.RoleMiddleware(domain.RoleLibrarian), h.ReportHandler.GetPopularBooks)
-			reports.GET("/revenue", middleware.RoleMiddleware(domain.RoleAdmin), h.ReportHandler.GetRevenueReport)
-			reports.GET("/overdue", middleware.RoleMiddleware(domain.RoleLibrarian), h.ReportHandler.GetOverdueBooks)
+			reports.GET("/books/popular", middleware.Require(domain.PermReportsRead), h.ReportHandler.GetPopularBooks)
+			reports.GET("/revenue", middleware.Require(domain.PermReportsRevenue), h.ReportHandler.GetRevenueReport)
+			reports.GET("/overdue", middleware.Require(domain.PermReportsRead), h.ReportHandler.GetOverdueBooks)
+		}
+
+		// API key routes
+		apiKeys := v1.Group("/api-keys")
+		apiKeys.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermAPIKeysManage))
+		{
+			apiKeys.GET("", h.APIKeyHandler.List)
+			apiKeys.GET("/:id", h.APIKeyHandler.GetByID)
+			apiKeys.POST("", h.APIKeyHandler.Create)
+			apiKeys.DELETE("/:id", h.APIKeyHandler.Revoke)
+		}
+
+		// Admin routes - support tooling
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware())
+		{
+			admin.POST("/users/:id/impersonate", middleware.Require(domain.PermUsersImpersonate), h.AuthHandler.Impersonate)
+			admin.POST("/users/import", middleware.Require(domain.PermUsersImport), h.UserImportHandler.Import)
+			admin.GET("/users/import/:id", middleware.Require(domain.PermUsersImport), h.UserImportHandler.GetByID)
+		}
+
+		// Permission routes - manage which roles may do what
+		permissions := v1.Group("/permissions")
+		permissions.Use(middleware.AuthMiddleware(), middleware.Require(domain.PermPermissionsManage))
+		{
+			permissions.GET("", h.PermissionHandler.List)
+			permissions.POST("/roles/:role", h.PermissionHandler.Grant)
+			permissions.DELETE("/roles/:role/:permission", h.PermissionHandler.Revoke)
 		}
 	}
 
+	// Public token signing keys
+	router.GET("/.well-known/jwks.json", h.AuthHandler.JWKS)
+
 	// Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
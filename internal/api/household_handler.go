@@ -0,0 +1,355 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// HouseholdHandler handles household requests
+type HouseholdHandler struct {
+	householdService domain.HouseholdService
+	authzService     domain.AuthorizationService
+	logger           *logger.Logger
+}
+
+// NewHouseholdHandler creates a new HouseholdHandler
+func NewHouseholdHandler(householdService domain.HouseholdService, authzService domain.AuthorizationService, logger *logger.Logger) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdService: householdService,
+		authzService:     authzService,
+		logger:           logger,
+	}
+}
+
+// CreateHouseholdRequest represents a request to create a household
+type CreateHouseholdRequest struct {
+	Name                 string `json:"name" binding:"required,max=100" example:"The Smiths"`
+	GuardianID           int64  `json:"guardian_id" binding:"required" example:"4"`
+	MaxConcurrentRentals *int   `json:"max_concurrent_rentals" binding:"omitempty,min=1" example:"8"` // Omit for no household limit
+}
+
+// UpdateHouseholdRequest represents a request to update a household
+type UpdateHouseholdRequest struct {
+	Name                 string `json:"name" binding:"required,max=100" example:"The Smiths"`
+	MaxConcurrentRentals *int   `json:"max_concurrent_rentals" binding:"omitempty,min=1" example:"8"` // Omit for no household limit
+}
+
+// AddDependentRequest represents a request to add a dependent to a household
+type AddDependentRequest struct {
+	UserID int64 `json:"user_id" binding:"required" example:"5"`
+}
+
+// List handles listing households
+// @Summary      List households
+// @Description  Get all households with their guardian and dependents
+// @Tags         households
+// @Produce      json
+// @Success      200  {object}  Response{data=[]domain.Household}
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households [get]
+func (h *HouseholdHandler) List(c *gin.Context) {
+	households, err := h.householdService.List()
+	if err != nil {
+		h.logger.Error("Failed to list households", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, households, "Households retrieved successfully")
+}
+
+// GetByID handles getting a household by ID
+// @Summary      Get a household by ID
+// @Description  Retrieve a single household with its guardian and dependents
+// @Tags         households
+// @Produce      json
+// @Param        id   path      int  true  "Household ID"
+// @Success      200  {object}  Response{data=domain.Household}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households/{id} [get]
+func (h *HouseholdHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid household ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid household ID"))
+		return
+	}
+
+	household, err := h.householdService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get household by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, household, "Household retrieved successfully")
+}
+
+// Create handles creating a household
+// @Summary      Create a household
+// @Description  Create a household for a guardian. A user can belong to only one household, as guardian or as dependent.
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Param        household  body      CreateHouseholdRequest  true  "Household object"
+// @Success      201        {object}  Response{data=domain.Household}
+// @Failure      400        {object}  domain.ErrorResponse
+// @Failure      401        {object}  domain.ErrorResponse
+// @Failure      403        {object}  domain.ErrorResponse
+// @Failure      404        {object}  domain.ErrorResponse
+// @Failure      409        {object}  domain.ErrorResponse
+// @Failure      500        {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households [post]
+func (h *HouseholdHandler) Create(c *gin.Context) {
+	var req CreateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	createdBy, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	household := &domain.Household{
+		Name:                 req.Name,
+		GuardianID:           req.GuardianID,
+		MaxConcurrentRentals: req.MaxConcurrentRentals,
+	}
+
+	createdHousehold, err := h.householdService.Create(household, createdBy.(int64))
+	if err != nil {
+		h.logger.Error("Failed to create household", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, createdHousehold, "Household created successfully")
+}
+
+// Update handles updating a household
+// @Summary      Update a household
+// @Description  Update the name and the rental limit shared by everyone in a household
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                     true  "Household ID"
+// @Param        household  body      UpdateHouseholdRequest  true  "Updated household object"
+// @Success      200        {object}  Response{data=domain.Household}
+// @Failure      400        {object}  domain.ErrorResponse
+// @Failure      401        {object}  domain.ErrorResponse
+// @Failure      403        {object}  domain.ErrorResponse
+// @Failure      404        {object}  domain.ErrorResponse
+// @Failure      500        {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households/{id} [put]
+func (h *HouseholdHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid household ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid household ID"))
+		return
+	}
+
+	var req UpdateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	household := &domain.Household{
+		ID:                   id,
+		Name:                 req.Name,
+		MaxConcurrentRentals: req.MaxConcurrentRentals,
+	}
+
+	updatedHousehold, err := h.householdService.Update(household)
+	if err != nil {
+		h.logger.Error("Failed to update household", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, updatedHousehold, "Household updated successfully")
+}
+
+// Delete handles deleting a household
+// @Summary      Delete a household
+// @Description  Delete a household. The guardian loses access to the rentals and payments of the former dependents.
+// @Tags         households
+// @Produce      json
+// @Param        id   path      int  true  "Household ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households/{id} [delete]
+func (h *HouseholdHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid household ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid household ID"))
+		return
+	}
+
+	err = h.householdService.Delete(id)
+	if err != nil {
+		h.logger.Error("Failed to delete household", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Household deleted successfully"})
+}
+
+// AddDependent handles adding a dependent to a household
+// @Summary      Add a household dependent
+// @Description  Link a user to a household so its guardian can act on their rentals and payments
+// @Tags         households
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int                  true  "Household ID"
+// @Param        dependent  body      AddDependentRequest  true  "Dependent to add"
+// @Success      200        {object}  Response{data=domain.Household}
+// @Failure      400        {object}  domain.ErrorResponse
+// @Failure      401        {object}  domain.ErrorResponse
+// @Failure      403        {object}  domain.ErrorResponse
+// @Failure      404        {object}  domain.ErrorResponse
+// @Failure      409        {object}  domain.ErrorResponse
+// @Failure      500        {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households/{id}/dependents [post]
+func (h *HouseholdHandler) AddDependent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid household ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid household ID"))
+		return
+	}
+
+	var req AddDependentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	addedBy, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	household, err := h.householdService.AddDependent(id, req.UserID, addedBy.(int64))
+	if err != nil {
+		h.logger.Error("Failed to add household dependent", zap.Int64("id", id), zap.Int64("userID", req.UserID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, household, "Dependent added successfully")
+}
+
+// RemoveDependent handles removing a dependent from a household
+// @Summary      Remove a household dependent
+// @Description  Unlink a dependent from a household
+// @Tags         households
+// @Produce      json
+// @Param        id      path      int  true  "Household ID"
+// @Param        userId  path      int  true  "User ID of the dependent"
+// @Success      200     {object}  Response{data=domain.Household}
+// @Failure      400     {object}  domain.ErrorResponse
+// @Failure      401     {object}  domain.ErrorResponse
+// @Failure      403     {object}  domain.ErrorResponse
+// @Failure      404     {object}  domain.ErrorResponse
+// @Failure      500     {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /households/{id}/dependents/{userId} [delete]
+func (h *HouseholdHandler) RemoveDependent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid household ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid household ID"))
+		return
+	}
+
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	removedBy, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	household, err := h.householdService.RemoveDependent(id, userID, removedBy.(int64))
+	if err != nil {
+		h.logger.Error("Failed to remove household dependent", zap.Int64("id", id), zap.Int64("userID", userID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, household, "Dependent removed successfully")
+}
+
+// GetUserHousehold handles getting the household of a user
+// @Summary      Get a user's household
+// @Description  Get the household a user is the guardian or a dependent of. Users can only view their own household unless they may read any profile.
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  Response{data=domain.Household}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/household [get]
+func (h *HouseholdHandler) GetUserHousehold(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// Check if user is requesting their own household or may read any profile
+	if !authorizeAccess(c, h.authzService, domain.PermUsersRead, id) {
+		return
+	}
+
+	household, err := h.householdService.GetForUser(id)
+	if err != nil {
+		h.logger.Error("Failed to get household for user", zap.Int64("userID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, household, "Household retrieved successfully")
+}
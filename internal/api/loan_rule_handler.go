@@ -0,0 +1,259 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LoanRuleHandler handles loan rule requests
+type LoanRuleHandler struct {
+	ruleService domain.LoanRuleService
+	logger      *logger.Logger
+}
+
+// NewLoanRuleHandler creates a new LoanRuleHandler
+func NewLoanRuleHandler(ruleService domain.LoanRuleService, logger *logger.Logger) *LoanRuleHandler {
+	return &LoanRuleHandler{
+		ruleService: ruleService,
+		logger:      logger,
+	}
+}
+
+// LoanRuleRequest represents a loan rule request. Omitted criteria match any category, plan or book.
+type LoanRuleRequest struct {
+	Name             string   `json:"name" binding:"required,max=100" example:"Reference books"`
+	CategoryID       *int64   `json:"category_id" example:"3"`
+	MembershipPlanID *int64   `json:"membership_plan_id" example:"2"`
+	BookID           *int64   `json:"book_id"`
+	LoanPeriodDays   int      `json:"loan_period_days" binding:"required,min=1" example:"7"`
+	MaxRenewals      int      `json:"max_renewals" binding:"min=0" example:"1"`
+	FinePerDay       float64  `json:"fine_per_day" binding:"min=0" example:"0.50"`
+	FineCap          *float64 `json:"fine_cap" example:"10.00"`
+	GracePeriodDays  int      `json:"grace_period_days" binding:"min=0" example:"2"`
+}
+
+// List handles listing loan rules
+// @Summary      List loan rules
+// @Description  Get all loan rules, the most specific first. Only admins and librarians can access this endpoint.
+// @Tags         loan-rules
+// @Produce      json
+// @Success      200  {object}  Response{data=[]domain.LoanRule}
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules [get]
+func (h *LoanRuleHandler) List(c *gin.Context) {
+	rules, err := h.ruleService.List()
+	if err != nil {
+		h.logger.Error("Failed to list loan rules", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, rules, "Loan rules retrieved successfully")
+}
+
+// GetByID handles getting a loan rule by ID
+// @Summary      Get a loan rule by ID
+// @Description  Retrieve a single loan rule by its ID. Only admins and librarians can access this endpoint.
+// @Tags         loan-rules
+// @Produce      json
+// @Param        id   path      int  true  "Loan rule ID"
+// @Success      200  {object}  domain.LoanRule
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules/{id} [get]
+func (h *LoanRuleHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid loan rule ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid loan rule ID"))
+		return
+	}
+
+	rule, err := h.ruleService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get loan rule by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, rule, "Loan rule retrieved successfully")
+}
+
+// Preview handles previewing the loan rule that applies to a rental
+// @Summary      Preview the loan rule for a rental
+// @Description  Show which loan rule applies when a user rents a book. When no rule matches, the user's membership plan terms and the default fine rate apply.
+// @Tags         loan-rules
+// @Produce      json
+// @Param        user_id  query     int  true  "User ID"
+// @Param        book_id  query     int  true  "Book ID"
+// @Success      200      {object}  domain.LoanRulePreview
+// @Failure      400      {object}  domain.ErrorResponse
+// @Failure      401      {object}  domain.ErrorResponse
+// @Failure      403      {object}  domain.ErrorResponse
+// @Failure      404      {object}  domain.ErrorResponse
+// @Failure      500      {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules/preview [get]
+func (h *LoanRuleHandler) Preview(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Query("user_id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	bookID, err := strconv.ParseInt(c.Query("book_id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid book ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid book ID"))
+		return
+	}
+
+	preview, err := h.ruleService.Preview(userID, bookID)
+	if err != nil {
+		h.logger.Error("Failed to preview loan rule", zap.Int64("userID", userID), zap.Int64("bookID", bookID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, preview, "Loan rule preview retrieved successfully")
+}
+
+// Create handles creating a loan rule
+// @Summary      Create a loan rule
+// @Description  Create a new loan rule. Only one rule can exist for each combination of category, plan and book.
+// @Tags         loan-rules
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      LoanRuleRequest  true  "Loan rule object"
+// @Success      201   {object}  domain.LoanRule
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      404   {object}  domain.ErrorResponse
+// @Failure      409   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules [post]
+func (h *LoanRuleHandler) Create(c *gin.Context) {
+	var req LoanRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	rule := &domain.LoanRule{}
+	req.applyTo(rule)
+
+	createdRule, err := h.ruleService.Create(rule)
+	if err != nil {
+		h.logger.Error("Failed to create loan rule", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, createdRule, "Loan rule created successfully")
+}
+
+// Update handles updating a loan rule
+// @Summary      Update a loan rule
+// @Description  Update a loan rule. Rentals already out keep their due date, but the rule is looked up again when they are renewed or returned, so the new renewal limit and fine terms apply to them as well as to future rentals.
+// @Tags         loan-rules
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Loan rule ID"
+// @Param        rule  body      LoanRuleRequest  true  "Updated loan rule object"
+// @Success      200   {object}  domain.LoanRule
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      404   {object}  domain.ErrorResponse
+// @Failure      409   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules/{id} [put]
+func (h *LoanRuleHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid loan rule ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid loan rule ID"))
+		return
+	}
+
+	var req LoanRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	rule := &domain.LoanRule{ID: id}
+	req.applyTo(rule)
+
+	updatedRule, err := h.ruleService.Update(rule)
+	if err != nil {
+		h.logger.Error("Failed to update loan rule", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, updatedRule, "Loan rule updated successfully")
+}
+
+// Delete handles deleting a loan rule
+// @Summary      Delete a loan rule
+// @Description  Delete a loan rule. The rentals it matched fall back to less specific rules, including rentals already out when they are renewed or returned.
+// @Tags         loan-rules
+// @Produce      json
+// @Param        id   path      int  true  "Loan rule ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /loan-rules/{id} [delete]
+func (h *LoanRuleHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid loan rule ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid loan rule ID"))
+		return
+	}
+
+	err = h.ruleService.Delete(id)
+	if err != nil {
+		h.logger.Error("Failed to delete loan rule", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Loan rule deleted successfully"})
+}
+
+// applyTo copies the request fields onto a loan rule
+func (r *LoanRuleRequest) applyTo(rule *domain.LoanRule) {
+	rule.Name = r.Name
+	rule.CategoryID = r.CategoryID
+	rule.MembershipPlanID = r.MembershipPlanID
+	rule.BookID = r.BookID
+	rule.LoanPeriodDays = r.LoanPeriodDays
+	rule.MaxRenewals = r.MaxRenewals
+	rule.FinePerDay = r.FinePerDay
+	rule.FineCap = r.FineCap
+	rule.GracePeriodDays = r.GracePeriodDays
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MembershipPlanHandler handles membership plan requests
+type MembershipPlanHandler struct {
+	planService  domain.MembershipPlanService
+	authzService domain.AuthorizationService
+	logger       *logger.Logger
+}
+
+// NewMembershipPlanHandler creates a new MembershipPlanHandler
+func NewMembershipPlanHandler(planService domain.MembershipPlanService, authzService domain.AuthorizationService, logger *logger.Logger) *MembershipPlanHandler {
+	return &MembershipPlanHandler{
+		planService:  planService,
+		authzService: authzService,
+		logger:       logger,
+	}
+}
+
+// MembershipPlanRequest represents a membership plan request
+type MembershipPlanRequest struct {
+	Name                 string  `json:"name" binding:"required,max=50" example:"premium"`
+	Description          string  `json:"description" example:"More books for longer"`
+	MaxConcurrentRentals int     `json:"max_concurrent_rentals" binding:"required,min=1" example:"10"`
+	LoanPeriodDays       int     `json:"loan_period_days" binding:"required,min=1" example:"28"`
+	MaxExtensions        int     `json:"max_extensions" binding:"min=0" example:"3"`
+	MaxExtensionDays     int     `json:"max_extension_days" binding:"min=0" example:"14"`
+	MonthlyFee           float64 `json:"monthly_fee" binding:"min=0" example:"9.99"`
+	IsDefault            bool    `json:"is_default" example:"false"`
+}
+
+// AssignMembershipPlanRequest represents a request to assign a membership plan to a user
+type AssignMembershipPlanRequest struct {
+	PlanID *int64 `json:"plan_id" example:"2"` // Omit or null to assign the default plan
+}
+
+// List handles listing membership plans
+// @Summary      List membership plans
+// @Description  Get all membership plans with their borrowing limits
+// @Tags         membership-plans
+// @Produce      json
+// @Success      200  {object}  Response{data=[]domain.MembershipPlan}
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /membership-plans [get]
+func (h *MembershipPlanHandler) List(c *gin.Context) {
+	plans, err := h.planService.List()
+	if err != nil {
+		h.logger.Error("Failed to list membership plans", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, plans, "Membership plans retrieved successfully")
+}
+
+// GetByID handles getting a membership plan by ID
+// @Summary      Get a membership plan by ID
+// @Description  Retrieve a single membership plan by its ID
+// @Tags         membership-plans
+// @Produce      json
+// @Param        id   path      int  true  "Membership plan ID"
+// @Success      200  {object}  domain.MembershipPlan
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /membership-plans/{id} [get]
+func (h *MembershipPlanHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid membership plan ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid membership plan ID"))
+		return
+	}
+
+	plan, err := h.planService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get membership plan by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, plan, "Membership plan retrieved successfully")
+}
+
+// Create handles creating a membership plan
+// @Summary      Create a membership plan
+// @Description  Create a new membership plan. A new default plan replaces the previous default.
+// @Tags         membership-plans
+// @Accept       json
+// @Produce      json
+// @Param        plan  body      MembershipPlanRequest  true  "Membership plan object"
+// @Success      201   {object}  domain.MembershipPlan
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      409   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /membership-plans [post]
+func (h *MembershipPlanHandler) Create(c *gin.Context) {
+	var req MembershipPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	plan := &domain.MembershipPlan{}
+	req.applyTo(plan)
+
+	createdPlan, err := h.planService.Create(plan)
+	if err != nil {
+		h.logger.Error("Failed to create membership plan", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, createdPlan, "Membership plan created successfully")
+}
+
+// Update handles updating a membership plan
+// @Summary      Update a membership plan
+// @Description  Update a membership plan. New limits apply to future rentals and extensions.
+// @Tags         membership-plans
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Membership plan ID"
+// @Param        plan  body      MembershipPlanRequest  true  "Updated membership plan object"
+// @Success      200   {object}  domain.MembershipPlan
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      404   {object}  domain.ErrorResponse
+// @Failure      409   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /membership-plans/{id} [put]
+func (h *MembershipPlanHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid membership plan ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid membership plan ID"))
+		return
+	}
+
+	var req MembershipPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	plan := &domain.MembershipPlan{ID: id}
+	req.applyTo(plan)
+
+	updatedPlan, err := h.planService.Update(plan)
+	if err != nil {
+		h.logger.Error("Failed to update membership plan", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, updatedPlan, "Membership plan updated successfully")
+}
+
+// Delete handles deleting a membership plan
+// @Summary      Delete a membership plan
+// @Description  Delete a membership plan. Its members move to the default plan.
+// @Tags         membership-plans
+// @Produce      json
+// @Param        id   path      int  true  "Membership plan ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /membership-plans/{id} [delete]
+func (h *MembershipPlanHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid membership plan ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid membership plan ID"))
+		return
+	}
+
+	err = h.planService.Delete(id)
+	if err != nil {
+		h.logger.Error("Failed to delete membership plan", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Membership plan deleted successfully"})
+}
+
+// GetUserPlan handles getting the membership plan of a user
+// @Summary      Get a user's membership plan
+// @Description  Get the membership plan whose limits apply to a user. Users can only view their own plan unless they may read any profile.
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  domain.MembershipPlan
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/membership-plan [get]
+func (h *MembershipPlanHandler) GetUserPlan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// Check if user is requesting their own plan or may read any profile
+	if !authorizeAccess(c, h.authzService, domain.PermUsersRead, id) {
+		return
+	}
+
+	plan, err := h.planService.GetForUser(id)
+	if err != nil {
+		h.logger.Error("Failed to get membership plan for user", zap.Int64("userID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, plan, "Membership plan retrieved successfully")
+}
+
+// AssignUserPlan handles assigning a membership plan to a user
+// @Summary      Assign a membership plan
+// @Description  Assign a membership plan to a user. Omitting plan_id moves the user to the default plan.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                          true  "User ID"
+// @Param        plan  body      AssignMembershipPlanRequest  true  "Plan to assign"
+// @Success      200   {object}  domain.MembershipPlan
+// @Failure      400   {object}  domain.ErrorResponse
+// @Failure      401   {object}  domain.ErrorResponse
+// @Failure      403   {object}  domain.ErrorResponse
+// @Failure      404   {object}  domain.ErrorResponse
+// @Failure      500   {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/membership-plan [put]
+func (h *MembershipPlanHandler) AssignUserPlan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	var req AssignMembershipPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	assignedBy, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	plan, err := h.planService.AssignToUser(id, req.PlanID, assignedBy.(int64))
+	if err != nil {
+		h.logger.Error("Failed to assign membership plan", zap.Int64("userID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, plan, "Membership plan assigned successfully")
+}
+
+// applyTo copies the request fields onto a membership plan
+func (r *MembershipPlanRequest) applyTo(plan *domain.MembershipPlan) {
+	plan.Name = r.Name
+	plan.Description = r.Description
+	plan.MaxConcurrentRentals = r.MaxConcurrentRentals
+	plan.LoanPeriodDays = r.LoanPeriodDays
+	plan.MaxExtensions = r.MaxExtensions
+	plan.MaxExtensionDays = r.MaxExtensionDays
+	plan.MonthlyFee = r.MonthlyFee
+	plan.IsDefault = r.IsDefault
+}
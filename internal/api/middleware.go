@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -8,7 +9,9 @@ import (
 	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/service"
 	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/config"
 	"github.com/SimpleBookRental/backend/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -32,26 +35,32 @@ type IPLimit struct {
 
 // Middleware holds all middleware handlers
 type Middleware struct {
-	jwtService  *auth.JWTService
-	logger      *logger.Logger
-	rateLimiter *RateLimiter
+	jwtService    *auth.JWTService
+	authService   service.AuthService
+	apiKeyService domain.APIKeyService
+	authzService  domain.AuthorizationService
+	logger        *logger.Logger
+	rateLimiter   *RateLimiter
 }
 
 // NewMiddleware creates a new Middleware
-func NewMiddleware(jwtService *auth.JWTService, logger *logger.Logger) *Middleware {
-	// Create rate limiter: 100 requests per minute
+func NewMiddleware(jwtService *auth.JWTService, authService service.AuthService, apiKeyService domain.APIKeyService, authzService domain.AuthorizationService, rateLimit config.RateLimitConfig, logger *logger.Logger) *Middleware {
+	// Create rate limiter from configuration
 	rateLimiter := &RateLimiter{
 		limits:     make(map[string]*IPLimit),
-		rate:       100,
-		window:     time.Minute,
+		rate:       rateLimit.Requests,
+		window:     rateLimit.Duration,
 		lastClean:  time.Now(),
 		cleanEvery: 5 * time.Minute,
 	}
 
 	return &Middleware{
-		jwtService:  jwtService,
-		logger:      logger,
-		rateLimiter: rateLimiter,
+		jwtService:    jwtService,
+		authService:   authService,
+		apiKeyService: apiKeyService,
+		authzService:  authzService,
+		logger:        logger,
+		rateLimiter:   rateLimiter,
 	}
 }
 
@@ -68,13 +77,23 @@ func (m *Middleware) LoggerMiddleware() gin.HandlerFunc {
 		statusCode := c.Writer.Status()
 		clientIP := c.ClientIP()
 		
-		m.logger.Info("API Request",
+		fields := []zap.Field{
 			zap.String("method", method),
 			zap.String("path", path),
 			zap.Int("status", statusCode),
 			zap.String("ip", clientIP),
 			zap.Duration("latency", latency),
-		)
+		}
+
+		// Requests made under impersonation are attributed to the admin really making them
+		if actorID, ok := c.Get("actorID"); ok {
+			fields = append(fields,
+				zap.String("event", "impersonated_request"),
+				zap.Int64("actorID", actorID.(int64)),
+				zap.Int64("userID", c.GetInt64("userID")))
+		}
+
+		m.logger.Info("API Request", fields...)
 	}
 }
 
@@ -98,7 +117,7 @@ func (m *Middleware) CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key")
 		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
@@ -114,7 +133,24 @@ func (m *Middleware) CORSMiddleware() gin.HandlerFunc {
 
 // AuthMiddleware checks if the user is authenticated
 func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
+	return m.authenticate(true)
+}
+
+// EnrollmentAuthMiddleware authenticates like AuthMiddleware but also admits staff who have not
+// set up mandatory two-factor authentication yet, so they can enroll or log out
+func (m *Middleware) EnrollmentAuthMiddleware() gin.HandlerFunc {
+	return m.authenticate(false)
+}
+
+// authenticate validates the bearer token or API key and stores the caller in the context
+func (m *Middleware) authenticate(enforceMFA bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Integrations authenticate with an API key instead of a user token
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			m.authenticateAPIKey(c, apiKey)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
@@ -138,46 +174,191 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Token is valid, add user ID and role to context
-		c.Set("userId", claims.UserID)
+		// Refresh and MFA tokens cannot be used to call the API
+		if claims.TokenType != auth.AccessToken {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token type"})
+			return
+		}
+
+		// Reject tokens that were revoked on logout
+		revoked, err := m.authService.IsRevoked(claims)
+		if err != nil {
+			m.logger.Error("Failed to check token revocation", zap.Error(err))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify token"})
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+			return
+		}
+
+		if enforceMFA && !claims.MFA && m.authService.MFARequired(domain.UserRole(claims.Role)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication must be enabled for this account"})
+			return
+		}
+
+		// Token is valid, add user ID, role and session to context
+		c.Set("userID", claims.UserID)
 		c.Set("userRole", claims.Role)
+		c.Set("sessionID", claims.SessionID)
+		if claims.Actor != nil {
+			c.Set("actorID", claims.Actor.UserID)
+		}
 
 		c.Next()
 	}
 }
 
-// RoleMiddleware checks if the user has the required role
-func (m *Middleware) RoleMiddleware(role domain.UserRole) gin.HandlerFunc {
+// authenticateAPIKey validates an API key, checks that its scopes cover the route
+// and stores the key's owner in the context. Keys have no second factor, so keys of owners
+// who must use two-factor authentication can only read, even if their role changed after
+// the key was issued. Email verification is checked by the services for the owner, as
+// with a token login.
+func (m *Middleware) authenticateAPIKey(c *gin.Context, rawKey string) {
+	key, err := m.apiKeyService.Authenticate(rawKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAPIKey) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			return
+		}
+		m.logger.Error("Failed to authenticate API key", zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify API key"})
+		return
+	}
+
+	scope := requiredScope(c)
+	if scope == "" || !key.HasScope(scope) {
+		m.logger.Warn("API key used outside its scopes",
+			zap.Int64("apiKeyID", key.ID), zap.String("scope", scope), zap.String("path", c.FullPath()))
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key does not have the required scope"})
+		return
+	}
+
+	if strings.HasSuffix(scope, ":"+domain.ScopeWrite) && m.authService.MFARequired(key.UserRole) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API keys of accounts that must use two-factor authentication can only read"})
+		return
+	}
+
+	// Same context values as a token login, so handlers treat the key as its owner
+	c.Set("userID", key.UserID)
+	c.Set("userRole", string(key.UserRole))
+	c.Set("sessionID", int64(0))
+	c.Set("apiKeyID", key.ID)
+
+	c.Next()
+}
+
+// requiredScope returns the API key scope needed for the matched route, or an empty
+// string for routes API keys cannot use
+func requiredScope(c *gin.Context) string {
+	path := strings.TrimPrefix(c.FullPath(), "/api/v1/")
+	resource, _, _ := strings.Cut(path, "/")
+
+	known := false
+	for _, r := range domain.APIKeyResources {
+		if r == resource {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return ""
+	}
+
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		return resource + ":" + domain.ScopeRead
+	}
+	return resource + ":" + domain.ScopeWrite
+}
+
+// Require checks if the authenticated user's role has been granted a permission
+func (m *Middleware) Require(permission domain.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get("userRole")
-		if !exists {
+		subject, ok := subjectFromContext(c)
+		if !ok {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			return
 		}
 
-		// Check role
-		switch role {
-		case domain.RoleAdmin:
-			if userRole != string(domain.RoleAdmin) {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-				return
-			}
-		case domain.RoleLibrarian:
-			if userRole != string(domain.RoleAdmin) && userRole != string(domain.RoleLibrarian) {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Librarian access required"})
-				return
-			}
-		case domain.RoleMember:
-			if userRole != string(domain.RoleAdmin) && userRole != string(domain.RoleLibrarian) && userRole != string(domain.RoleMember) {
-				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Member access required"})
-				return
-			}
+		if !m.authzService.Can(subject, permission) {
+			m.logger.Warn("Permission denied",
+				zap.String("event", "permission_denied"),
+				zap.Int64("userID", subject.UserID),
+				zap.String("permission", string(permission)),
+				zap.String("path", c.FullPath()))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Permission denied"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NoImpersonation refuses requests made with an impersonation token, for routes that change how
+// the impersonated user signs in or hand out their data
+func (m *Middleware) NoImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actorID, impersonating := c.Get("actorID"); impersonating {
+			m.logger.Warn("Refused request made while impersonating",
+				zap.String("event", "impersonation_denied"),
+				zap.Int64("actorID", actorID.(int64)),
+				zap.Int64("userID", c.GetInt64("userID")),
+				zap.String("path", c.FullPath()))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": domain.ErrImpersonationForbidden.Error()})
+			return
 		}
 
 		c.Next()
 	}
 }
 
+// subjectFromContext returns the authenticated user stored in the context by the auth middleware
+func subjectFromContext(c *gin.Context) (domain.Subject, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		return domain.Subject{}, false
+	}
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		return domain.Subject{}, false
+	}
+	return domain.Subject{UserID: userID.(int64), Role: domain.UserRole(userRole.(string))}, true
+}
+
+// authorizeAccess checks if the authenticated user may act on a resource owned by ownerID
+// and sends the error response when they may not
+func authorizeAccess(c *gin.Context, authz domain.AuthorizationService, permission domain.ScopedPermission, ownerID int64) bool {
+	subject, ok := subjectFromContext(c)
+	if !ok {
+		SendError(c, domain.ErrUnauthorized)
+		return false
+	}
+	if !authz.CanAccess(subject, permission, ownerID) {
+		SendError(c, domain.ErrForbidden)
+		return false
+	}
+	return true
+}
+
+// actingFor returns the user a rental or payment is made for: the authenticated user, or the
+// requested user when the authenticated user is their guardian. It sends the error response
+// when the authenticated user may not act for the requested user.
+func actingFor(c *gin.Context, authz domain.AuthorizationService, requestedUserID *int64) (int64, bool) {
+	subject, ok := subjectFromContext(c)
+	if !ok {
+		SendError(c, domain.ErrUnauthorized)
+		return 0, false
+	}
+	if requestedUserID == nil {
+		return subject.UserID, true
+	}
+	if !authz.CanActFor(subject, *requestedUserID) {
+		SendError(c, domain.NewForbiddenError("only a household guardian may act for another user"))
+		return 0, false
+	}
+	return *requestedUserID, true
+}
+
 // RateLimitMiddleware limits the number of requests
 func (m *Middleware) RateLimitMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -13,14 +13,16 @@ import (
 // PaymentHandler handles payment requests
 type PaymentHandler struct {
 	paymentService domain.PaymentService
+	authzService   domain.AuthorizationService
 	jwtService     *auth.JWTService
 	logger         *logger.Logger
 }
 
 // NewPaymentHandler creates a new PaymentHandler
-func NewPaymentHandler(paymentService domain.PaymentService, jwtService *auth.JWTService, logger *logger.Logger) *PaymentHandler {
+func NewPaymentHandler(paymentService domain.PaymentService, authzService domain.AuthorizationService, jwtService *auth.JWTService, logger *logger.Logger) *PaymentHandler {
 	return &PaymentHandler{
 		paymentService: paymentService,
+		authzService:   authzService,
 		jwtService:     jwtService,
 		logger:         logger,
 	}
@@ -28,14 +30,16 @@ func NewPaymentHandler(paymentService domain.PaymentService, jwtService *auth.JW
 
 // PaymentRequest represents a payment request
 type PaymentRequest struct {
+	UserID        *int64  `json:"user_id" example:"5"` // Pay for a dependent of the authenticated guardian, omit to pay for yourself
 	RentalID      *int64  `json:"rental_id" example:"1"`
+	FineID        *int64  `json:"fine_id" example:"3"` // Pay towards a fine, the payment is linked to the fined rental
 	Amount        float64 `json:"amount" binding:"required,gt=0" example:"15.50"`
 	PaymentMethod string  `json:"payment_method" binding:"required" example:"credit_card"`
 }
 
 // GetByID handles getting a payment by ID
 // @Summary      Get a payment by ID
-// @Description  Retrieve a single payment by its ID. Users can only view their own payments unless they are admins/librarians.
+// @Description  Retrieve a single payment by its ID. Users can only view their own payments and those of their dependents unless they are admins/librarians.
 // @Tags         payments
 // @Accept       json
 // @Produce      json
@@ -63,18 +67,8 @@ func (h *PaymentHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	// Check if user is requesting their own payment or is an admin/librarian
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if userID.(int64) != payment.UserID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is requesting their own payment or may read any payment
+	if !authorizeAccess(c, h.authzService, domain.PermPaymentsRead, payment.UserID) {
 		return
 	}
 
@@ -96,19 +90,6 @@ func (h *PaymentHandler) GetByID(c *gin.Context) {
 // @Security     Bearer
 // @Router       /payments [get]
 func (h *PaymentHandler) List(c *gin.Context) {
-	// Only admins and librarians can list all payments
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
@@ -124,7 +105,7 @@ func (h *PaymentHandler) List(c *gin.Context) {
 
 // ListByUser handles listing payments for a specific user with pagination
 // @Summary      List user payments
-// @Description  Get a paginated list of payments for a specific user. Users can only view their own payments unless they are admins/librarians.
+// @Description  Get a paginated list of payments for a specific user. Users can only view their own payments and those of their dependents unless they are admins/librarians.
 // @Tags         payments
 // @Accept       json
 // @Produce      json
@@ -146,18 +127,8 @@ func (h *PaymentHandler) ListByUser(c *gin.Context) {
 		return
 	}
 
-	// Check if user is requesting their own payments or is an admin/librarian
-	currentUserID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if currentUserID.(int64) != userID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is requesting their own payments or may read any user's payments
+	if !authorizeAccess(c, h.authzService, domain.PermPaymentsRead, userID) {
 		return
 	}
 
@@ -176,7 +147,7 @@ func (h *PaymentHandler) ListByUser(c *gin.Context) {
 
 // Create handles creating a payment
 // @Summary      Create a payment
-// @Description  Create a new payment for the authenticated user
+// @Description  Create a new payment for the authenticated user, or for one of their dependents when they are a household guardian
 // @Tags         payments
 // @Accept       json
 // @Produce      json
@@ -184,17 +155,11 @@ func (h *PaymentHandler) ListByUser(c *gin.Context) {
 // @Success      201    {object}   domain.Payment
 // @Failure      400    {object}   domain.ErrorResponse
 // @Failure      401    {object}   domain.ErrorResponse
+// @Failure      403    {object}   domain.ErrorResponse
 // @Failure      500    {object}   domain.ErrorResponse
 // @Security     Bearer
 // @Router       /payments [post]
 func (h *PaymentHandler) Create(c *gin.Context) {
-	// Get user ID from context
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
 	var req PaymentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
@@ -202,9 +167,16 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// Pay for the authenticated user or a dependent they are the guardian of
+	userID, ok := actingFor(c, h.authzService, req.UserID)
+	if !ok {
+		return
+	}
+
 	payment := &domain.Payment{
-		UserID:        userID.(int64),
+		UserID:        userID,
 		RentalID:      req.RentalID,
+		FineID:        req.FineID,
 		Amount:        req.Amount,
 		PaymentMethod: req.PaymentMethod,
 		Status:        domain.PaymentStatusPending,
@@ -222,7 +194,7 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 
 // Process handles processing a payment
 // @Summary      Process a payment
-// @Description  Process a payment transaction
+// @Description  Process a payment transaction for the authenticated user, or for one of their dependents when they are a household guardian
 // @Tags         payments
 // @Accept       json
 // @Produce      json
@@ -230,17 +202,11 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 // @Success      200    {object}   domain.Payment
 // @Failure      400    {object}   domain.ErrorResponse
 // @Failure      401    {object}   domain.ErrorResponse
+// @Failure      403    {object}   domain.ErrorResponse
 // @Failure      500    {object}   domain.ErrorResponse
 // @Security     Bearer
 // @Router       /payments/process [post]
 func (h *PaymentHandler) Process(c *gin.Context) {
-	// Get user ID from context
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
 	var req PaymentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
@@ -248,9 +214,16 @@ func (h *PaymentHandler) Process(c *gin.Context) {
 		return
 	}
 
+	// Pay for the authenticated user or a dependent they are the guardian of
+	userID, ok := actingFor(c, h.authzService, req.UserID)
+	if !ok {
+		return
+	}
+
 	payment := &domain.Payment{
-		UserID:        userID.(int64),
+		UserID:        userID,
 		RentalID:      req.RentalID,
+		FineID:        req.FineID,
 		Amount:        req.Amount,
 		PaymentMethod: req.PaymentMethod,
 		Status:        domain.PaymentStatusPending,
@@ -289,19 +262,6 @@ func (h *PaymentHandler) Refund(c *gin.Context) {
 		return
 	}
 
-	// Only admins and librarians can refund payments
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	refundedPayment, err := h.paymentService.RefundPayment(id)
 	if err != nil {
 		h.logger.Error("Failed to refund payment", zap.Int64("id", id), zap.Error(err))
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PermissionHandler handles role permission management requests
+type PermissionHandler struct {
+	authzService domain.AuthorizationService
+	logger       *logger.Logger
+}
+
+// NewPermissionHandler creates a new PermissionHandler
+func NewPermissionHandler(authzService domain.AuthorizationService, logger *logger.Logger) *PermissionHandler {
+	return &PermissionHandler{
+		authzService: authzService,
+		logger:       logger,
+	}
+}
+
+// GrantPermissionRequest represents a request to grant a permission to a role
+type GrantPermissionRequest struct {
+	Permission domain.Permission `json:"permission" binding:"required" example:"reports:revenue"`
+}
+
+// PermissionsResponse lists every known permission and the permissions granted to each role
+type PermissionsResponse struct {
+	Permissions []domain.Permission                     `json:"permissions"`
+	Roles       map[domain.UserRole][]domain.Permission `json:"roles"`
+}
+
+// List handles listing the permission policy
+// @Summary      List permissions
+// @Description  List every known permission and the permissions granted to each role
+// @Tags         permissions
+// @Produce      json
+// @Success      200  {object}  Response{data=PermissionsResponse}
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /permissions [get]
+func (h *PermissionHandler) List(c *gin.Context) {
+	roles, err := h.authzService.ListRolePermissions()
+	if err != nil {
+		h.logger.Error("Failed to list role permissions", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, PermissionsResponse{Permissions: domain.AllPermissions(), Roles: roles}, "Permissions retrieved successfully")
+}
+
+// Grant handles granting a permission to a role
+// @Summary      Grant a permission
+// @Description  Grant a permission to a role. Takes effect for every user with the role.
+// @Tags         permissions
+// @Accept       json
+// @Produce      json
+// @Param        role        path      string                  true  "Role"
+// @Param        permission  body      GrantPermissionRequest  true  "Permission to grant"
+// @Success      200         {object}  map[string]string
+// @Failure      400         {object}  domain.ErrorResponse
+// @Failure      401         {object}  domain.ErrorResponse
+// @Failure      403         {object}  domain.ErrorResponse
+// @Failure      500         {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /permissions/roles/{role} [post]
+func (h *PermissionHandler) Grant(c *gin.Context) {
+	var req GrantPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	role := domain.UserRole(c.Param("role"))
+	err := h.authzService.Grant(role, req.Permission, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to grant permission", zap.String("role", string(role)), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Permission granted successfully"})
+}
+
+// Revoke handles revoking a permission from a role
+// @Summary      Revoke a permission
+// @Description  Revoke a permission from a role. The admin role cannot lose the permission to manage permissions.
+// @Tags         permissions
+// @Produce      json
+// @Param        role        path      string  true  "Role"
+// @Param        permission  path      string  true  "Permission"
+// @Success      200         {object}  map[string]string
+// @Failure      400         {object}  domain.ErrorResponse
+// @Failure      401         {object}  domain.ErrorResponse
+// @Failure      403         {object}  domain.ErrorResponse
+// @Failure      404         {object}  domain.ErrorResponse
+// @Failure      500         {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /permissions/roles/{role}/{permission} [delete]
+func (h *PermissionHandler) Revoke(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	role := domain.UserRole(c.Param("role"))
+	permission := domain.Permission(c.Param("permission"))
+	err := h.authzService.Revoke(role, permission, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to revoke permission", zap.String("role", string(role)), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Permission revoked successfully"})
+}
@@ -14,14 +14,16 @@ import (
 // RentalHandler handles rental requests
 type RentalHandler struct {
 	rentalService domain.RentalService
+	authzService  domain.AuthorizationService
 	jwtService    *auth.JWTService
 	logger        *logger.Logger
 }
 
 // NewRentalHandler creates a new RentalHandler
-func NewRentalHandler(rentalService domain.RentalService, jwtService *auth.JWTService, logger *logger.Logger) *RentalHandler {
+func NewRentalHandler(rentalService domain.RentalService, authzService domain.AuthorizationService, jwtService *auth.JWTService, logger *logger.Logger) *RentalHandler {
 	return &RentalHandler{
 		rentalService: rentalService,
+		authzService:  authzService,
 		jwtService:    jwtService,
 		logger:        logger,
 	}
@@ -29,11 +31,24 @@ func NewRentalHandler(rentalService domain.RentalService, jwtService *auth.JWTSe
 
 // RentalRequest represents a rental request
 type RentalRequest struct {
+	UserID     *int64    `json:"user_id" example:"5"` // Rent for a dependent of the authenticated guardian, omit to rent for yourself
 	BookID     int64     `json:"book_id" binding:"required" example:"1"`
 	RentalDate time.Time `json:"rental_date" example:"2025-05-01T10:00:00Z"`
 	DueDate    time.Time `json:"due_date" example:"2025-05-15T10:00:00Z"`
 }
 
+// CheckoutRequest represents a request to lend a copy by its barcode
+type CheckoutRequest struct {
+	Barcode string    `json:"barcode" binding:"required" example:"BK000001-001"`
+	UserID  int64     `json:"user_id" binding:"required" example:"5"`
+	DueDate time.Time `json:"due_date" example:"2025-05-15T10:00:00Z"`
+}
+
+// ReturnByBarcodeRequest represents a request to return a copy by its barcode
+type ReturnByBarcodeRequest struct {
+	Barcode string `json:"barcode" binding:"required" example:"BK000001-001"`
+}
+
 // ExtendRentalRequest represents a rental extension request
 type ExtendRentalRequest struct {
 	Days int `json:"days" binding:"required,min=1" example:"7"`
@@ -41,7 +56,7 @@ type ExtendRentalRequest struct {
 
 // GetByID handles getting a rental by ID
 // @Summary      Get a rental by ID
-// @Description  Retrieve a single rental by its ID. Users can only view their own rentals unless they are admins/librarians.
+// @Description  Retrieve a single rental by its ID. Users can only view their own rentals and those of their dependents unless they are admins/librarians.
 // @Tags         rentals
 // @Accept       json
 // @Produce      json
@@ -69,18 +84,8 @@ func (h *RentalHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	// Check if user is requesting their own rental or is an admin/librarian
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if userID.(int64) != rental.UserID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is requesting their own rental or may act on any rental
+	if !authorizeAccess(c, h.authzService, domain.PermRentalsRead, rental.UserID) {
 		return
 	}
 
@@ -102,19 +107,6 @@ func (h *RentalHandler) GetByID(c *gin.Context) {
 // @Security     Bearer
 // @Router       /rentals [get]
 func (h *RentalHandler) List(c *gin.Context) {
-	// Only admins and librarians can list all rentals
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
@@ -130,7 +122,7 @@ func (h *RentalHandler) List(c *gin.Context) {
 
 // ListByUser handles listing rentals for a specific user with pagination
 // @Summary      List user rentals
-// @Description  Get a paginated list of rentals for a specific user. Users can only view their own rentals unless they are admins/librarians.
+// @Description  Get a paginated list of rentals for a specific user. Users can only view their own rentals and those of their dependents unless they are admins/librarians.
 // @Tags         rentals
 // @Accept       json
 // @Produce      json
@@ -152,18 +144,8 @@ func (h *RentalHandler) ListByUser(c *gin.Context) {
 		return
 	}
 
-	// Check if user is requesting their own rentals or is an admin/librarian
-	currentUserID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if currentUserID.(int64) != userID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is requesting their own rentals or may read any user's rentals
+	if !authorizeAccess(c, h.authzService, domain.PermRentalsRead, userID) {
 		return
 	}
 
@@ -182,7 +164,7 @@ func (h *RentalHandler) ListByUser(c *gin.Context) {
 
 // Create handles creating a rental
 // @Summary      Create a rental
-// @Description  Create a new book rental for the authenticated user
+// @Description  Create a new book rental for the authenticated user, or for one of their dependents when they are a household guardian
 // @Tags         rentals
 // @Accept       json
 // @Produce      json
@@ -190,18 +172,12 @@ func (h *RentalHandler) ListByUser(c *gin.Context) {
 // @Success      201   {object}   domain.Rental
 // @Failure      400   {object}   domain.ErrorResponse
 // @Failure      401   {object}   domain.ErrorResponse
+// @Failure      403   {object}   domain.ErrorResponse
 // @Failure      404   {object}   domain.ErrorResponse
 // @Failure      500   {object}   domain.ErrorResponse
 // @Security     Bearer
 // @Router       /rentals [post]
 func (h *RentalHandler) Create(c *gin.Context) {
-	// Get user ID from context
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
 	var req RentalRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("Invalid request body", zap.Error(err))
@@ -209,8 +185,14 @@ func (h *RentalHandler) Create(c *gin.Context) {
 		return
 	}
 
+	// Rent for the authenticated user or a dependent they are the guardian of
+	userID, ok := actingFor(c, h.authzService, req.UserID)
+	if !ok {
+		return
+	}
+
 	rental := &domain.Rental{
-		UserID:     userID.(int64),
+		UserID:     userID,
 		BookID:     req.BookID,
 		RentalDate: req.RentalDate,
 		DueDate:    req.DueDate,
@@ -229,7 +211,7 @@ func (h *RentalHandler) Create(c *gin.Context) {
 
 // Return handles returning a rental
 // @Summary      Return a rental
-// @Description  Return a rented book, calculates any late fees if applicable
+// @Description  Return a rented book. A book returned late is fined: the fine is recorded and its amount returned as late_fee.
 // @Tags         rentals
 // @Accept       json
 // @Produce      json
@@ -258,41 +240,101 @@ func (h *RentalHandler) Return(c *gin.Context) {
 		return
 	}
 
-	// Check if user is returning their own rental or is an admin/librarian
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
+	// Check if user is returning their own rental or may act on any rental
+	if !authorizeAccess(c, h.authzService, domain.PermRentalsReturn, rental.UserID) {
 		return
 	}
 
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
+	returnedRental, fine, err := h.rentalService.Return(id)
+	if err != nil {
+		h.logger.Error("Failed to return rental", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
 
-	if userID.(int64) != rental.UserID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	SendSuccess(c, returnResponse(returnedRental, fine), "Rental returned successfully")
+}
+
+// Checkout handles lending a copy by its barcode
+// @Summary      Check out a copy
+// @Description  Lend the copy with the scanned barcode to a user at the circulation desk
+// @Tags         rentals
+// @Accept       json
+// @Produce      json
+// @Param        checkout  body      CheckoutRequest  true  "Copy and borrower"
+// @Success      201       {object}  domain.Rental
+// @Failure      400       {object}  domain.ErrorResponse
+// @Failure      401       {object}  domain.ErrorResponse
+// @Failure      403       {object}  domain.ErrorResponse
+// @Failure      404       {object}  domain.ErrorResponse
+// @Failure      409       {object}  domain.ErrorResponse
+// @Failure      500       {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /rentals/checkout [post]
+func (h *RentalHandler) Checkout(c *gin.Context) {
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
 		return
 	}
 
-	returnedRental, err := h.rentalService.Return(id)
+	rental, err := h.rentalService.Checkout(req.Barcode, req.UserID, req.DueDate)
 	if err != nil {
-		h.logger.Error("Failed to return rental", zap.Int64("id", id), zap.Error(err))
+		h.logger.Error("Failed to check out book copy", zap.String("barcode", req.Barcode), zap.Error(err))
 		SendError(c, err)
 		return
 	}
 
-	// Calculate late fee if any
-	lateFee, err := h.rentalService.CalculateLateFee(returnedRental)
+	SendCreated(c, rental, "Book copy checked out successfully")
+}
+
+// ReturnByBarcode handles returning a copy by its barcode
+// @Summary      Return a copy
+// @Description  Return the open rental of the copy with the scanned barcode. A book returned late is fined: the fine is recorded and its amount returned as late_fee.
+// @Tags         rentals
+// @Accept       json
+// @Produce      json
+// @Param        return  body      ReturnByBarcodeRequest  true  "Returned copy"
+// @Success      200     {object}  map[string]interface{}
+// @Failure      400     {object}  domain.ErrorResponse
+// @Failure      401     {object}  domain.ErrorResponse
+// @Failure      403     {object}  domain.ErrorResponse
+// @Failure      404     {object}  domain.ErrorResponse
+// @Failure      500     {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /rentals/return [post]
+func (h *RentalHandler) ReturnByBarcode(c *gin.Context) {
+	var req ReturnByBarcodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
+		return
+	}
+
+	returnedRental, fine, err := h.rentalService.ReturnByBarcode(req.Barcode)
 	if err != nil {
-		h.logger.Error("Failed to calculate late fee", zap.Int64("id", id), zap.Error(err))
-		// Continue anyway, just log the error
+		h.logger.Error("Failed to return book copy", zap.String("barcode", req.Barcode), zap.Error(err))
+		SendError(c, err)
+		return
 	}
 
+	SendSuccess(c, returnResponse(returnedRental, fine), "Book copy returned successfully")
+}
+
+// returnResponse builds the response to a return with the late fee assessed for it, if any
+func returnResponse(rental *domain.Rental, fine *domain.Fine) gin.H {
 	response := gin.H{
-		"rental":   returnedRental,
-		"late_fee": lateFee,
+		"rental":   rental,
+		"late_fee": 0.0,
 	}
 
-	SendSuccess(c, response, "Rental returned successfully")
+	if fine != nil {
+		response["late_fee"] = fine.Amount
+		response["fine"] = fine
+	}
+
+	return response
 }
 
 // Extend handles extending a rental
@@ -327,18 +369,8 @@ func (h *RentalHandler) Extend(c *gin.Context) {
 		return
 	}
 
-	// Check if user is extending their own rental or is an admin/librarian
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if userID.(int64) != rental.UserID && !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is extending their own rental or may act on any rental
+	if !authorizeAccess(c, h.authzService, domain.PermRentalsExtend, rental.UserID) {
 		return
 	}
 
@@ -358,3 +390,40 @@ func (h *RentalHandler) Extend(c *gin.Context) {
 
 	SendSuccess(c, extendedRental, "Rental extended successfully")
 }
+
+// Eligibility handles checking whether a user may rent books
+// @Summary      Check borrowing eligibility
+// @Description  Check whether a user may rent books, with their overdue rentals, unpaid late fees and any reasons they are blocked. Users can only check themselves unless they may read any rental.
+// @Tags         users
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  Response{data=domain.Eligibility}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/eligibility [get]
+func (h *RentalHandler) Eligibility(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// Check if user is checking themselves or may read any rental
+	if !authorizeAccess(c, h.authzService, domain.PermRentalsRead, id) {
+		return
+	}
+
+	eligibility, err := h.rentalService.CheckEligibility(id)
+	if err != nil {
+		h.logger.Error("Failed to check borrowing eligibility", zap.Int64("userID", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, eligibility, "Eligibility retrieved successfully")
+}
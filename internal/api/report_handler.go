@@ -48,19 +48,6 @@ type RevenueReportRequest struct {
 // @Security     Bearer
 // @Router       /reports/books/popular [get]
 func (h *ReportHandler) GetPopularBooks(c *gin.Context) {
-	// Only admins and librarians can access reports
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
@@ -90,19 +77,6 @@ func (h *ReportHandler) GetPopularBooks(c *gin.Context) {
 // @Security     Bearer
 // @Router       /reports/revenue [get]
 func (h *ReportHandler) GetRevenueReport(c *gin.Context) {
-	// Only admins can access revenue reports
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if role != domain.RoleAdmin {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	var req RevenueReportRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		h.logger.Error("Invalid request parameters", zap.Error(err))
@@ -135,19 +109,6 @@ func (h *ReportHandler) GetRevenueReport(c *gin.Context) {
 // @Security     Bearer
 // @Router       /reports/overdue [get]
 func (h *ReportHandler) GetOverdueBooks(c *gin.Context) {
-	// Only admins and librarians can access reports
-	userRole, exists := c.Get("userRole")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	role := domain.UserRole(userRole.(string))
-	if !auth.IsLibrarian(role) {
-		SendError(c, domain.ErrForbidden)
-		return
-	}
-
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReservationHandler handles reservation requests
+type ReservationHandler struct {
+	reservationService domain.ReservationService
+	authzService       domain.AuthorizationService
+	logger             *logger.Logger
+}
+
+// NewReservationHandler creates a new ReservationHandler
+func NewReservationHandler(reservationService domain.ReservationService, authzService domain.AuthorizationService, logger *logger.Logger) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+		authzService:       authzService,
+		logger:             logger,
+	}
+}
+
+// ReservationRequest represents a request to reserve a book
+type ReservationRequest struct {
+	UserID *int64 `json:"user_id" example:"5"` // Reserve for another user, omit to reserve for yourself
+}
+
+// Create handles reserving a book
+// @Summary      Reserve a book
+// @Description  Join the queue for a book that has no copy available. The next returned copy is held for the first member in line. Members blocked from borrowing by overdue books or unpaid fees cannot join the queue.
+// @Tags         reservations
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true   "Book ID"
+// @Param        reservation  body      ReservationRequest  false  "Who to reserve for"
+// @Success      201          {object}  domain.Reservation
+// @Failure      400          {object}  domain.ErrorResponse
+// @Failure      401          {object}  domain.ErrorResponse
+// @Failure      403          {object}  domain.ErrorResponse
+// @Failure      404          {object}  domain.ErrorResponse
+// @Failure      409          {object}  domain.ErrorResponse
+// @Failure      500          {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /books/{id}/reservations [post]
+func (h *ReservationHandler) Create(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid book ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid book ID"))
+		return
+	}
+
+	// The body is optional, members reserve for themselves by default
+	var req ReservationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Invalid request body", zap.Error(err))
+			SendError(c, domain.NewInvalidInputError(err.Error()))
+			return
+		}
+	}
+
+	authUserID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	userID := authUserID.(int64)
+	if req.UserID != nil {
+		userID = *req.UserID
+	}
+
+	// Check if user is reserving for themselves, a dependent or may reserve for anyone
+	if !authorizeAccess(c, h.authzService, domain.PermReservationsManage, userID) {
+		return
+	}
+
+	reservation, err := h.reservationService.Create(userID, bookID)
+	if err != nil {
+		h.logger.Error("Failed to create reservation", zap.Int64("bookID", bookID), zap.Int64("userID", userID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendCreated(c, reservation, "Book reserved successfully")
+}
+
+// ListByBook handles listing the reservation queue of a book
+// @Summary      List the reservation queue of a book
+// @Description  Get the open reservations of a book: held copies first, then the waiting members in the order they will be served
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "Book ID"
+// @Success      200  {object}  Response{data=[]domain.Reservation}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /books/{id}/reservations [get]
+func (h *ReservationHandler) ListByBook(c *gin.Context) {
+	bookID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid book ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid book ID"))
+		return
+	}
+
+	reservations, err := h.reservationService.ListOpenByBook(bookID)
+	if err != nil {
+		h.logger.Error("Failed to list reservations by book", zap.Int64("bookID", bookID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, reservations, "Reservations retrieved successfully")
+}
+
+// ListByUser handles listing the reservations of a user
+// @Summary      List reservations by user
+// @Description  Get the reservations of a user with their queue position or hold expiry. Users can only view their own reservations and those of their dependents unless they are admins/librarians.
+// @Tags         reservations
+// @Produce      json
+// @Param        userId  path      int  true  "User ID"
+// @Success      200     {object}  Response{data=[]domain.Reservation}
+// @Failure      400     {object}  domain.ErrorResponse
+// @Failure      401     {object}  domain.ErrorResponse
+// @Failure      403     {object}  domain.ErrorResponse
+// @Failure      500     {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /reservations/user/{userId} [get]
+func (h *ReservationHandler) ListByUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	// Check if user is requesting their own reservations or may manage any reservation
+	if !authorizeAccess(c, h.authzService, domain.PermReservationsManage, userID) {
+		return
+	}
+
+	reservations, err := h.reservationService.ListByUser(userID)
+	if err != nil {
+		h.logger.Error("Failed to list reservations by user", zap.Int64("userID", userID), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, reservations, "Reservations retrieved successfully")
+}
+
+// GetByID handles getting a reservation by ID
+// @Summary      Get a reservation by ID
+// @Description  Retrieve a single reservation with its queue position or hold expiry
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "Reservation ID"
+// @Success      200  {object}  domain.Reservation
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /reservations/{id} [get]
+func (h *ReservationHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid reservation ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid reservation ID"))
+		return
+	}
+
+	reservation, err := h.reservationService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get reservation by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	// Check if user is requesting their own reservation or may manage any reservation
+	if !authorizeAccess(c, h.authzService, domain.PermReservationsManage, reservation.UserID) {
+		return
+	}
+
+	SendSuccess(c, reservation, "Reservation retrieved successfully")
+}
+
+// Cancel handles cancelling a reservation
+// @Summary      Cancel a reservation
+// @Description  Leave the queue for a book. A copy on hold for the reservation passes to the next member in line.
+// @Tags         reservations
+// @Produce      json
+// @Param        id   path      int  true  "Reservation ID"
+// @Success      200  {object}  domain.Reservation
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      409  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /reservations/{id}/cancel [put]
+func (h *ReservationHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid reservation ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid reservation ID"))
+		return
+	}
+
+	// Get reservation to check ownership
+	reservation, err := h.reservationService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get reservation by ID", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	// Check if user is cancelling their own reservation or may manage any reservation
+	if !authorizeAccess(c, h.authzService, domain.PermReservationsManage, reservation.UserID) {
+		return
+	}
+
+	cancelledReservation, err := h.reservationService.Cancel(id)
+	if err != nil {
+		h.logger.Error("Failed to cancel reservation", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, cancelledReservation, "Reservation cancelled successfully")
+}
@@ -14,6 +14,7 @@ type Response struct {
 	Message string      `json:"message,omitempty" example:"Operation successful"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response
@@ -38,10 +39,17 @@ func NewSuccessResponse(data interface{}, message string) Response {
 
 // NewErrorResponse creates a new error response
 func NewErrorResponse(err error) Response {
-	return Response{
+	response := Response{
 		Success: false,
 		Error:   err.Error(),
 	}
+
+	var detailed domain.DetailedError
+	if errors.As(err, &detailed) {
+		response.Details = detailed.ErrorDetails()
+	}
+
+	return response
 }
 
 // NewPaginatedResponse creates a new paginated response
@@ -66,6 +74,11 @@ func SendCreated(c *gin.Context, data interface{}, message string) {
 	c.JSON(http.StatusCreated, NewSuccessResponse(data, message))
 }
 
+// SendAccepted sends an accepted response for work that continues in the background
+func SendAccepted(c *gin.Context, data interface{}, message string) {
+	c.JSON(http.StatusAccepted, NewSuccessResponse(data, message))
+}
+
 // SendError sends an error response
 func SendError(c *gin.Context, err error) {
 	var statusCode int
@@ -80,26 +93,81 @@ func SendError(c *gin.Context, err error) {
 			 errors.Is(err, domain.ErrBookNotFound) || 
 			 errors.Is(err, domain.ErrCategoryNotFound) || 
 			 errors.Is(err, domain.ErrRentalNotFound) || 
+			 errors.Is(err, domain.ErrSessionNotFound) || 
+			 errors.Is(err, domain.ErrAPIKeyNotFound) || 
+			 errors.Is(err, domain.ErrOIDCProviderNotFound) || 
+			 errors.Is(err, domain.ErrRolePermissionNotFound) || 
+			 errors.Is(err, domain.ErrMembershipPlanNotFound) ||
+			 errors.Is(err, domain.ErrDataExportNotFound) ||
+			 errors.Is(err, domain.ErrUserImportNotFound) ||
+			 errors.Is(err, domain.ErrHouseholdNotFound) ||
+			 errors.Is(err, domain.ErrBookCopyNotFound) ||
+			 errors.Is(err, domain.ErrReservationNotFound) ||
+			 errors.Is(err, domain.ErrFineNotFound) ||
+			 errors.Is(err, domain.ErrLoanRuleNotFound) ||
+			 errors.Is(err, domain.ErrClosureNotFound) ||
+			 errors.Is(err, domain.ErrNotHouseholdDependent) ||
 			 errors.Is(err, domain.ErrPaymentNotFound):
 			statusCode = http.StatusNotFound
 		case errors.Is(err, domain.ErrInvalidInput) || 
 			 errors.Is(err, domain.ErrInvalidCredentials) || 
-			 errors.Is(err, domain.ErrInvalidPassword):
+			 errors.Is(err, domain.ErrInvalidPassword) ||
+			 errors.Is(err, domain.ErrWeakPassword) ||
+			 errors.Is(err, domain.ErrUserNotDeleted) ||
+			 errors.Is(err, domain.ErrInvalidUserImport) ||
+			 errors.Is(err, domain.ErrInvalidResetToken) ||
+			 errors.Is(err, domain.ErrInvalidVerificationToken) ||
+			 errors.Is(err, domain.ErrMFANotEnrolled) ||
+			 errors.Is(err, domain.ErrInvalidOIDCState) ||
+			 errors.Is(err, domain.ErrUnknownPermission):
 			statusCode = http.StatusBadRequest
-		case errors.Is(err, domain.ErrUnauthorized):
+		case errors.Is(err, domain.ErrUnauthorized) ||
+			 errors.Is(err, domain.ErrTokenRevoked) ||
+			 errors.Is(err, domain.ErrTokenReuseDetected) ||
+			 errors.Is(err, domain.ErrInvalidMFACode) ||
+			 errors.Is(err, domain.ErrInvalidAPIKey) ||
+			 errors.Is(err, domain.ErrOIDCLoginFailed):
 			statusCode = http.StatusUnauthorized
-		case errors.Is(err, domain.ErrForbidden):
+		case errors.Is(err, domain.ErrForbidden) ||
+			 errors.Is(err, domain.ErrEmailNotVerified) ||
+			 errors.Is(err, domain.ErrMFARequired) ||
+			 errors.Is(err, domain.ErrAccountSuspended) ||
+			 errors.Is(err, domain.ErrImpersonationForbidden) ||
+			 errors.Is(err, domain.ErrRentalLimitReached) ||
+			 errors.Is(err, domain.ErrExtensionLimitReached) ||
+			 errors.Is(err, domain.ErrHouseholdLimitReached) ||
+			 errors.Is(err, domain.ErrBorrowingBlocked):
 			statusCode = http.StatusForbidden
 		case errors.Is(err, domain.ErrConflict) || 
 			 errors.Is(err, domain.ErrUserAlreadyExists) || 
 			 errors.Is(err, domain.ErrBookAlreadyExists) || 
 			 errors.Is(err, domain.ErrCategoryAlreadyExists) || 
 			 errors.Is(err, domain.ErrRentalAlreadyExists) || 
-			 errors.Is(err, domain.ErrPaymentAlreadyExists):
+			 errors.Is(err, domain.ErrRentalNotActive) ||
+			 errors.Is(err, domain.ErrPaymentAlreadyExists) ||
+			 errors.Is(err, domain.ErrMFAAlreadyEnabled) ||
+			 errors.Is(err, domain.ErrEmailAlreadyVerified) ||
+			 errors.Is(err, domain.ErrOIDCAccountConflict) ||
+			 errors.Is(err, domain.ErrMembershipPlanAlreadyExists) ||
+			 errors.Is(err, domain.ErrAlreadyInHousehold) ||
+			 errors.Is(err, domain.ErrBookCopyAlreadyExists) ||
+			 errors.Is(err, domain.ErrBookCopyNotAvailable) ||
+			 errors.Is(err, domain.ErrAlreadyReserved) ||
+			 errors.Is(err, domain.ErrAlreadyBorrowed) ||
+			 errors.Is(err, domain.ErrReservationNotOpen) ||
+			 errors.Is(err, domain.ErrFineNotOutstanding) ||
+			 errors.Is(err, domain.ErrFineOverpaid) ||
+			 errors.Is(err, domain.ErrLoanRuleAlreadyExists) ||
+			 errors.Is(err, domain.ErrClosureAlreadyExists) ||
+			 errors.Is(err, domain.ErrDataExportNotReady) ||
+			 errors.Is(err, domain.ErrDataExportPending):
 			statusCode = http.StatusConflict
 		case errors.Is(err, domain.ErrResourceExhausted) || 
-			 errors.Is(err, domain.ErrBookNotAvailable):
+			 errors.Is(err, domain.ErrBookNotAvailable) ||
+			 errors.Is(err, domain.ErrTooManyLoginAttempts):
 			statusCode = http.StatusTooManyRequests
+		case errors.Is(err, domain.ErrAccountLocked):
+			statusCode = http.StatusLocked
 		default:
 			statusCode = http.StatusInternalServerError
 		}
@@ -3,6 +3,7 @@ package api
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
 	"github.com/SimpleBookRental/backend/pkg/auth"
@@ -13,17 +14,19 @@ import (
 
 // UserHandler handles user requests
 type UserHandler struct {
-	userService domain.UserService
-	jwtService  *auth.JWTService
-	logger      *logger.Logger
+	userService  domain.UserService
+	authzService domain.AuthorizationService
+	jwtService   *auth.JWTService
+	logger       *logger.Logger
 }
 
 // NewUserHandler creates a new UserHandler
-func NewUserHandler(userService domain.UserService, jwtService *auth.JWTService, logger *logger.Logger) *UserHandler {
+func NewUserHandler(userService domain.UserService, authzService domain.AuthorizationService, jwtService *auth.JWTService, logger *logger.Logger) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		jwtService:  jwtService,
-		logger:      logger,
+		userService:  userService,
+		authzService: authzService,
+		jwtService:   jwtService,
+		logger:       logger,
 	}
 }
 
@@ -36,10 +39,23 @@ type UpdateUserRequest struct {
 	Role      domain.UserRole `json:"role" example:"member"`
 }
 
+// UserListRequest represents the search, filter and sort parameters for listing users
+type UserListRequest struct {
+	Search      string `form:"search"`
+	Role        string `form:"role" binding:"omitempty,oneof=admin librarian member"`
+	Status      string `form:"status" binding:"omitempty,oneof=active suspended deleted"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Sort        string `form:"sort,default=id" binding:"oneof=id username email name created_at"`
+	Order       string `form:"order,default=asc" binding:"oneof=asc desc"`
+	Limit       int32  `form:"limit,default=10" binding:"min=1,max=100"`
+	Offset      int32  `form:"offset,default=0" binding:"min=0"`
+}
+
 // ChangePasswordRequest represents a password change request
 type ChangePasswordRequest struct {
 	CurrentPassword string `json:"current_password" binding:"required" example:"oldpassword"`
-	NewPassword     string `json:"new_password" binding:"required,min=6" example:"newpassword123"`
+	NewPassword     string `json:"new_password" binding:"required" example:"NewPassword123"`
 }
 
 // GetByID handles getting a user by ID
@@ -65,18 +81,8 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	// Check if user is requesting their own profile or is an admin
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if userID.(int64) != id && role != domain.RoleAdmin {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is requesting their own profile or may read any profile
+	if !authorizeAccess(c, h.authzService, domain.PermUsersRead, id) {
 		return
 	}
 
@@ -91,38 +97,72 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 }
 
 // List handles listing users
-// @Summary      List all users
-// @Description  Get a paginated list of all users. Only admins can access this endpoint.
+// @Summary      List users
+// @Description  Search, filter and sort users. The search matches username, email and name. Deleted users are only listed when filtering by status=deleted.
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        limit  query    int     false  "Limit"  default(10)
-// @Param        offset query    int     false  "Offset" default(0)
-// @Success      200    {object} PaginatedResponse{data=[]domain.User}
-// @Failure      401    {object} domain.ErrorResponse
-// @Failure      403    {object} domain.ErrorResponse
-// @Failure      500    {object} domain.ErrorResponse
+// @Param        search        query    string  false  "Search username, email and name"
+// @Param        role          query    string  false  "Role"  Enums(admin, librarian, member)
+// @Param        status        query    string  false  "Status"  Enums(active, suspended, deleted)
+// @Param        created_from  query    string  false  "Created on or after (YYYY-MM-DD)"
+// @Param        created_to    query    string  false  "Created on or before (YYYY-MM-DD)"
+// @Param        sort          query    string  false  "Sort field"  Enums(id, username, email, name, created_at)  default(id)
+// @Param        order         query    string  false  "Sort order"  Enums(asc, desc)  default(asc)
+// @Param        limit         query    int     false  "Limit"  default(10)
+// @Param        offset        query    int     false  "Offset" default(0)
+// @Success      200           {object} PaginatedResponse{data=[]domain.User}
+// @Failure      400           {object} domain.ErrorResponse
+// @Failure      401           {object} domain.ErrorResponse
+// @Failure      403           {object} domain.ErrorResponse
+// @Failure      500           {object} domain.ErrorResponse
 // @Security     Bearer
 // @Router       /users [get]
 func (h *UserHandler) List(c *gin.Context) {
-	// Only admins can list all users
-	userRole, exists := c.Get("userRole")
-	if !exists || userRole.(string) != string(domain.RoleAdmin) {
-		SendError(c, domain.ErrForbidden)
+	var req UserListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Invalid list parameters", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError(err.Error()))
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	params := domain.UserListParams{
+		Search:   req.Search,
+		Role:     domain.UserRole(req.Role),
+		Status:   domain.UserStatus(req.Status),
+		SortBy:   req.Sort,
+		SortDesc: req.Order == "desc",
+		Limit:    req.Limit,
+		Offset:   req.Offset,
+	}
+
+	if req.CreatedFrom != "" {
+		createdFrom, err := time.Parse("2006-01-02", req.CreatedFrom)
+		if err != nil {
+			SendError(c, domain.NewInvalidInputError("invalid created_from date format, use YYYY-MM-DD"))
+			return
+		}
+		params.CreatedFrom = createdFrom
+	}
+
+	if req.CreatedTo != "" {
+		createdTo, err := time.Parse("2006-01-02", req.CreatedTo)
+		if err != nil {
+			SendError(c, domain.NewInvalidInputError("invalid created_to date format, use YYYY-MM-DD"))
+			return
+		}
+		// Include the whole end day
+		params.CreatedBefore = createdTo.AddDate(0, 0, 1)
+	}
 
-	users, err := h.userService.List(int32(limit), int32(offset))
+	users, total, err := h.userService.List(params)
 	if err != nil {
 		h.logger.Error("Failed to list users", zap.Error(err))
 		SendError(c, err)
 		return
 	}
 
-	SendPaginated(c, users, int64(len(users)), int32(limit), int32(offset), "Users retrieved successfully")
+	SendPaginated(c, users, total, req.Limit, req.Offset, "Users retrieved successfully")
 }
 
 // Update handles updating a user
@@ -149,20 +189,11 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Check if user is updating their own profile or is an admin
-	userID, exists := c.Get("userID")
-	if !exists {
-		SendError(c, domain.ErrUnauthorized)
-		return
-	}
-
-	userRole, _ := c.Get("userRole")
-	role := domain.UserRole(userRole.(string))
-
-	if userID.(int64) != id && role != domain.RoleAdmin {
-		SendError(c, domain.ErrForbidden)
+	// Check if user is updating their own profile or may update any profile
+	if !authorizeAccess(c, h.authzService, domain.PermUsersUpdate, id) {
 		return
 	}
+	subject, _ := subjectFromContext(c)
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -193,8 +224,8 @@ func (h *UserHandler) Update(c *gin.Context) {
 		existingUser.LastName = req.LastName
 	}
 
-	// Only admins can update roles
-	if req.Role != "" && role == domain.RoleAdmin {
+	// Roles can only be changed with the permission to do so
+	if req.Role != "" && h.authzService.Can(subject, domain.PermUsersUpdateRole) {
 		existingUser.Role = req.Role
 	}
 
@@ -210,7 +241,7 @@ func (h *UserHandler) Update(c *gin.Context) {
 
 // Delete handles deleting a user
 // @Summary      Delete a user
-// @Description  Delete a user from the system. Only admins can delete users.
+// @Description  Soft-delete a user and end their sessions. Rentals and payments are kept, and personal data is anonymized after the retention period. Only admins can delete users.
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -231,14 +262,13 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Only admins can delete users
-	userRole, exists := c.Get("userRole")
-	if !exists || userRole.(string) != string(domain.RoleAdmin) {
-		SendError(c, domain.ErrForbidden)
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
 		return
 	}
 
-	err = h.userService.Delete(id)
+	err = h.userService.Delete(id, userID.(int64))
 	if err != nil {
 		h.logger.Error("Failed to delete user", zap.Int64("id", id), zap.Error(err))
 		SendError(c, err)
@@ -248,6 +278,162 @@ func (h *UserHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
 
+// Unlock handles clearing a login lockout
+// @Summary      Unlock a user account
+// @Description  Clear the failed login counter and lockout of a user. Only admins can unlock accounts.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/unlock [post]
+func (h *UserHandler) Unlock(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err = h.userService.Unlock(id, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to unlock user", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
+// Suspend handles suspending a user
+// @Summary      Suspend a user account
+// @Description  Block a user from signing in and end their sessions. Admins and librarians can suspend accounts.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/suspend [post]
+func (h *UserHandler) Suspend(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err = h.userService.Suspend(id, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to suspend user", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User suspended successfully"})
+}
+
+// Reactivate handles lifting a suspension
+// @Summary      Reactivate a user account
+// @Description  Lift the suspension of a user account. Admins and librarians can reactivate accounts.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/reactivate [post]
+func (h *UserHandler) Reactivate(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err = h.userService.Reactivate(id, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to reactivate user", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User reactivated successfully"})
+}
+
+// Anonymize handles anonymizing a deleted user
+// @Summary      Anonymize a deleted user
+// @Description  Scrub the username, email and names of a deleted user right away. Rentals and payments are kept. Only admins can anonymize accounts.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]string
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /users/{id}/anonymize [post]
+func (h *UserHandler) Anonymize(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid user ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid user ID"))
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	err = h.userService.Anonymize(id, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to anonymize user", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User anonymized successfully"})
+}
+
 // ChangePassword handles changing a user's password
 // @Summary      Change user password
 // @Description  Change a user's password. Users can only change their own password.
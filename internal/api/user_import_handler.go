@@ -0,0 +1,126 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// maxImportFileSize caps the size of an uploaded user import file
+const maxImportFileSize = 5 << 20
+
+// UserImportHandler handles bulk user import requests
+type UserImportHandler struct {
+	importService domain.UserImportService
+	logger        *logger.Logger
+}
+
+// NewUserImportHandler creates a new UserImportHandler
+func NewUserImportHandler(importService domain.UserImportService, logger *logger.Logger) *UserImportHandler {
+	return &UserImportHandler{
+		importService: importService,
+		logger:        logger,
+	}
+}
+
+// Import handles importing users from a CSV file
+// @Summary      Import users from CSV
+// @Description  Create accounts from a CSV file with a header row naming the columns username, email and optionally first_name, last_name and role (member by default). Every row is validated first; with dry_run=true only the per-row errors are returned. Otherwise a file without errors starts an import job that creates all accounts in one transaction with generated temporary passwords, which are only sent out in invites, so send_invites has to be true. Imported users have to change their password.
+// @Tags         admin
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file          formData  file  true   "CSV file"
+// @Param        dry_run       query     bool  false  "Only validate the file"
+// @Param        send_invites  query     bool  true   "Email each user their username and temporary password, required"
+// @Success      200           {object}  Response{data=domain.UserImportValidation}
+// @Success      202           {object}  Response{data=domain.UserImportJob}
+// @Failure      400           {object}  domain.ErrorResponse
+// @Failure      401           {object}  domain.ErrorResponse
+// @Failure      403           {object}  domain.ErrorResponse
+// @Failure      500           {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /admin/users/import [post]
+func (h *UserImportHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		SendError(c, domain.NewInvalidInputError("a CSV file is required in the file field"))
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		SendError(c, domain.NewInvalidInputError("the import file must be at most 5 MB"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("Failed to open import file", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+	defer file.Close()
+
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	if dryRun {
+		validation, err := h.importService.Validate(file)
+		if err != nil {
+			h.logger.Error("Failed to validate import file", zap.Error(err))
+			SendError(c, err)
+			return
+		}
+
+		SendSuccess(c, validation, "Import file validated")
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		SendError(c, domain.ErrUnauthorized)
+		return
+	}
+
+	sendInvites, _ := strconv.ParseBool(c.Query("send_invites"))
+
+	job, err := h.importService.Import(file, sendInvites, userID.(int64))
+	if err != nil {
+		h.logger.Error("Failed to import users", zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendAccepted(c, job, "User import started")
+}
+
+// GetByID handles checking on an import job
+// @Summary      Get a user import job
+// @Description  Check the progress of a bulk user import
+// @Tags         admin
+// @Produce      json
+// @Param        id   path      int  true  "Import job ID"
+// @Success      200  {object}  Response{data=domain.UserImportJob}
+// @Failure      400  {object}  domain.ErrorResponse
+// @Failure      401  {object}  domain.ErrorResponse
+// @Failure      403  {object}  domain.ErrorResponse
+// @Failure      404  {object}  domain.ErrorResponse
+// @Failure      500  {object}  domain.ErrorResponse
+// @Security     Bearer
+// @Router       /admin/users/import/{id} [get]
+func (h *UserImportHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.logger.Error("Invalid import job ID", zap.Error(err))
+		SendError(c, domain.NewInvalidInputError("invalid import job ID"))
+		return
+	}
+
+	job, err := h.importService.GetByID(id)
+	if err != nil {
+		h.logger.Error("Failed to get user import job", zap.Int64("id", id), zap.Error(err))
+		SendError(c, err)
+		return
+	}
+
+	SendSuccess(c, job, "User import retrieved successfully")
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"time"
+)
+
+// Permission is a named action that can be granted to a role, such as "payments:refund"
+type Permission string
+
+// ScopedPermission is an action on resources that belong to a user. It is granted either
+// for the subject's own resources (":own") or for everyone's resources (":any").
+type ScopedPermission string
+
+// Own returns the permission to perform the action on the subject's own resources
+func (p ScopedPermission) Own() Permission {
+	return Permission(p + ":own")
+}
+
+// Any returns the permission to perform the action on any user's resources
+func (p ScopedPermission) Any() Permission {
+	return Permission(p + ":any")
+}
+
+// Actions on resources owned by a user
+const (
+	PermUsersRead          ScopedPermission = "users:read"
+	PermUsersUpdate        ScopedPermission = "users:update"
+	PermRentalsRead        ScopedPermission = "rentals:read"
+	PermRentalsReturn      ScopedPermission = "rentals:return"
+	PermRentalsExtend      ScopedPermission = "rentals:extend"
+	PermPaymentsRead       ScopedPermission = "payments:read"
+	PermReservationsManage ScopedPermission = "reservations:manage"
+)
+
+// Actions that are not tied to a resource owner
+const (
+	PermUsersDelete           Permission = "users:delete"
+	PermUsersUnlock           Permission = "users:unlock"
+	PermUsersUpdateRole       Permission = "users:update_role"
+	PermUsersImpersonate      Permission = "users:impersonate"
+	PermUsersAssignPlan       Permission = "users:assign_plan"
+	PermUsersSuspend          Permission = "users:suspend"
+	PermUsersAnonymize        Permission = "users:anonymize"
+	PermUsersImport           Permission = "users:import"
+	PermCategoriesManage      Permission = "categories:manage"
+	PermBooksManage           Permission = "books:manage"
+	PermRentalsCreate         Permission = "rentals:create"
+	PermRentalsCheckout       Permission = "rentals:checkout"
+	PermPaymentsCreate        Permission = "payments:create"
+	PermPaymentsList          Permission = "payments:list"
+	PermPaymentsRefund        Permission = "payments:refund"
+	PermFinesWaive            Permission = "fines:waive"
+	PermReportsRead           Permission = "reports:read"
+	PermReportsRevenue        Permission = "reports:revenue"
+	PermAPIKeysManage         Permission = "api_keys:manage"
+	PermPermissionsManage     Permission = "permissions:manage"
+	PermMembershipPlansManage Permission = "membership_plans:manage"
+	PermLoanRulesRead         Permission = "loan_rules:read"
+	PermLoanRulesManage       Permission = "loan_rules:manage"
+	PermCalendarManage        Permission = "calendar:manage"
+	PermHouseholdsManage      Permission = "households:manage"
+)
+
+// scopedPermissions lists every action on owned resources
+var scopedPermissions = []ScopedPermission{
+	PermUsersRead,
+	PermUsersUpdate,
+	PermRentalsRead,
+	PermRentalsReturn,
+	PermRentalsExtend,
+	PermPaymentsRead,
+	PermReservationsManage,
+}
+
+// unscopedPermissions lists every action not tied to a resource owner
+var unscopedPermissions = []Permission{
+	PermUsersDelete,
+	PermUsersUnlock,
+	PermUsersUpdateRole,
+	PermUsersImpersonate,
+	PermUsersAssignPlan,
+	PermUsersSuspend,
+	PermUsersAnonymize,
+	PermUsersImport,
+	PermCategoriesManage,
+	PermBooksManage,
+	PermRentalsCreate,
+	PermRentalsCheckout,
+	PermPaymentsCreate,
+	PermPaymentsList,
+	PermPaymentsRefund,
+	PermFinesWaive,
+	PermReportsRead,
+	PermReportsRevenue,
+	PermAPIKeysManage,
+	PermPermissionsManage,
+	PermMembershipPlansManage,
+	PermLoanRulesRead,
+	PermLoanRulesManage,
+	PermCalendarManage,
+	PermHouseholdsManage,
+}
+
+// guardianPermissions lists the actions a household guardian may take on their dependents' resources
+var guardianPermissions = map[ScopedPermission]bool{
+	PermRentalsRead:        true,
+	PermRentalsReturn:      true,
+	PermRentalsExtend:      true,
+	PermPaymentsRead:       true,
+	PermReservationsManage: true,
+}
+
+// DelegatedToGuardians reports whether guardians holding the ":own" variant of the permission
+// may also perform the action on their dependents' resources
+func (p ScopedPermission) DelegatedToGuardians() bool {
+	return guardianPermissions[p]
+}
+
+// AllPermissions returns every permission that can be granted to a role
+func AllPermissions() []Permission {
+	permissions := make([]Permission, 0, 2*len(scopedPermissions)+len(unscopedPermissions))
+	for _, p := range scopedPermissions {
+		permissions = append(permissions, p.Own(), p.Any())
+	}
+	return append(permissions, unscopedPermissions...)
+}
+
+// IsValidPermission checks if a permission is known to the application
+func IsValidPermission(permission Permission) bool {
+	for _, p := range AllPermissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidRole checks if a role is known to the application
+func IsValidRole(role UserRole) bool {
+	return role == RoleAdmin || role == RoleLibrarian || role == RoleMember
+}
+
+// Subject is the authenticated principal an authorization decision is made for
+type Subject struct {
+	UserID int64
+	Role   UserRole
+}
+
+// RolePermission represents a permission granted to a role
+type RolePermission struct {
+	Role       UserRole   `json:"role"`
+	Permission Permission `json:"permission"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// PermissionRepository defines the interface for role permission data access
+type PermissionRepository interface {
+	List() ([]*RolePermission, error)
+	Grant(role UserRole, permission Permission) error
+	Revoke(role UserRole, permission Permission) error
+}
+
+// AuthorizationService defines the interface for permission checks and policy management
+type AuthorizationService interface {
+	Can(subject Subject, permission Permission) bool
+	CanAccess(subject Subject, permission ScopedPermission, ownerID int64) bool
+	CanActFor(subject Subject, userID int64) bool
+	ListRolePermissions() (map[UserRole][]Permission, error)
+	Grant(role UserRole, permission Permission, grantedBy int64) error
+	Revoke(role UserRole, permission Permission, revokedBy int64) error
+}
@@ -13,8 +13,8 @@ type Book struct {
 	Description     string    `json:"description,omitempty"`
 	PublishedYear   int32     `json:"published_year,omitempty"`
 	Publisher       string    `json:"publisher,omitempty"`
-	TotalCopies     int32     `json:"total_copies"`
-	AvailableCopies int32     `json:"available_copies"`
+	TotalCopies     int32     `json:"total_copies"`     // Copies in the collection, derived from the book's copies
+	AvailableCopies int32     `json:"available_copies"` // Copies on the shelf, derived from the book's copies
 	CategoryID      int64     `json:"category_id,omitempty"`
 	CategoryName    string    `json:"category_name,omitempty"` // For join queries
 	CreatedAt       time.Time `json:"created_at"`
@@ -42,9 +42,6 @@ type BookRepository interface {
 	Search(params BookSearchParams) ([]*Book, error)
 	Create(book *Book) (*Book, error)
 	Update(book *Book) (*Book, error)
-	UpdateCopies(id int64, totalCopies, availableCopies int32) (*Book, error)
-	DecrementAvailableCopies(id int64) (*Book, error)
-	IncrementAvailableCopies(id int64) (*Book, error)
 	Delete(id int64) error
 }
 
@@ -57,7 +54,6 @@ type BookService interface {
 	Search(params BookSearchParams) ([]*Book, error)
 	Create(book *Book) (*Book, error)
 	Update(book *Book) (*Book, error)
-	UpdateCopies(id int64, totalCopies, availableCopies int32) (*Book, error)
 	Delete(id int64) error
 	IsAvailable(id int64) (bool, error)
 }
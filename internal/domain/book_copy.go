@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"time"
+)
+
+// BookCopyStatus defines the status of a physical copy of a book
+type BookCopyStatus string
+
+const (
+	// BookCopyStatusAvailable represents a copy on the shelf that can be lent out
+	BookCopyStatusAvailable BookCopyStatus = "available"
+	// BookCopyStatusCheckedOut represents a copy held by a member
+	BookCopyStatusCheckedOut BookCopyStatus = "checked_out"
+	// BookCopyStatusOnHold represents a copy kept aside for the member who reserved it
+	BookCopyStatusOnHold BookCopyStatus = "on_hold"
+	// BookCopyStatusMaintenance represents a copy that is being repaired
+	BookCopyStatusMaintenance BookCopyStatus = "maintenance"
+	// BookCopyStatusLost represents a copy that went missing
+	BookCopyStatusLost BookCopyStatus = "lost"
+	// BookCopyStatusWithdrawn represents a copy removed from the collection
+	BookCopyStatusWithdrawn BookCopyStatus = "withdrawn"
+)
+
+// BookCopyCondition defines the physical condition of a copy
+type BookCopyCondition string
+
+const (
+	BookCopyConditionNew     BookCopyCondition = "new"
+	BookCopyConditionGood    BookCopyCondition = "good"
+	BookCopyConditionFair    BookCopyCondition = "fair"
+	BookCopyConditionPoor    BookCopyCondition = "poor"
+	BookCopyConditionDamaged BookCopyCondition = "damaged"
+)
+
+// BookCopy represents a physical copy of a book identified by its barcode
+type BookCopy struct {
+	ID            int64             `json:"id"`
+	BookID        int64             `json:"book_id"`
+	Barcode       string            `json:"barcode"`
+	Condition     BookCopyCondition `json:"condition"`
+	ShelfLocation string            `json:"shelf_location,omitempty"`
+	Status        BookCopyStatus    `json:"status"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	BookTitle     string            `json:"book_title,omitempty"` // For join queries
+}
+
+// IsValidBookCopyCondition checks if a condition is known to the application
+func IsValidBookCopyCondition(condition BookCopyCondition) bool {
+	switch condition {
+	case BookCopyConditionNew, BookCopyConditionGood, BookCopyConditionFair, BookCopyConditionPoor, BookCopyConditionDamaged:
+		return true
+	}
+	return false
+}
+
+// IsValidBookCopyStatus checks if a status is known to the application
+func IsValidBookCopyStatus(status BookCopyStatus) bool {
+	switch status {
+	case BookCopyStatusAvailable, BookCopyStatusCheckedOut, BookCopyStatusOnHold, BookCopyStatusMaintenance, BookCopyStatusLost, BookCopyStatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// BookCopyRepository defines the interface for book copy data access
+type BookCopyRepository interface {
+	GetByID(id int64) (*BookCopy, error)
+	GetByBarcode(barcode string) (*BookCopy, error)
+	ListByBook(bookID int64) ([]*BookCopy, error)
+	Create(copy *BookCopy) (*BookCopy, error)
+	Update(copy *BookCopy) (*BookCopy, error)
+}
+
+// BookCopyService defines the interface for book copy business logic
+type BookCopyService interface {
+	GetByBarcode(barcode string) (*BookCopy, error)
+	ListByBook(bookID int64) ([]*BookCopy, error)
+	Create(copy *BookCopy) (*BookCopy, error)
+	Update(copy *BookCopy) (*BookCopy, error)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"io"
+	"time"
+)
+
+// ClosureSource defines where a library closure was recorded from
+type ClosureSource string
+
+const (
+	// ClosureSourceManual represents a closure added by an admin
+	ClosureSourceManual ClosureSource = "manual"
+	// ClosureSourceICal represents a closure imported from an iCalendar file
+	ClosureSourceICal ClosureSource = "ical"
+)
+
+// OpeningHours are the hours the library is open on a day of the week
+type OpeningHours struct {
+	Weekday  time.Weekday `json:"weekday" example:"1"`                 // 0 is Sunday
+	OpensAt  string       `json:"opens_at,omitempty" example:"09:00"`  // Empty when closed
+	ClosesAt string       `json:"closes_at,omitempty" example:"18:00"` // Empty when closed
+	Closed   bool         `json:"closed" example:"false"`
+}
+
+// Closure is a date the library is closed on, such as a public holiday
+type Closure struct {
+	ID        int64         `json:"id"`
+	Date      time.Time     `json:"date"`
+	Reason    string        `json:"reason"`
+	Source    ClosureSource `json:"source"`
+	CreatedBy *int64        `json:"created_by,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ClosureImportResult is the outcome of importing closures from an iCalendar file
+type ClosureImportResult struct {
+	Imported   int `json:"imported"`   // Closed dates added to the calendar
+	Duplicates int `json:"duplicates"` // Dates the library was already closed on
+	Skipped    int `json:"skipped"`    // Recurring, cancelled or invalid events
+}
+
+// LibraryCalendar tells which days the library is open, from its weekly opening hours and
+// the closures in a range of dates
+type LibraryCalendar struct {
+	closedWeekdays map[time.Weekday]bool
+	closedDates    map[string]bool
+}
+
+// calendarDateLayout formats the dates of a calendar
+const calendarDateLayout = "2006-01-02"
+
+// NewLibraryCalendar creates a calendar from the weekly opening hours and a set of closures
+func NewLibraryCalendar(hours []*OpeningHours, closures []*Closure) *LibraryCalendar {
+	calendar := &LibraryCalendar{
+		closedWeekdays: make(map[time.Weekday]bool),
+		closedDates:    make(map[string]bool),
+	}
+	for _, h := range hours {
+		if h.Closed {
+			calendar.closedWeekdays[h.Weekday] = true
+		}
+	}
+	for _, c := range closures {
+		calendar.closedDates[c.Date.Format(calendarDateLayout)] = true
+	}
+	return calendar
+}
+
+// IsOpen reports whether the library is open on the day of t
+func (c *LibraryCalendar) IsOpen(t time.Time) bool {
+	return !c.closedWeekdays[t.Weekday()] && !c.closedDates[t.Format(calendarDateLayout)]
+}
+
+// NextOpenDay returns t if the library is open that day, otherwise the same time of day on the
+// next day it is open. t is returned unchanged when the library is not open within a year.
+func (c *LibraryCalendar) NextOpenDay(t time.Time) time.Time {
+	for day := t; day.Before(t.AddDate(1, 0, 0)); day = day.AddDate(0, 0, 1) {
+		if c.IsOpen(day) {
+			return day
+		}
+	}
+	return t
+}
+
+// OpenDaysBetween counts the days after the day of from, up to and including the day of to,
+// that the library is open on. Both days are taken in the time zone of from.
+func (c *LibraryCalendar) OpenDaysBetween(from, to time.Time) int {
+	open := 0
+	last := to.In(from.Location()).Format(calendarDateLayout)
+	for day := from.AddDate(0, 0, 1); day.Format(calendarDateLayout) <= last; day = day.AddDate(0, 0, 1) {
+		if c.IsOpen(day) {
+			open++
+		}
+	}
+	return open
+}
+
+// CalendarRepository defines the interface for library calendar data access
+type CalendarRepository interface {
+	ListOpeningHours() ([]*OpeningHours, error)
+	UpdateOpeningHours(hours []*OpeningHours) ([]*OpeningHours, error)
+	ListClosures(from, to time.Time) ([]*Closure, error)
+	CreateClosure(closure *Closure) (*Closure, error)
+	ImportClosures(closures []*Closure) (int, error) // Dates that are already closed are left as they are
+	DeleteClosure(id int64) error
+}
+
+// CalendarService defines the interface for library calendar business logic
+type CalendarService interface {
+	GetOpeningHours() ([]*OpeningHours, error)
+	UpdateOpeningHours(hours []*OpeningHours) ([]*OpeningHours, error)
+	ListClosures(from, to time.Time) ([]*Closure, error)
+	CreateClosure(closure *Closure) (*Closure, error)
+	DeleteClosure(id int64) error
+	// ImportICal adds the days covered by the events of an iCalendar file as closures
+	ImportICal(file io.Reader, importedBy int64) (*ClosureImportResult, error)
+}
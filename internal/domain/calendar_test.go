@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLibraryCalendarOpenDaysBetween(t *testing.T) {
+	// Closed on weekends and on Friday 2025-12-26
+	calendar := NewLibraryCalendar(
+		[]*OpeningHours{{Weekday: time.Saturday, Closed: true}, {Weekday: time.Sunday, Closed: true}},
+		[]*Closure{{Date: time.Date(2025, 12, 26, 0, 0, 0, 0, time.UTC)}},
+	)
+
+	// Wednesday 2025-12-10 10:00
+	due := time.Date(2025, 12, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		due      time.Time
+		returned time.Time
+		want     int
+	}{
+		{"later on the due date", due, due.Add(5 * time.Hour), 0},
+		{"next morning", due, time.Date(2025, 12, 11, 9, 0, 0, 0, time.UTC), 1},
+		{"over the weekend", due, time.Date(2025, 12, 14, 9, 0, 0, 0, time.UTC), 2},
+		{"on the Monday after the weekend", due, time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC), 3},
+		{"on a closed day within a day of the due date", time.Date(2025, 12, 12, 17, 0, 0, 0, time.UTC), time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC), 0},
+		{"across a holiday and a weekend", time.Date(2025, 12, 24, 17, 0, 0, 0, time.UTC), time.Date(2025, 12, 29, 9, 0, 0, 0, time.UTC), 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calendar.OpenDaysBetween(tt.due, tt.returned); got != tt.want {
+				t.Errorf("OpenDaysBetween() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibraryCalendarNextOpenDay(t *testing.T) {
+	calendar := NewLibraryCalendar(
+		[]*OpeningHours{{Weekday: time.Saturday, Closed: true}, {Weekday: time.Sunday, Closed: true}},
+		nil,
+	)
+
+	// Saturday 2025-12-13 rolls to Monday 2025-12-15 at the same time of day
+	saturday := time.Date(2025, 12, 13, 10, 0, 0, 0, time.UTC)
+	if got, want := calendar.NextOpenDay(saturday), saturday.AddDate(0, 0, 2); !got.Equal(want) {
+		t.Errorf("NextOpenDay() = %v, want %v", got, want)
+	}
+
+	friday := saturday.AddDate(0, 0, -1)
+	if got := calendar.NextOpenDay(friday); !got.Equal(friday) {
+		t.Errorf("NextOpenDay() = %v, want %v", got, friday)
+	}
+}
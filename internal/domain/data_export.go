@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+)
+
+// DataExportStatus defines the progress of an asynchronous data export
+type DataExportStatus string
+
+const (
+	// DataExportStatusPending represents an export that is still being generated
+	DataExportStatusPending DataExportStatus = "pending"
+	// DataExportStatusReady represents an export that can be downloaded
+	DataExportStatusReady DataExportStatus = "ready"
+	// DataExportStatusFailed represents an export that could not be generated
+	DataExportStatusFailed DataExportStatus = "failed"
+)
+
+// DataExport is a ZIP archive of everything stored about a user, generated in the background
+// for users with a lot of history. It is downloaded through a secret link until it expires.
+type DataExport struct {
+	ID          int64            `json:"id"`
+	UserID      int64            `json:"user_id"`
+	Status      DataExportStatus `json:"status"`
+	TokenHash   string           `json:"-"`
+	Archive     []byte           `json:"-"`
+	SizeBytes   int64            `json:"size_bytes"`
+	Error       string           `json:"error,omitempty"`
+	DownloadURL string           `json:"download_url,omitempty"` // Only returned when the export is requested
+	ExpiresAt   time.Time        `json:"expires_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// UserData holds everything stored about a user
+type UserData struct {
+	Profile     *User
+	Rentals     []*Rental
+	Payments    []*Payment
+	Sessions    []*Session
+	AuditEvents []*LoginAttempt
+}
+
+// DataExportRepository defines the interface for data export storage
+type DataExportRepository interface {
+	GetByID(id int64) (*DataExport, error)
+	GetByTokenHash(tokenHash string) (*DataExport, error)
+	CountUserRecords(userID int64) (int, error)
+	// Create fails with ErrDataExportPending while the user has an export being generated or
+	// one that can still be downloaded
+	Create(export *DataExport) (*DataExport, error)
+	Complete(id int64, archive []byte, expiresAt time.Time) error
+	Fail(id int64, reason string) error
+	FailPendingBefore(before time.Time, reason string) (int64, error)
+	DeleteExpired() (int64, error)
+}
+
+// DataExportService defines the interface for exporting a user's data
+type DataExportService interface {
+	// Export returns the archive right away for small exports. Larger exports are generated
+	// in the background and returned as a pending DataExport instead.
+	Export(userID int64, async bool) ([]byte, *DataExport, error)
+	GetByID(id, userID int64) (*DataExport, error)
+	Download(token string) (*DataExport, error)
+	PurgeExpired() (int64, error)
+	// FailStale marks background exports that have been pending for too long as failed
+	FailStale() (int64, error)
+}
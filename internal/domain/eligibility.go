@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+)
+
+// Reasons reported in BorrowingBlockReason.Code
+const (
+	BorrowingBlockOverdueRentals = "overdue_rentals"
+	BorrowingBlockUnpaidFees     = "unpaid_fees"
+)
+
+// BorrowingBlockReason is a reason a user may not rent books
+type BorrowingBlockReason struct {
+	Code    string `json:"code" example:"overdue_rentals"`
+	Message string `json:"message" example:"2 overdue rentals, at most 0 allowed"`
+}
+
+// Eligibility describes whether a user may rent books and why not
+type Eligibility struct {
+	UserID            int64                  `json:"user_id"`
+	Eligible          bool                   `json:"eligible"`
+	OverdueRentals    int                    `json:"overdue_rentals"`
+	MaxOverdueRentals int                    `json:"max_overdue_rentals"`
+	UnpaidFees        float64                `json:"unpaid_fees"`
+	MaxUnpaidFees     float64                `json:"max_unpaid_fees"`
+	Reasons           []BorrowingBlockReason `json:"reasons,omitempty"`
+}
+
+// BorrowingBlockedError lists every reason a user is blocked from borrowing
+type BorrowingBlockedError struct {
+	Reasons []BorrowingBlockReason
+}
+
+// Error returns the reasons as a single message
+func (e *BorrowingBlockedError) Error() string {
+	messages := make([]string, len(e.Reasons))
+	for i, r := range e.Reasons {
+		messages[i] = r.Message
+	}
+	return ErrBorrowingBlocked.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the wrapped error
+func (e *BorrowingBlockedError) Unwrap() error {
+	return ErrBorrowingBlocked
+}
+
+// ErrorDetails returns the reasons for the API response
+func (e *BorrowingBlockedError) ErrorDetails() interface{} {
+	return e.Reasons
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// EmailVerificationToken represents a single-use email verification token.
+// Only the SHA-256 hash of the token is stored; the raw token is emailed to the user.
+type EmailVerificationToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// EmailVerifier sends users a link to verify their email address
+type EmailVerifier interface {
+	// Send stores a new verification token for the user and emails the link to their address
+	Send(user *User) error
+}
+
+// EmailVerificationRepository defines the interface for email verification token data access
+type EmailVerificationRepository interface {
+	GetByTokenHash(tokenHash string) (*EmailVerificationToken, error)
+	Create(token *EmailVerificationToken) (*EmailVerificationToken, error)
+	MarkUsed(id int64) error
+}
@@ -22,6 +22,65 @@ var (
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidPassword   = errors.New("invalid password")
+	ErrTokenRevoked      = errors.New("token has been revoked")
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified  = errors.New("email address not verified")
+	ErrEmailAlreadyVerified = errors.New("email address is already verified")
+	ErrAccountLocked     = errors.New("account temporarily locked due to repeated failed logins")
+	ErrTooManyLoginAttempts = errors.New("too many login attempts, try again later")
+	ErrWeakPassword      = errors.New("password does not meet the password policy")
+	ErrAccountSuspended  = errors.New("account is suspended")
+	ErrUserNotDeleted    = errors.New("only deleted users can be anonymized")
+)
+
+// Two-factor authentication errors
+var (
+	ErrMFARequired       = errors.New("two-factor authentication required")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrollment not started")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication already enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+)
+
+// API key errors
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidAPIKey  = errors.New("invalid or expired api key")
+)
+
+// Authorization errors
+var (
+	ErrUnknownPermission      = errors.New("unknown permission")
+	ErrRolePermissionNotFound = errors.New("role does not have this permission")
+	ErrImpersonationForbidden = errors.New("this user cannot be impersonated")
+)
+
+// OpenID Connect errors
+var (
+	ErrOIDCProviderNotFound = errors.New("identity provider not found")
+	ErrInvalidOIDCState     = errors.New("invalid or expired login state")
+	ErrOIDCLoginFailed      = errors.New("identity provider login failed")
+	ErrOIDCAccountConflict  = errors.New("an account with this email already exists and cannot be linked automatically")
+	ErrIdentityNotFound     = errors.New("external identity not found")
+)
+
+// User import errors
+var (
+	ErrUserImportNotFound = errors.New("user import not found")
+	ErrInvalidUserImport  = errors.New("user import file has invalid rows")
+)
+
+// Data export errors
+var (
+	ErrDataExportNotFound = errors.New("data export not found or expired")
+	ErrDataExportNotReady = errors.New("data export is not ready yet")
+	ErrDataExportPending  = errors.New("a data export is already being generated or ready to download")
+)
+
+// Session errors
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrTokenReuseDetected = errors.New("refresh token reuse detected")
 )
 
 // Book errors
@@ -37,12 +96,44 @@ var (
 	ErrCategoryAlreadyExists = errors.New("category already exists")
 )
 
+// Book copy errors
+var (
+	ErrBookCopyNotFound      = errors.New("book copy not found")
+	ErrBookCopyAlreadyExists = errors.New("book copy with this barcode already exists")
+	ErrBookCopyNotAvailable  = errors.New("book copy is not available")
+)
+
 // Rental errors
 var (
-	ErrRentalNotFound      = errors.New("rental not found")
-	ErrRentalAlreadyExists = errors.New("rental already exists")
-	ErrRentalNotActive     = errors.New("rental not active")
-	ErrRentalOverdue       = errors.New("rental is overdue")
+	ErrRentalNotFound        = errors.New("rental not found")
+	ErrRentalAlreadyExists   = errors.New("rental already exists")
+	ErrRentalNotActive       = errors.New("rental not active")
+	ErrRentalOverdue         = errors.New("rental is overdue")
+	ErrRentalLimitReached    = errors.New("membership plan rental limit reached")
+	ErrExtensionLimitReached = errors.New("membership plan extension limit reached")
+	ErrBorrowingBlocked      = errors.New("borrowing is blocked")
+	ErrHouseholdLimitReached = errors.New("household rental limit reached")
+)
+
+// Reservation errors
+var (
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrAlreadyReserved     = errors.New("book is already reserved by this user")
+	ErrReservationNotOpen  = errors.New("reservation is no longer open")
+	ErrAlreadyBorrowed     = errors.New("book is already on loan to this user")
+)
+
+// Membership plan errors
+var (
+	ErrMembershipPlanNotFound      = errors.New("membership plan not found")
+	ErrMembershipPlanAlreadyExists = errors.New("membership plan already exists")
+)
+
+// Household errors
+var (
+	ErrHouseholdNotFound     = errors.New("household not found")
+	ErrAlreadyInHousehold    = errors.New("user already belongs to a household")
+	ErrNotHouseholdDependent = errors.New("user is not a dependent of the household")
 )
 
 // Payment errors
@@ -53,10 +144,36 @@ var (
 	ErrInvalidPaymentStatus = errors.New("invalid payment status")
 )
 
+// Loan rule errors
+var (
+	ErrLoanRuleNotFound      = errors.New("loan rule not found")
+	ErrLoanRuleAlreadyExists = errors.New("a loan rule with the same category, membership plan and book already exists")
+)
+
+// Library calendar errors
+var (
+	ErrClosureNotFound      = errors.New("closure not found")
+	ErrClosureAlreadyExists = errors.New("the library is already closed on this date")
+)
+
+// Fine errors
+var (
+	ErrFineNotFound       = errors.New("fine not found")
+	ErrFineNotOutstanding = errors.New("fine is already paid or waived")
+	ErrFineOverpaid       = errors.New("payment exceeds the outstanding balance of the fine")
+)
+
 // ErrorResponse represents an error response for API
 type ErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Error   string `json:"error" example:"Resource not found"`
+	Success bool        `json:"success" example:"false"`
+	Error   string      `json:"error" example:"Resource not found"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// DetailedError is implemented by errors that carry structured details for the API response
+type DetailedError interface {
+	error
+	ErrorDetails() interface{}
 }
 
 // AppError represents an application error
@@ -124,6 +241,15 @@ func NewForbiddenError(message string) *AppError {
 	}
 }
 
+// NewLimitReachedError creates a forbidden error for a plan limit that has been reached
+func NewLimitReachedError(err error, message string) *AppError {
+	return &AppError{
+		Err:     err,
+		Message: message,
+		Code:    403,
+	}
+}
+
 // NewInvalidInputError creates a new invalid input error
 func NewInvalidInputError(message string) *AppError {
 	return &AppError{
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// FineStatus defines the status of a fine
+type FineStatus string
+
+const (
+	// FineStatusOutstanding represents a fine that is not fully paid
+	FineStatusOutstanding FineStatus = "outstanding"
+	// FineStatusPaid represents a fine that was paid in full
+	FineStatusPaid FineStatus = "paid"
+	// FineStatusWaived represents a fine that a librarian waived
+	FineStatusWaived FineStatus = "waived"
+)
+
+// Fine is the late fee assessed when a rental is returned after its due date
+type Fine struct {
+	ID           int64      `json:"id"`
+	RentalID     int64      `json:"rental_id"`
+	UserID       int64      `json:"user_id"`
+	Amount       float64    `json:"amount"`
+	AmountPaid   float64    `json:"amount_paid"`
+	Status       FineStatus `json:"status"`
+	WaivedBy     *int64     `json:"waived_by,omitempty"`
+	WaiverReason *string    `json:"waiver_reason,omitempty"`
+	WaivedAt     *time.Time `json:"waived_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	UserUsername string     `json:"user_username,omitempty"` // For join queries
+	BookTitle    string     `json:"book_title,omitempty"`    // For join queries
+}
+
+// Balance returns the amount of an outstanding fine that is still to be paid
+func (f *Fine) Balance() float64 {
+	if f.Status != FineStatusOutstanding {
+		return 0
+	}
+	return math.Round((f.Amount-f.AmountPaid)*100) / 100
+}
+
+// FineRepository defines the interface for fine data access. Fines are assessed when a
+// rental is returned and paid through payments that reference them.
+type FineRepository interface {
+	GetByID(id int64) (*Fine, error)
+	GetByRental(rentalID int64) (*Fine, error)
+	List(status FineStatus, limit, offset int32) ([]*Fine, error) // An empty status lists every fine
+	ListByUser(userID int64) ([]*Fine, error)
+	SumOutstandingByUser(userID int64) (float64, error)
+	Waive(id, waivedBy int64, reason string) (*Fine, error)
+}
+
+// FineService defines the interface for fine business logic
+type FineService interface {
+	GetByID(id int64) (*Fine, error)
+	GetByRental(rentalID int64) (*Fine, error)
+	List(status FineStatus, limit, offset int32) ([]*Fine, error)
+	ListByUser(userID int64) ([]*Fine, error)
+	Waive(id, waivedBy int64, reason string) (*Fine, error)
+}
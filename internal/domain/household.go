@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+)
+
+// Household links a guardian to the dependents whose rentals and payments they manage
+type Household struct {
+	ID                   int64                 `json:"id"`
+	Name                 string                `json:"name"`
+	GuardianID           int64                 `json:"guardian_id"`
+	MaxConcurrentRentals *int                  `json:"max_concurrent_rentals,omitempty"` // Shared by the guardian and all dependents, nil for no household limit
+	Dependents           []*HouseholdDependent `json:"dependents"`
+	CreatedAt            time.Time             `json:"created_at"`
+	UpdatedAt            time.Time             `json:"updated_at"`
+}
+
+// HouseholdDependent is a user whose rentals and payments the household guardian manages
+type HouseholdDependent struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"first_name,omitempty"`
+	LastName  string    `json:"last_name,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// MemberIDs returns the guardian followed by every dependent
+func (h *Household) MemberIDs() []int64 {
+	ids := []int64{h.GuardianID}
+	for _, d := range h.Dependents {
+		ids = append(ids, d.UserID)
+	}
+	return ids
+}
+
+// HouseholdRepository defines the interface for household data access
+type HouseholdRepository interface {
+	GetByID(id int64) (*Household, error)
+	GetByMember(userID int64) (*Household, error)
+	List() ([]*Household, error)
+	Create(household *Household) (*Household, error)
+	Update(household *Household) (*Household, error)
+	Delete(id int64) error
+	AddDependent(householdID, userID int64) error
+	RemoveDependent(householdID, userID int64) error
+	IsGuardianOf(guardianID, userID int64) (bool, error)
+}
+
+// HouseholdService defines the interface for household business logic
+type HouseholdService interface {
+	GetByID(id int64) (*Household, error)
+	GetForUser(userID int64) (*Household, error)
+	List() ([]*Household, error)
+	Create(household *Household, createdBy int64) (*Household, error)
+	Update(household *Household) (*Household, error)
+	Delete(id int64) error
+	AddDependent(householdID, userID, addedBy int64) (*Household, error)
+	RemoveDependent(householdID, userID, removedBy int64) (*Household, error)
+}
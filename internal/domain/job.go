@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+)
+
+// JobLockRepository hands out cluster-wide locks so that only one replica runs a background
+// job at a time
+type JobLockRepository interface {
+	// TryLock takes the lock for a job without waiting. When acquired is true the caller
+	// holds the lock until it calls unlock.
+	TryLock(ctx context.Context, job string) (unlock func(), acquired bool, err error)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// LoanRule sets the loan terms of the rentals it matches. A rule matches by category,
+// membership plan and book, where an empty criterion matches anything. When several rules
+// match a rental, the most specific one applies: a rule for the book wins over a rule for
+// its category, which wins over a rule for the plan alone.
+type LoanRule struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	CategoryID       *int64    `json:"category_id,omitempty"`
+	MembershipPlanID *int64    `json:"membership_plan_id,omitempty"`
+	BookID           *int64    `json:"book_id,omitempty"`
+	LoanPeriodDays   int       `json:"loan_period_days"`
+	MaxRenewals      int       `json:"max_renewals"`
+	FinePerDay       float64   `json:"fine_per_day"`
+	FineCap          *float64  `json:"fine_cap,omitempty"` // Fines are not capped when empty
+	GracePeriodDays  int       `json:"grace_period_days"`  // Late days that are not fined
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// LateFee returns the fine for a book kept the given number of days past its due date
+func (r *LoanRule) LateFee(lateDays int) float64 {
+	finedDays := lateDays - r.GracePeriodDays
+	if finedDays <= 0 {
+		return 0
+	}
+
+	fee := float64(finedDays) * r.FinePerDay
+	if r.FineCap != nil && fee > *r.FineCap {
+		fee = *r.FineCap
+	}
+	return math.Round(fee*100) / 100
+}
+
+// LoanRulePreview shows which loan terms apply when a user rents a book
+type LoanRulePreview struct {
+	UserID           int64     `json:"user_id"`
+	BookID           int64     `json:"book_id"`
+	CategoryID       int64     `json:"category_id,omitempty"`
+	MembershipPlanID int64     `json:"membership_plan_id"`
+	Rule             *LoanRule `json:"rule"`
+	Default          bool      `json:"default"` // No rule matches, the plan's loan terms and the default fine rate apply
+}
+
+// LoanRuleRepository defines the interface for loan rule data access
+type LoanRuleRepository interface {
+	GetByID(id int64) (*LoanRule, error)
+	List() ([]*LoanRule, error)
+	Match(bookID, categoryID, planID int64) (*LoanRule, error) // The most specific matching rule
+	Create(rule *LoanRule) (*LoanRule, error)
+	Update(rule *LoanRule) (*LoanRule, error)
+	Delete(id int64) error
+}
+
+// LoanRuleService defines the interface for loan rule business logic
+type LoanRuleService interface {
+	GetByID(id int64) (*LoanRule, error)
+	List() ([]*LoanRule, error)
+	Create(rule *LoanRule) (*LoanRule, error)
+	Update(rule *LoanRule) (*LoanRule, error)
+	Delete(id int64) error
+	Preview(userID, bookID int64) (*LoanRulePreview, error)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "testing"
+
+func TestLoanRuleLateFee(t *testing.T) {
+	fineCap := 2.00
+
+	tests := []struct {
+		name     string
+		rule     LoanRule
+		lateDays int
+		want     float64
+	}{
+		{"not late", LoanRule{FinePerDay: 0.50}, 0, 0},
+		{"fined for every late day", LoanRule{FinePerDay: 0.50}, 3, 1.50},
+		{"within the grace period", LoanRule{FinePerDay: 0.50, GracePeriodDays: 2}, 2, 0},
+		{"after the grace period", LoanRule{FinePerDay: 0.50, GracePeriodDays: 2}, 5, 1.50},
+		{"below the cap", LoanRule{FinePerDay: 0.50, FineCap: &fineCap}, 3, 1.50},
+		{"capped", LoanRule{FinePerDay: 0.50, FineCap: &fineCap}, 10, 2.00},
+		{"capped after the grace period", LoanRule{FinePerDay: 0.50, FineCap: &fineCap, GracePeriodDays: 1}, 10, 2.00},
+		{"rounded to cents", LoanRule{FinePerDay: 0.10}, 3, 0.30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.LateFee(tt.lateDays); got != tt.want {
+				t.Errorf("LateFee(%d) = %.2f, want %.2f", tt.lateDays, got, tt.want)
+			}
+		})
+	}
+}
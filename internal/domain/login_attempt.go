@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+// LoginAttempt represents a single login attempt, successful or not
+type LoginAttempt struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id,omitempty"`
+	Username  string    `json:"username"`
+	IPAddress string    `json:"ip_address"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginAttemptRepository defines the interface for login attempt data access
+type LoginAttemptRepository interface {
+	Record(attempt *LoginAttempt) error
+	CountFailuresByIP(ipAddress string, since time.Time) (int, error)
+	// GetUnknownUserFailures counts the failed attempts for a username that matches no account
+	// and returns when the last one was made
+	GetUnknownUserFailures(username string) (int, time.Time, error)
+	ListByUser(userID int64) ([]*LoginAttempt, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
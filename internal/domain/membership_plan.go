@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// MembershipPlan defines the borrowing limits of the members assigned to it
+type MembershipPlan struct {
+	ID                   int64     `json:"id"`
+	Name                 string    `json:"name"`
+	Description          string    `json:"description,omitempty"`
+	MaxConcurrentRentals int       `json:"max_concurrent_rentals"`
+	LoanPeriodDays       int       `json:"loan_period_days"`
+	MaxExtensions        int       `json:"max_extensions"`
+	MaxExtensionDays     int       `json:"max_extension_days"`
+	MonthlyFee           float64   `json:"monthly_fee"`
+	IsDefault            bool      `json:"is_default"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// MembershipPlanRepository defines the interface for membership plan data access
+type MembershipPlanRepository interface {
+	GetByID(id int64) (*MembershipPlan, error)
+	GetByName(name string) (*MembershipPlan, error)
+	GetDefault() (*MembershipPlan, error)
+	List() ([]*MembershipPlan, error)
+	Create(plan *MembershipPlan) (*MembershipPlan, error)
+	Update(plan *MembershipPlan) (*MembershipPlan, error)
+	Delete(id int64) error
+}
+
+// MembershipPlanService defines the interface for membership plan business logic
+type MembershipPlanService interface {
+	GetByID(id int64) (*MembershipPlan, error)
+	List() ([]*MembershipPlan, error)
+	Create(plan *MembershipPlan) (*MembershipPlan, error)
+	Update(plan *MembershipPlan) (*MembershipPlan, error)
+	Delete(id int64) error
+	GetForUser(userID int64) (*MembershipPlan, error)
+	AssignToUser(userID int64, planID *int64, assignedBy int64) (*MembershipPlan, error)
+}
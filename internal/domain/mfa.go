@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+)
+
+// MFASettings holds the TOTP two-factor authentication state of a user.
+// Enrollment is pending until the user confirms it with a valid code.
+type MFASettings struct {
+	UserID       int64      `json:"user_id"`
+	Secret       string     `json:"-"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"` // Time step of the last accepted code, used to reject replays
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// IsEnabled checks if two-factor authentication has been confirmed
+func (m *MFASettings) IsEnabled() bool {
+	return m.ConfirmedAt != nil
+}
+
+// MFARepository defines the interface for two-factor authentication data access
+type MFARepository interface {
+	GetByUserID(userID int64) (*MFASettings, error)
+	Upsert(settings *MFASettings) (*MFASettings, error)
+	Confirm(userID int64) error
+	UseStep(userID int64, step int64) error
+	ReplaceRecoveryCodes(userID int64, codeHashes []string) error
+	UseRecoveryCode(userID int64, codeHash string) error
+}
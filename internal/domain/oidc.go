@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+)
+
+// UserIdentity links a user to their account at an external OpenID Connect provider
+type UserIdentity struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// OIDCAuthRequest holds the state of an OpenID Connect login between the redirect to the
+// provider and the callback. Only the SHA-256 hash of the state parameter is stored.
+type OIDCAuthRequest struct {
+	ID           int64     `json:"id"`
+	StateHash    string    `json:"-"`
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"-"`
+	Nonce        string    `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UserIdentityRepository defines the interface for external identity data access
+type UserIdentityRepository interface {
+	GetByProviderSubject(provider, subject string) (*UserIdentity, error)
+	Create(identity *UserIdentity) (*UserIdentity, error)
+	TouchLastLogin(id int64) error
+}
+
+// OIDCAuthRequestRepository defines the interface for pending OpenID Connect login data access
+type OIDCAuthRequestRepository interface {
+	Create(request *OIDCAuthRequest) (*OIDCAuthRequest, error)
+	Consume(stateHash string) (*OIDCAuthRequest, error)
+	DeleteExpired() (int64, error)
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+)
+
+// Password policy rules reported in PasswordViolation.Rule
+const (
+	PasswordRuleMinLength = "min_length"
+	PasswordRuleMaxLength = "max_length"
+	PasswordRuleUppercase = "uppercase"
+	PasswordRuleLowercase = "lowercase"
+	PasswordRuleDigit     = "digit"
+	PasswordRuleSymbol    = "symbol"
+	PasswordRuleReused    = "not_reused"
+	PasswordRuleBreached  = "not_breached"
+)
+
+// PasswordViolation is a password policy rule that a password failed
+type PasswordViolation struct {
+	Rule    string `json:"rule" example:"min_length"`
+	Message string `json:"message" example:"must be at least 8 characters long"`
+}
+
+// PasswordPolicyError lists every password policy rule a password failed
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error returns the failed rules as a single message
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return "password " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the wrapped error
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// ErrorDetails returns the failed rules for the API response
+func (e *PasswordPolicyError) ErrorDetails() interface{} {
+	return e.Violations
+}
+
+// PasswordPolicy validates new passwords and remembers them for the reuse check
+type PasswordPolicy interface {
+	// Validate checks a new password. The user is nil when the account does not exist yet.
+	Validate(password string, user *User) error
+	// Remember records a password hash the user has set
+	Remember(userID int64, passwordHash string) error
+}
+
+// PasswordHistoryRepository defines the interface for password history data access
+type PasswordHistoryRepository interface {
+	ListRecent(userID int64, limit int) ([]string, error)
+	Add(userID int64, passwordHash string) error
+	Prune(userID int64, keep int) error
+}
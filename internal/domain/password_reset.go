@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// PasswordResetToken represents a single-use password reset token.
+// Only the SHA-256 hash of the token is stored; the raw token is emailed to the user.
+type PasswordResetToken struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PasswordResetRepository defines the interface for password reset token data access
+type PasswordResetRepository interface {
+	GetByTokenHash(tokenHash string) (*PasswordResetToken, error)
+	Create(token *PasswordResetToken) (*PasswordResetToken, error)
+	MarkUsed(id int64) error
+	InvalidateByUser(userID int64) error
+}
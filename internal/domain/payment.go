@@ -23,6 +23,7 @@ type Payment struct {
 	ID            int64         `json:"id"`
 	UserID        int64         `json:"user_id"`
 	RentalID      *int64        `json:"rental_id,omitempty"`
+	FineID        *int64        `json:"fine_id,omitempty"`
 	Amount        float64       `json:"amount"`
 	PaymentDate   time.Time     `json:"payment_date"`
 	PaymentMethod string        `json:"payment_method,omitempty"`
@@ -18,18 +18,28 @@ const (
 
 // Rental represents a book rental in the system
 type Rental struct {
-	ID           int64        `json:"id"`
-	UserID       int64        `json:"user_id"`
-	BookID       int64        `json:"book_id"`
-	RentalDate   time.Time    `json:"rental_date"`
-	DueDate      time.Time    `json:"due_date"`
-	ReturnDate   *time.Time   `json:"return_date,omitempty"`
-	Status       RentalStatus `json:"status"`
-	CreatedAt    time.Time    `json:"created_at"`
-	UpdatedAt    time.Time    `json:"updated_at"`
-	UserUsername string       `json:"user_username,omitempty"` // For join queries
-	BookTitle    string       `json:"book_title,omitempty"`    // For join queries
-	BookAuthor   string       `json:"book_author,omitempty"`   // For join queries
+	ID             int64        `json:"id"`
+	UserID         int64        `json:"user_id"`
+	BookID         int64        `json:"book_id"`
+	CopyID         *int64       `json:"copy_id,omitempty"`
+	RentalDate     time.Time    `json:"rental_date"`
+	DueDate        time.Time    `json:"due_date"`
+	ReturnDate     *time.Time   `json:"return_date,omitempty"`
+	Status         RentalStatus `json:"status"`
+	ExtensionCount int          `json:"extension_count"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	UserUsername   string       `json:"user_username,omitempty"` // For join queries
+	BookTitle      string       `json:"book_title,omitempty"`    // For join queries
+	BookAuthor     string       `json:"book_author,omitempty"`   // For join queries
+	CopyBarcode    *string      `json:"copy_barcode,omitempty"`  // For join queries
+}
+
+// RentalLimits are the borrowing limits a rental is checked against when it is stored, so that
+// concurrent checkouts cannot exceed them
+type RentalLimits struct {
+	MaxActiveRentals int   // Books the user may have on loan at a time
+	HouseholdID      int64 // Household whose shared limit the rental counts towards, 0 for none
 }
 
 // RentalRepository defines the interface for rental data access
@@ -40,10 +50,14 @@ type RentalRepository interface {
 	ListByBook(bookID int64, limit, offset int32) ([]*Rental, error)
 	ListActive(limit, offset int32) ([]*Rental, error)
 	ListOverdue(limit, offset int32) ([]*Rental, error)
-	Create(rental *Rental) (*Rental, error)
-	UpdateStatus(id int64, status RentalStatus) (*Rental, error)
-	Return(id int64) (*Rental, error)
-	Extend(id int64, newDueDate time.Time) (*Rental, error)
+	GetOpenByCopy(copyID int64) (*Rental, error)
+	CountOverdueByUser(userID int64) (int, error)
+	CountActiveByUserAndBook(userID, bookID int64) (int, error)
+	ListLateByUser(userID int64) ([]*Rental, error)
+	Create(rental *Rental, limits RentalLimits) (*Rental, error) // Fails with ErrRentalLimitReached or ErrHouseholdLimitReached at a limit
+	MarkOverdue() (int64, error)
+	Return(id int64, lateFee float64, holdUntil time.Time) (*Rental, error)  // Assesses a fine of lateFee if positive, the copy is held until holdUntil for the next reservation of its book
+	Extend(id int64, newDueDate time.Time, maxRenewals int) (*Rental, error) // Fails with ErrExtensionLimitReached once renewed maxRenewals times
 	Delete(id int64) error
 }
 
@@ -56,8 +70,12 @@ type RentalService interface {
 	ListActive(limit, offset int32) ([]*Rental, error)
 	ListOverdue(limit, offset int32) ([]*Rental, error)
 	Create(rental *Rental) (*Rental, error)
-	Return(id int64) (*Rental, error)
+	Checkout(barcode string, userID int64, dueDate time.Time) (*Rental, error)
+	Return(id int64) (*Rental, *Fine, error) // The fine is nil when the rental is returned on time
+	ReturnByBarcode(barcode string) (*Rental, *Fine, error)
 	Extend(id int64, days int) (*Rental, error)
 	CalculateLateFee(rental *Rental) (float64, error)
 	IsOverdue(rental *Rental) bool
+	MarkOverdue() (int64, error)
+	CheckEligibility(userID int64) (*Eligibility, error)
 }
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+)
+
+// ReservationStatus defines the status of a reservation
+type ReservationStatus string
+
+const (
+	// ReservationStatusWaiting represents a reservation queued for the next copy of its book
+	ReservationStatusWaiting ReservationStatus = "waiting"
+	// ReservationStatusReady represents a reservation with a copy on hold for pickup
+	ReservationStatusReady ReservationStatus = "ready"
+	// ReservationStatusFulfilled represents a reservation whose member rented the book
+	ReservationStatusFulfilled ReservationStatus = "fulfilled"
+	// ReservationStatusCancelled represents a reservation cancelled before pickup
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+	// ReservationStatusExpired represents a reservation whose hold was not picked up in time
+	ReservationStatusExpired ReservationStatus = "expired"
+)
+
+// Reservation represents a member's place in the queue for a book. Reservations are served
+// first come, first served: a copy that comes back is held for the oldest waiting reservation.
+type Reservation struct {
+	ID            int64             `json:"id"`
+	UserID        int64             `json:"user_id"`
+	BookID        int64             `json:"book_id"`
+	CopyID        *int64            `json:"copy_id,omitempty"` // The copy on hold, set once the reservation is ready
+	Status        ReservationStatus `json:"status"`
+	QueuePosition int               `json:"queue_position,omitempty"` // 1 for the next in line, only set while waiting
+	HoldExpiresAt *time.Time        `json:"hold_expires_at,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	UserUsername  string            `json:"user_username,omitempty"` // For join queries
+	BookTitle     string            `json:"book_title,omitempty"`    // For join queries
+	CopyBarcode   *string           `json:"copy_barcode,omitempty"`  // For join queries
+}
+
+// IsOpen reports whether the reservation is still waiting or on hold
+func (r *Reservation) IsOpen() bool {
+	return r.Status == ReservationStatusWaiting || r.Status == ReservationStatusReady
+}
+
+// ReservationRepository defines the interface for reservation data access. Copies that are
+// released by a cancellation or an expired hold pass to the next reservation in line and are
+// held until holdUntil.
+type ReservationRepository interface {
+	GetByID(id int64) (*Reservation, error)
+	ListByUser(userID int64) ([]*Reservation, error)
+	ListOpenByBook(bookID int64) ([]*Reservation, error)
+	Create(reservation *Reservation) (*Reservation, error)
+	Cancel(id int64, holdUntil time.Time) (*Reservation, error)
+	ExpireHolds(holdUntil time.Time) (int64, error)
+	HoldAvailableCopies(holdUntil time.Time) (int64, error)
+}
+
+// ReservationService defines the interface for reservation business logic
+type ReservationService interface {
+	GetByID(id int64) (*Reservation, error)
+	ListByUser(userID int64) ([]*Reservation, error)
+	ListOpenByBook(bookID int64) ([]*Reservation, error)
+	Create(userID, bookID int64) (*Reservation, error)
+	Cancel(id int64) (*Reservation, error)
+	ProcessHolds() (int64, int64, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// Session represents a refresh-token family created by a single login.
+// Every refresh rotates RefreshTokenID; presenting any earlier token of the
+// family is treated as reuse and revokes the whole session.
+type Session struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	RefreshTokenID string     `json:"-"` // jti of the only refresh token currently valid for this session
+	UserAgent      string     `json:"user_agent,omitempty"`
+	IPAddress      string     `json:"ip_address,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	LastUsedAt     time.Time  `json:"last_used_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason  string     `json:"revoked_reason,omitempty"`
+	MFAVerified    bool       `json:"mfa_verified"` // Set when the login passed two-factor authentication
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Current        bool       `json:"current"` // Set when the session belongs to the requesting token
+}
+
+// IsActive checks if the session can still be used
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(time.Now())
+}
+
+// SessionRepository defines the interface for session data access
+type SessionRepository interface {
+	GetByID(id int64) (*Session, error)
+	ListActiveByUser(userID int64) ([]*Session, error)
+	ListByUser(userID int64) ([]*Session, error)
+	Create(session *Session) (*Session, error)
+	Rotate(id int64, oldTokenID, newTokenID string, expiresAt time.Time) error
+	Revoke(id int64, reason string) error
+	RevokeAllByUser(userID int64, reason string) error
+}
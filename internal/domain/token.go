@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+)
+
+// RevokedToken represents a JWT that has been explicitly invalidated before its expiry
+type RevokedToken struct {
+	JTI       string    `json:"jti"`
+	UserID    int64     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// RevokedTokenRepository defines the interface for the token revocation store
+type RevokedTokenRepository interface {
+	Revoke(token *RevokedToken) error
+	IsRevoked(jti string) (bool, error)
+	DeleteExpired() (int64, error)
+}
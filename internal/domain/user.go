@@ -16,17 +16,74 @@ const (
 	RoleMember UserRole = "member"
 )
 
+// UserStatus defines whether a user account can be used
+type UserStatus string
+
+const (
+	// UserStatusActive represents an account in normal use
+	UserStatusActive UserStatus = "active"
+	// UserStatusSuspended represents an account blocked by staff until it is reactivated
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusDeleted represents a soft-deleted account, kept for its rental and payment history
+	UserStatusDeleted UserStatus = "deleted"
+)
+
 // User represents a user in the system
 type User struct {
-	ID           int64     `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"` // Never expose password hash in JSON responses
-	FirstName    string    `json:"first_name,omitempty"`
-	LastName     string    `json:"last_name,omitempty"`
-	Role         UserRole  `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID                  int64      `json:"id"`
+	Username            string     `json:"username"`
+	Email               string     `json:"email"`
+	PasswordHash        string     `json:"-"` // Never expose password hash in JSON responses
+	FirstName           string     `json:"first_name,omitempty"`
+	LastName            string     `json:"last_name,omitempty"`
+	Role                UserRole   `json:"role"`
+	EmailVerifiedAt     *time.Time `json:"email_verified_at,omitempty"`
+	FailedLoginAttempts int        `json:"-"`
+	LockedUntil         *time.Time `json:"locked_until,omitempty"`
+	MembershipPlanID    *int64     `json:"membership_plan_id,omitempty"` // Nil means the default plan
+	Status              UserStatus `json:"status"`
+	DeletedAt           *time.Time `json:"deleted_at,omitempty"`
+	AnonymizedAt        *time.Time `json:"anonymized_at,omitempty"` // Set once personal data has been scrubbed
+	MustChangePassword  bool       `json:"must_change_password"`    // Set while the account still has a generated password
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// IsEmailVerified checks if the user has verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// IsLocked checks if logins for the user are currently blocked
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now())
+}
+
+// IsActive checks if the account may sign in and use the system
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+// Fields users can be sorted by
+const (
+	UserSortID        = "id"
+	UserSortUsername  = "username"
+	UserSortEmail     = "email"
+	UserSortName      = "name"
+	UserSortCreatedAt = "created_at"
+)
+
+// UserListParams represents parameters for searching and filtering users
+type UserListParams struct {
+	Search        string     `json:"search,omitempty"` // Matches username, email and name
+	Role          UserRole   `json:"role,omitempty"`
+	Status        UserStatus `json:"status,omitempty"` // Deleted users are only listed when asked for
+	CreatedFrom   time.Time  `json:"created_from,omitempty"`
+	CreatedBefore time.Time  `json:"created_before,omitempty"`
+	SortBy        string     `json:"sort_by,omitempty"`
+	SortDesc      bool       `json:"sort_desc,omitempty"`
+	Limit         int32      `json:"limit,omitempty"`
+	Offset        int32      `json:"offset,omitempty"`
 }
 
 // UserRepository defines the interface for user data access
@@ -34,11 +91,22 @@ type UserRepository interface {
 	GetByID(id int64) (*User, error)
 	GetByUsername(username string) (*User, error)
 	GetByEmail(email string) (*User, error)
-	List(limit, offset int32) ([]*User, error)
+	List(params UserListParams) ([]*User, int64, error)
 	Create(user *User) (*User, error)
+	CreateBatch(users []*User) ([]*User, error)
+	// CreateWithIdentity creates a user linked to an external identity in one transaction
+	CreateWithIdentity(user *User, identity *UserIdentity) (*User, error)
 	Update(user *User) (*User, error)
 	UpdatePassword(id int64, passwordHash string) error
+	MarkEmailVerified(id int64) error
+	IncrementFailedLogins(id int64) (int, error)
+	LockUntil(id int64, until time.Time) error
+	ResetFailedLogins(id int64) error
+	UpdateMembershipPlan(id int64, planID *int64) error
+	UpdateStatus(id int64, status UserStatus) error
 	Delete(id int64) error
+	ListDeletedBefore(before time.Time, limit int) ([]int64, error)
+	Anonymize(id int64) error
 }
 
 // UserService defines the interface for user business logic
@@ -46,10 +114,15 @@ type UserService interface {
 	GetByID(id int64) (*User, error)
 	GetByUsername(username string) (*User, error)
 	GetByEmail(email string) (*User, error)
-	List(limit, offset int32) ([]*User, error)
+	List(params UserListParams) ([]*User, int64, error)
 	Create(user *User, password string) (*User, error)
 	Update(user *User) (*User, error)
 	ChangePassword(id int64, currentPassword, newPassword string) error
-	Delete(id int64) error
+	Delete(id, deletedBy int64) error
 	ValidateCredentials(username, password string) (*User, error)
+	Unlock(id, unlockedBy int64) error
+	Suspend(id, suspendedBy int64) error
+	Reactivate(id, reactivatedBy int64) error
+	Anonymize(id, anonymizedBy int64) error
+	AnonymizeDeletedUsers() (int64, error)
 }
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// UserImportStatus defines the progress of a bulk user import
+type UserImportStatus string
+
+const (
+	// UserImportStatusPending represents an import whose accounts are still being created
+	UserImportStatusPending UserImportStatus = "pending"
+	// UserImportStatusCompleted represents an import whose accounts have all been created
+	UserImportStatusCompleted UserImportStatus = "completed"
+	// UserImportStatusFailed represents an import that created no accounts
+	UserImportStatusFailed UserImportStatus = "failed"
+)
+
+// UserImportRow is an account to create from a row of an import file
+type UserImportRow struct {
+	Row       int      `json:"row"` // Line in the file, the header being line 1
+	Username  string   `json:"username"`
+	Email     string   `json:"email"`
+	FirstName string   `json:"first_name,omitempty"`
+	LastName  string   `json:"last_name,omitempty"`
+	Role      UserRole `json:"role"`
+}
+
+// UserImportRowError is a problem with a row of an import file
+type UserImportRowError struct {
+	Row     int    `json:"row" example:"3"`
+	Field   string `json:"field,omitempty" example:"email"`
+	Message string `json:"message" example:"email is already in use"`
+}
+
+// UserImportValidation is the outcome of checking an import file without creating accounts
+type UserImportValidation struct {
+	TotalRows int                  `json:"total_rows"`
+	Valid     bool                 `json:"valid"`
+	Errors    []UserImportRowError `json:"errors,omitempty"`
+}
+
+// UserImportError lists every invalid row of an import file
+type UserImportError struct {
+	Errors []UserImportRowError
+}
+
+// Error returns the number of invalid rows as a single message
+func (e *UserImportError) Error() string {
+	return fmt.Sprintf("%s: %d error(s)", ErrInvalidUserImport.Error(), len(e.Errors))
+}
+
+// Unwrap returns the wrapped error
+func (e *UserImportError) Unwrap() error {
+	return ErrInvalidUserImport
+}
+
+// ErrorDetails returns the row errors for the API response
+func (e *UserImportError) ErrorDetails() interface{} {
+	return e.Errors
+}
+
+// UserImportJob is a bulk user import whose accounts are created in the background
+type UserImportJob struct {
+	ID           int64            `json:"id"`
+	Status       UserImportStatus `json:"status"`
+	SendInvites  bool             `json:"send_invites"`
+	TotalRows    int              `json:"total_rows"`
+	CreatedCount int              `json:"created_count"`
+	InvitesSent  int              `json:"invites_sent"`
+	Error        string           `json:"error,omitempty"`
+	CreatedBy    int64            `json:"created_by"`
+	CreatedAt    time.Time        `json:"created_at"`
+	CompletedAt  *time.Time       `json:"completed_at,omitempty"`
+}
+
+// UserImportRepository defines the interface for user import job data access
+type UserImportRepository interface {
+	GetByID(id int64) (*UserImportJob, error)
+	Create(job *UserImportJob) (*UserImportJob, error)
+	Complete(id int64, createdCount, invitesSent int) error
+	Fail(id int64, reason string) error
+	FailPendingBefore(before time.Time, reason string) (int64, error) // Fails the jobs still pending that were created before the given time
+}
+
+// UserImportService defines the interface for importing users from CSV files
+type UserImportService interface {
+	// Validate checks every row of an import file without creating accounts
+	Validate(file io.Reader) (*UserImportValidation, error)
+	// Import checks an import file and creates its accounts in the background
+	Import(file io.Reader, sendInvites bool, importedBy int64) (*UserImportJob, error)
+	GetByID(id int64) (*UserImportJob, error)
+	// FailStale marks imports that have been pending for too long as failed
+	FailStale() (int64, error)
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/service"
+	"github.com/SimpleBookRental/backend/pkg/config"
+)
+
+// RegisterAll registers the application's background jobs with the scheduler
+func RegisterAll(scheduler *Scheduler, services *service.Service, cfg *config.Config) {
+	// Rentals past their due date are marked overdue in a single statement rather than
+	// one row at a time while serving reads
+	scheduler.Register(Job{
+		Name:     "mark_overdue_rentals",
+		Interval: cfg.Jobs.MarkOverdueInterval,
+		Run: func(ctx context.Context) error {
+			_, err := services.Rental.MarkOverdue()
+			return err
+		},
+	})
+
+	// Holds that were not picked up pass to the next in line, and copies on the shelf
+	// are held for members who are waiting
+	scheduler.Register(Job{
+		Name:     "process_reservation_holds",
+		Interval: cfg.Jobs.ReservationHoldsInterval,
+		Run: func(ctx context.Context) error {
+			_, _, err := services.Reservation.ProcessHolds()
+			return err
+		},
+	})
+
+	// Revocation entries for tokens that have expired anyway, login attempts past their
+	// retention period and expired data exports are purged, deleted accounts are
+	// anonymized once their retention period has passed, and imports and exports
+	// interrupted by a restart are marked as failed
+	scheduler.Register(Job{
+		Name:     "purge_expired_data",
+		Interval: cfg.JWT.RevocationPurgeInterval,
+		Run: func(ctx context.Context) error {
+			var errs []error
+			for _, purge := range []func() (int64, error){
+				services.Auth.PurgeRevokedTokens,
+				services.Auth.PurgeLoginAttempts,
+				services.Auth.PurgeOIDCAuthRequests,
+				services.DataExport.PurgeExpired,
+				services.User.AnonymizeDeletedUsers,
+				services.UserImport.FailStale,
+				services.DataExport.FailStale,
+			} {
+				if ctx.Err() != nil {
+					break
+				}
+				if _, err := purge(); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		},
+	})
+}
@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Job is a unit of background work that runs on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration // Zero or negative disables the job
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their intervals. Every run takes the job's cluster-wide
+// lock first, so when several replicas are deployed a job never runs on two of them at once.
+// Jobs should therefore be idempotent rather than rely on running exactly once per interval.
+type Scheduler struct {
+	locks  domain.JobLockRepository
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	logger *logger.Logger
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(locks domain.JobLockRepository, logger *logger.Logger) *Scheduler {
+	return &Scheduler{
+		locks:  locks,
+		logger: logger,
+	}
+}
+
+// Register adds a job to the scheduler. Jobs have to be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		s.logger.Info("Job disabled", zap.String("job", job.Name))
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job once and then on its interval until Stop is called
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+
+	s.logger.Info("Job scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop cancels the running jobs and waits for them to finish, or until ctx is done
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn("Job scheduler stopped before all jobs finished")
+		return ctx.Err()
+	}
+}
+
+// loop runs a job on its interval until ctx is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job unless another replica holds its lock
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	unlock, acquired, err := s.locks.TryLock(ctx, job.Name)
+	if err != nil {
+		s.logger.Error("Failed to lock job", zap.String("job", job.Name), zap.Error(err))
+		return
+	}
+	if !acquired {
+		s.logger.Debug("Job is running on another replica", zap.String("job", job.Name))
+		return
+	}
+	defer unlock()
+
+	started := time.Now()
+	if err := job.Run(ctx); err != nil {
+		s.logger.Error("Job failed", zap.String("job", job.Name), zap.Duration("duration", time.Since(started)), zap.Error(err))
+		return
+	}
+	s.logger.Debug("Job finished", zap.String("job", job.Name), zap.Duration("duration", time.Since(started)))
+}
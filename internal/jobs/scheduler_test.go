@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// lockedRepo returns a job lock repository that grants or refuses every lock
+func lockedRepo(ctrl *gomock.Controller, acquired bool) *mocks.MockJobLockRepository {
+	locks := mocks.NewMockJobLockRepository(ctrl)
+	locks.EXPECT().TryLock(gomock.Any(), gomock.Any()).Return(func() {}, acquired, nil).AnyTimes()
+	return locks
+}
+
+func TestSchedulerRunsOnInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewScheduler(lockedRepo(ctrl, true), &logger.Logger{Logger: zap.NewNop()})
+
+	var runs, disabledRuns atomic.Int32
+	s.Register(Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Register(Job{Name: "disabled", Interval: 0, Run: func(ctx context.Context) error {
+		disabledRuns.Add(1)
+		return nil
+	}})
+
+	s.Start()
+	time.Sleep(55 * time.Millisecond)
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	// Once at start and then on every tick
+	stopped := runs.Load()
+	if stopped < 3 {
+		t.Errorf("job ran %d times, want at least 3", stopped)
+	}
+	if disabledRuns.Load() != 0 {
+		t.Errorf("disabled job ran %d times, want 0", disabledRuns.Load())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Errorf("job ran %d times after Stop, want 0", runs.Load()-stopped)
+	}
+}
+
+func TestSchedulerSkipsLockedJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewScheduler(lockedRepo(ctrl, false), &logger.Logger{Logger: zap.NewNop()})
+
+	var runs atomic.Int32
+	s.Register(Job{Name: "locked", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+
+	s.Start()
+	time.Sleep(35 * time.Millisecond)
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if runs.Load() != 0 {
+		t.Errorf("job held by another replica ran %d times, want 0", runs.Load())
+	}
+}
+
+func TestSchedulerStopWaitsForRunningJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := NewScheduler(lockedRepo(ctrl, true), &logger.Logger{Logger: zap.NewNop()})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s.Register(Job{Name: "slow", Interval: time.Hour, Run: func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}})
+
+	s.Start()
+	<-started
+
+	// A job that does not finish before the deadline makes Stop give up
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(release)
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() error = %v once the job finished", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/authorization.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/authorization.go -destination=internal/mocks/authorization_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPermissionRepository is a mock of PermissionRepository interface.
+type MockPermissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPermissionRepositoryMockRecorder is the mock recorder for MockPermissionRepository.
+type MockPermissionRepositoryMockRecorder struct {
+	mock *MockPermissionRepository
+}
+
+// NewMockPermissionRepository creates a new mock instance.
+func NewMockPermissionRepository(ctrl *gomock.Controller) *MockPermissionRepository {
+	mock := &MockPermissionRepository{ctrl: ctrl}
+	mock.recorder = &MockPermissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionRepository) EXPECT() *MockPermissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Grant mocks base method.
+func (m *MockPermissionRepository) Grant(role domain.UserRole, permission domain.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", role, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockPermissionRepositoryMockRecorder) Grant(role, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockPermissionRepository)(nil).Grant), role, permission)
+}
+
+// List mocks base method.
+func (m *MockPermissionRepository) List() ([]*domain.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPermissionRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPermissionRepository)(nil).List))
+}
+
+// Revoke mocks base method.
+func (m *MockPermissionRepository) Revoke(role domain.UserRole, permission domain.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", role, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockPermissionRepositoryMockRecorder) Revoke(role, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockPermissionRepository)(nil).Revoke), role, permission)
+}
+
+// MockAuthorizationService is a mock of AuthorizationService interface.
+type MockAuthorizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthorizationServiceMockRecorder is the mock recorder for MockAuthorizationService.
+type MockAuthorizationServiceMockRecorder struct {
+	mock *MockAuthorizationService
+}
+
+// NewMockAuthorizationService creates a new mock instance.
+func NewMockAuthorizationService(ctrl *gomock.Controller) *MockAuthorizationService {
+	mock := &MockAuthorizationService{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorizationService) EXPECT() *MockAuthorizationServiceMockRecorder {
+	return m.recorder
+}
+
+// Can mocks base method.
+func (m *MockAuthorizationService) Can(subject domain.Subject, permission domain.Permission) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Can", subject, permission)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Can indicates an expected call of Can.
+func (mr *MockAuthorizationServiceMockRecorder) Can(subject, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Can", reflect.TypeOf((*MockAuthorizationService)(nil).Can), subject, permission)
+}
+
+// CanAccess mocks base method.
+func (m *MockAuthorizationService) CanAccess(subject domain.Subject, permission domain.ScopedPermission, ownerID int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanAccess", subject, permission, ownerID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanAccess indicates an expected call of CanAccess.
+func (mr *MockAuthorizationServiceMockRecorder) CanAccess(subject, permission, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanAccess", reflect.TypeOf((*MockAuthorizationService)(nil).CanAccess), subject, permission, ownerID)
+}
+
+// CanActFor mocks base method.
+func (m *MockAuthorizationService) CanActFor(subject domain.Subject, userID int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanActFor", subject, userID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanActFor indicates an expected call of CanActFor.
+func (mr *MockAuthorizationServiceMockRecorder) CanActFor(subject, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanActFor", reflect.TypeOf((*MockAuthorizationService)(nil).CanActFor), subject, userID)
+}
+
+// Grant mocks base method.
+func (m *MockAuthorizationService) Grant(role domain.UserRole, permission domain.Permission, grantedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Grant", role, permission, grantedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Grant indicates an expected call of Grant.
+func (mr *MockAuthorizationServiceMockRecorder) Grant(role, permission, grantedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Grant", reflect.TypeOf((*MockAuthorizationService)(nil).Grant), role, permission, grantedBy)
+}
+
+// ListRolePermissions mocks base method.
+func (m *MockAuthorizationService) ListRolePermissions() (map[domain.UserRole][]domain.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRolePermissions")
+	ret0, _ := ret[0].(map[domain.UserRole][]domain.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockAuthorizationServiceMockRecorder) ListRolePermissions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockAuthorizationService)(nil).ListRolePermissions))
+}
+
+// Revoke mocks base method.
+func (m *MockAuthorizationService) Revoke(role domain.UserRole, permission domain.Permission, revokedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", role, permission, revokedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAuthorizationServiceMockRecorder) Revoke(role, permission, revokedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAuthorizationService)(nil).Revoke), role, permission, revokedBy)
+}
@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/book_copy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/book_copy.go -destination=internal/mocks/book_copy_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookCopyRepository is a mock of BookCopyRepository interface.
+type MockBookCopyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookCopyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBookCopyRepositoryMockRecorder is the mock recorder for MockBookCopyRepository.
+type MockBookCopyRepositoryMockRecorder struct {
+	mock *MockBookCopyRepository
+}
+
+// NewMockBookCopyRepository creates a new mock instance.
+func NewMockBookCopyRepository(ctrl *gomock.Controller) *MockBookCopyRepository {
+	mock := &MockBookCopyRepository{ctrl: ctrl}
+	mock.recorder = &MockBookCopyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookCopyRepository) EXPECT() *MockBookCopyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookCopyRepository) Create(copy *domain.BookCopy) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", copy)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookCopyRepositoryMockRecorder) Create(copy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookCopyRepository)(nil).Create), copy)
+}
+
+// GetByBarcode mocks base method.
+func (m *MockBookCopyRepository) GetByBarcode(barcode string) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBarcode", barcode)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBarcode indicates an expected call of GetByBarcode.
+func (mr *MockBookCopyRepositoryMockRecorder) GetByBarcode(barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBarcode", reflect.TypeOf((*MockBookCopyRepository)(nil).GetByBarcode), barcode)
+}
+
+// GetByID mocks base method.
+func (m *MockBookCopyRepository) GetByID(id int64) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBookCopyRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookCopyRepository)(nil).GetByID), id)
+}
+
+// ListByBook mocks base method.
+func (m *MockBookCopyRepository) ListByBook(bookID int64) ([]*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", bookID)
+	ret0, _ := ret[0].([]*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockBookCopyRepositoryMockRecorder) ListByBook(bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockBookCopyRepository)(nil).ListByBook), bookID)
+}
+
+// Update mocks base method.
+func (m *MockBookCopyRepository) Update(copy *domain.BookCopy) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", copy)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookCopyRepositoryMockRecorder) Update(copy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookCopyRepository)(nil).Update), copy)
+}
+
+// MockBookCopyService is a mock of BookCopyService interface.
+type MockBookCopyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookCopyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBookCopyServiceMockRecorder is the mock recorder for MockBookCopyService.
+type MockBookCopyServiceMockRecorder struct {
+	mock *MockBookCopyService
+}
+
+// NewMockBookCopyService creates a new mock instance.
+func NewMockBookCopyService(ctrl *gomock.Controller) *MockBookCopyService {
+	mock := &MockBookCopyService{ctrl: ctrl}
+	mock.recorder = &MockBookCopyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookCopyService) EXPECT() *MockBookCopyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookCopyService) Create(copy *domain.BookCopy) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", copy)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookCopyServiceMockRecorder) Create(copy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookCopyService)(nil).Create), copy)
+}
+
+// GetByBarcode mocks base method.
+func (m *MockBookCopyService) GetByBarcode(barcode string) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBarcode", barcode)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBarcode indicates an expected call of GetByBarcode.
+func (mr *MockBookCopyServiceMockRecorder) GetByBarcode(barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBarcode", reflect.TypeOf((*MockBookCopyService)(nil).GetByBarcode), barcode)
+}
+
+// ListByBook mocks base method.
+func (m *MockBookCopyService) ListByBook(bookID int64) ([]*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByBook", bookID)
+	ret0, _ := ret[0].([]*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByBook indicates an expected call of ListByBook.
+func (mr *MockBookCopyServiceMockRecorder) ListByBook(bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByBook", reflect.TypeOf((*MockBookCopyService)(nil).ListByBook), bookID)
+}
+
+// Update mocks base method.
+func (m *MockBookCopyService) Update(copy *domain.BookCopy) (*domain.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", copy)
+	ret0, _ := ret[0].(*domain.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookCopyServiceMockRecorder) Update(copy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookCopyService)(nil).Update), copy)
+}
@@ -55,21 +55,6 @@ func (mr *MockBookRepositoryMockRecorder) Create(book any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookRepository)(nil).Create), book)
 }
 
-// DecrementAvailableCopies mocks base method.
-func (m *MockBookRepository) DecrementAvailableCopies(id int64) (*domain.Book, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DecrementAvailableCopies", id)
-	ret0, _ := ret[0].(*domain.Book)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// DecrementAvailableCopies indicates an expected call of DecrementAvailableCopies.
-func (mr *MockBookRepositoryMockRecorder) DecrementAvailableCopies(id any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementAvailableCopies", reflect.TypeOf((*MockBookRepository)(nil).DecrementAvailableCopies), id)
-}
-
 // Delete mocks base method.
 func (m *MockBookRepository) Delete(id int64) error {
 	m.ctrl.T.Helper()
@@ -114,21 +99,6 @@ func (mr *MockBookRepositoryMockRecorder) GetByISBN(isbn any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockBookRepository)(nil).GetByISBN), isbn)
 }
 
-// IncrementAvailableCopies mocks base method.
-func (m *MockBookRepository) IncrementAvailableCopies(id int64) (*domain.Book, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IncrementAvailableCopies", id)
-	ret0, _ := ret[0].(*domain.Book)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IncrementAvailableCopies indicates an expected call of IncrementAvailableCopies.
-func (mr *MockBookRepositoryMockRecorder) IncrementAvailableCopies(id any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAvailableCopies", reflect.TypeOf((*MockBookRepository)(nil).IncrementAvailableCopies), id)
-}
-
 // List mocks base method.
 func (m *MockBookRepository) List(limit, offset int32) ([]*domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -189,21 +159,6 @@ func (mr *MockBookRepositoryMockRecorder) Update(book any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), book)
 }
 
-// UpdateCopies mocks base method.
-func (m *MockBookRepository) UpdateCopies(id int64, totalCopies, availableCopies int32) (*domain.Book, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCopies", id, totalCopies, availableCopies)
-	ret0, _ := ret[0].(*domain.Book)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UpdateCopies indicates an expected call of UpdateCopies.
-func (mr *MockBookRepositoryMockRecorder) UpdateCopies(id, totalCopies, availableCopies any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopies", reflect.TypeOf((*MockBookRepository)(nil).UpdateCopies), id, totalCopies, availableCopies)
-}
-
 // MockBookService is a mock of BookService interface.
 type MockBookService struct {
 	ctrl     *gomock.Controller
@@ -361,18 +316,3 @@ func (mr *MockBookServiceMockRecorder) Update(book any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookService)(nil).Update), book)
 }
-
-// UpdateCopies mocks base method.
-func (m *MockBookService) UpdateCopies(id int64, totalCopies, availableCopies int32) (*domain.Book, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCopies", id, totalCopies, availableCopies)
-	ret0, _ := ret[0].(*domain.Book)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// UpdateCopies indicates an expected call of UpdateCopies.
-func (mr *MockBookServiceMockRecorder) UpdateCopies(id, totalCopies, availableCopies any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCopies", reflect.TypeOf((*MockBookService)(nil).UpdateCopies), id, totalCopies, availableCopies)
-}
@@ -0,0 +1,245 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/calendar.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/calendar.go -destination=internal/mocks/calendar_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateClosure mocks base method.
+func (m *MockCalendarRepository) CreateClosure(closure *domain.Closure) (*domain.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClosure", closure)
+	ret0, _ := ret[0].(*domain.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClosure indicates an expected call of CreateClosure.
+func (mr *MockCalendarRepositoryMockRecorder) CreateClosure(closure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClosure", reflect.TypeOf((*MockCalendarRepository)(nil).CreateClosure), closure)
+}
+
+// DeleteClosure mocks base method.
+func (m *MockCalendarRepository) DeleteClosure(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClosure", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClosure indicates an expected call of DeleteClosure.
+func (mr *MockCalendarRepositoryMockRecorder) DeleteClosure(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClosure", reflect.TypeOf((*MockCalendarRepository)(nil).DeleteClosure), id)
+}
+
+// ImportClosures mocks base method.
+func (m *MockCalendarRepository) ImportClosures(closures []*domain.Closure) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportClosures", closures)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportClosures indicates an expected call of ImportClosures.
+func (mr *MockCalendarRepositoryMockRecorder) ImportClosures(closures any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportClosures", reflect.TypeOf((*MockCalendarRepository)(nil).ImportClosures), closures)
+}
+
+// ListClosures mocks base method.
+func (m *MockCalendarRepository) ListClosures(from, to time.Time) ([]*domain.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClosures", from, to)
+	ret0, _ := ret[0].([]*domain.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClosures indicates an expected call of ListClosures.
+func (mr *MockCalendarRepositoryMockRecorder) ListClosures(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClosures", reflect.TypeOf((*MockCalendarRepository)(nil).ListClosures), from, to)
+}
+
+// ListOpeningHours mocks base method.
+func (m *MockCalendarRepository) ListOpeningHours() ([]*domain.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpeningHours")
+	ret0, _ := ret[0].([]*domain.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpeningHours indicates an expected call of ListOpeningHours.
+func (mr *MockCalendarRepositoryMockRecorder) ListOpeningHours() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpeningHours", reflect.TypeOf((*MockCalendarRepository)(nil).ListOpeningHours))
+}
+
+// UpdateOpeningHours mocks base method.
+func (m *MockCalendarRepository) UpdateOpeningHours(hours []*domain.OpeningHours) ([]*domain.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOpeningHours", hours)
+	ret0, _ := ret[0].([]*domain.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOpeningHours indicates an expected call of UpdateOpeningHours.
+func (mr *MockCalendarRepositoryMockRecorder) UpdateOpeningHours(hours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOpeningHours", reflect.TypeOf((*MockCalendarRepository)(nil).UpdateOpeningHours), hours)
+}
+
+// MockCalendarService is a mock of CalendarService interface.
+type MockCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarServiceMockRecorder is the mock recorder for MockCalendarService.
+type MockCalendarServiceMockRecorder struct {
+	mock *MockCalendarService
+}
+
+// NewMockCalendarService creates a new mock instance.
+func NewMockCalendarService(ctrl *gomock.Controller) *MockCalendarService {
+	mock := &MockCalendarService{ctrl: ctrl}
+	mock.recorder = &MockCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarService) EXPECT() *MockCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateClosure mocks base method.
+func (m *MockCalendarService) CreateClosure(closure *domain.Closure) (*domain.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateClosure", closure)
+	ret0, _ := ret[0].(*domain.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateClosure indicates an expected call of CreateClosure.
+func (mr *MockCalendarServiceMockRecorder) CreateClosure(closure any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateClosure", reflect.TypeOf((*MockCalendarService)(nil).CreateClosure), closure)
+}
+
+// DeleteClosure mocks base method.
+func (m *MockCalendarService) DeleteClosure(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteClosure", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteClosure indicates an expected call of DeleteClosure.
+func (mr *MockCalendarServiceMockRecorder) DeleteClosure(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteClosure", reflect.TypeOf((*MockCalendarService)(nil).DeleteClosure), id)
+}
+
+// GetOpeningHours mocks base method.
+func (m *MockCalendarService) GetOpeningHours() ([]*domain.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpeningHours")
+	ret0, _ := ret[0].([]*domain.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningHours indicates an expected call of GetOpeningHours.
+func (mr *MockCalendarServiceMockRecorder) GetOpeningHours() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningHours", reflect.TypeOf((*MockCalendarService)(nil).GetOpeningHours))
+}
+
+// ImportICal mocks base method.
+func (m *MockCalendarService) ImportICal(file io.Reader, importedBy int64) (*domain.ClosureImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportICal", file, importedBy)
+	ret0, _ := ret[0].(*domain.ClosureImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportICal indicates an expected call of ImportICal.
+func (mr *MockCalendarServiceMockRecorder) ImportICal(file, importedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportICal", reflect.TypeOf((*MockCalendarService)(nil).ImportICal), file, importedBy)
+}
+
+// ListClosures mocks base method.
+func (m *MockCalendarService) ListClosures(from, to time.Time) ([]*domain.Closure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClosures", from, to)
+	ret0, _ := ret[0].([]*domain.Closure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClosures indicates an expected call of ListClosures.
+func (mr *MockCalendarServiceMockRecorder) ListClosures(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClosures", reflect.TypeOf((*MockCalendarService)(nil).ListClosures), from, to)
+}
+
+// UpdateOpeningHours mocks base method.
+func (m *MockCalendarService) UpdateOpeningHours(hours []*domain.OpeningHours) ([]*domain.OpeningHours, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOpeningHours", hours)
+	ret0, _ := ret[0].([]*domain.OpeningHours)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOpeningHours indicates an expected call of UpdateOpeningHours.
+func (mr *MockCalendarServiceMockRecorder) UpdateOpeningHours(hours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOpeningHours", reflect.TypeOf((*MockCalendarService)(nil).UpdateOpeningHours), hours)
+}
@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/fine.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/fine.go -destination=internal/mocks/fine_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFineRepository is a mock of FineRepository interface.
+type MockFineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFineRepositoryMockRecorder is the mock recorder for MockFineRepository.
+type MockFineRepositoryMockRecorder struct {
+	mock *MockFineRepository
+}
+
+// NewMockFineRepository creates a new mock instance.
+func NewMockFineRepository(ctrl *gomock.Controller) *MockFineRepository {
+	mock := &MockFineRepository{ctrl: ctrl}
+	mock.recorder = &MockFineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineRepository) EXPECT() *MockFineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockFineRepository) GetByID(id int64) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockFineRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockFineRepository)(nil).GetByID), id)
+}
+
+// GetByRental mocks base method.
+func (m *MockFineRepository) GetByRental(rentalID int64) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRental", rentalID)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRental indicates an expected call of GetByRental.
+func (mr *MockFineRepositoryMockRecorder) GetByRental(rentalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRental", reflect.TypeOf((*MockFineRepository)(nil).GetByRental), rentalID)
+}
+
+// List mocks base method.
+func (m *MockFineRepository) List(status domain.FineStatus, limit, offset int32) ([]*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", status, limit, offset)
+	ret0, _ := ret[0].([]*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFineRepositoryMockRecorder) List(status, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFineRepository)(nil).List), status, limit, offset)
+}
+
+// ListByUser mocks base method.
+func (m *MockFineRepository) ListByUser(userID int64) ([]*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockFineRepositoryMockRecorder) ListByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockFineRepository)(nil).ListByUser), userID)
+}
+
+// SumOutstandingByUser mocks base method.
+func (m *MockFineRepository) SumOutstandingByUser(userID int64) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumOutstandingByUser", userID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumOutstandingByUser indicates an expected call of SumOutstandingByUser.
+func (mr *MockFineRepositoryMockRecorder) SumOutstandingByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumOutstandingByUser", reflect.TypeOf((*MockFineRepository)(nil).SumOutstandingByUser), userID)
+}
+
+// Waive mocks base method.
+func (m *MockFineRepository) Waive(id, waivedBy int64, reason string) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Waive", id, waivedBy, reason)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Waive indicates an expected call of Waive.
+func (mr *MockFineRepositoryMockRecorder) Waive(id, waivedBy, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waive", reflect.TypeOf((*MockFineRepository)(nil).Waive), id, waivedBy, reason)
+}
+
+// MockFineService is a mock of FineService interface.
+type MockFineService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFineServiceMockRecorder is the mock recorder for MockFineService.
+type MockFineServiceMockRecorder struct {
+	mock *MockFineService
+}
+
+// NewMockFineService creates a new mock instance.
+func NewMockFineService(ctrl *gomock.Controller) *MockFineService {
+	mock := &MockFineService{ctrl: ctrl}
+	mock.recorder = &MockFineServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineService) EXPECT() *MockFineServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockFineService) GetByID(id int64) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockFineServiceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockFineService)(nil).GetByID), id)
+}
+
+// GetByRental mocks base method.
+func (m *MockFineService) GetByRental(rentalID int64) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByRental", rentalID)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByRental indicates an expected call of GetByRental.
+func (mr *MockFineServiceMockRecorder) GetByRental(rentalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByRental", reflect.TypeOf((*MockFineService)(nil).GetByRental), rentalID)
+}
+
+// List mocks base method.
+func (m *MockFineService) List(status domain.FineStatus, limit, offset int32) ([]*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", status, limit, offset)
+	ret0, _ := ret[0].([]*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockFineServiceMockRecorder) List(status, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFineService)(nil).List), status, limit, offset)
+}
+
+// ListByUser mocks base method.
+func (m *MockFineService) ListByUser(userID int64) ([]*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", userID)
+	ret0, _ := ret[0].([]*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockFineServiceMockRecorder) ListByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockFineService)(nil).ListByUser), userID)
+}
+
+// Waive mocks base method.
+func (m *MockFineService) Waive(id, waivedBy int64, reason string) (*domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Waive", id, waivedBy, reason)
+	ret0, _ := ret[0].(*domain.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Waive indicates an expected call of Waive.
+func (mr *MockFineServiceMockRecorder) Waive(id, waivedBy, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Waive", reflect.TypeOf((*MockFineService)(nil).Waive), id, waivedBy, reason)
+}
@@ -0,0 +1,316 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/household.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/household.go -destination=internal/mocks/household_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHouseholdRepository is a mock of HouseholdRepository interface.
+type MockHouseholdRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHouseholdRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockHouseholdRepositoryMockRecorder is the mock recorder for MockHouseholdRepository.
+type MockHouseholdRepositoryMockRecorder struct {
+	mock *MockHouseholdRepository
+}
+
+// NewMockHouseholdRepository creates a new mock instance.
+func NewMockHouseholdRepository(ctrl *gomock.Controller) *MockHouseholdRepository {
+	mock := &MockHouseholdRepository{ctrl: ctrl}
+	mock.recorder = &MockHouseholdRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHouseholdRepository) EXPECT() *MockHouseholdRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDependent mocks base method.
+func (m *MockHouseholdRepository) AddDependent(householdID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependent", householdID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDependent indicates an expected call of AddDependent.
+func (mr *MockHouseholdRepositoryMockRecorder) AddDependent(householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependent", reflect.TypeOf((*MockHouseholdRepository)(nil).AddDependent), householdID, userID)
+}
+
+// Create mocks base method.
+func (m *MockHouseholdRepository) Create(household *domain.Household) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", household)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHouseholdRepositoryMockRecorder) Create(household any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHouseholdRepository)(nil).Create), household)
+}
+
+// Delete mocks base method.
+func (m *MockHouseholdRepository) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHouseholdRepositoryMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHouseholdRepository)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockHouseholdRepository) GetByID(id int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockHouseholdRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockHouseholdRepository)(nil).GetByID), id)
+}
+
+// GetByMember mocks base method.
+func (m *MockHouseholdRepository) GetByMember(userID int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMember", userID)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMember indicates an expected call of GetByMember.
+func (mr *MockHouseholdRepositoryMockRecorder) GetByMember(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMember", reflect.TypeOf((*MockHouseholdRepository)(nil).GetByMember), userID)
+}
+
+// IsGuardianOf mocks base method.
+func (m *MockHouseholdRepository) IsGuardianOf(guardianID, userID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsGuardianOf", guardianID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsGuardianOf indicates an expected call of IsGuardianOf.
+func (mr *MockHouseholdRepositoryMockRecorder) IsGuardianOf(guardianID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsGuardianOf", reflect.TypeOf((*MockHouseholdRepository)(nil).IsGuardianOf), guardianID, userID)
+}
+
+// List mocks base method.
+func (m *MockHouseholdRepository) List() ([]*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHouseholdRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHouseholdRepository)(nil).List))
+}
+
+// RemoveDependent mocks base method.
+func (m *MockHouseholdRepository) RemoveDependent(householdID, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependent", householdID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDependent indicates an expected call of RemoveDependent.
+func (mr *MockHouseholdRepositoryMockRecorder) RemoveDependent(householdID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependent", reflect.TypeOf((*MockHouseholdRepository)(nil).RemoveDependent), householdID, userID)
+}
+
+// Update mocks base method.
+func (m *MockHouseholdRepository) Update(household *domain.Household) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", household)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHouseholdRepositoryMockRecorder) Update(household any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHouseholdRepository)(nil).Update), household)
+}
+
+// MockHouseholdService is a mock of HouseholdService interface.
+type MockHouseholdService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHouseholdServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockHouseholdServiceMockRecorder is the mock recorder for MockHouseholdService.
+type MockHouseholdServiceMockRecorder struct {
+	mock *MockHouseholdService
+}
+
+// NewMockHouseholdService creates a new mock instance.
+func NewMockHouseholdService(ctrl *gomock.Controller) *MockHouseholdService {
+	mock := &MockHouseholdService{ctrl: ctrl}
+	mock.recorder = &MockHouseholdServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHouseholdService) EXPECT() *MockHouseholdServiceMockRecorder {
+	return m.recorder
+}
+
+// AddDependent mocks base method.
+func (m *MockHouseholdService) AddDependent(householdID, userID, addedBy int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependent", householdID, userID, addedBy)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependent indicates an expected call of AddDependent.
+func (mr *MockHouseholdServiceMockRecorder) AddDependent(householdID, userID, addedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependent", reflect.TypeOf((*MockHouseholdService)(nil).AddDependent), householdID, userID, addedBy)
+}
+
+// Create mocks base method.
+func (m *MockHouseholdService) Create(household *domain.Household, createdBy int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", household, createdBy)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHouseholdServiceMockRecorder) Create(household, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHouseholdService)(nil).Create), household, createdBy)
+}
+
+// Delete mocks base method.
+func (m *MockHouseholdService) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHouseholdServiceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHouseholdService)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockHouseholdService) GetByID(id int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockHouseholdServiceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockHouseholdService)(nil).GetByID), id)
+}
+
+// GetForUser mocks base method.
+func (m *MockHouseholdService) GetForUser(userID int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", userID)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockHouseholdServiceMockRecorder) GetForUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockHouseholdService)(nil).GetForUser), userID)
+}
+
+// List mocks base method.
+func (m *MockHouseholdService) List() ([]*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHouseholdServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHouseholdService)(nil).List))
+}
+
+// RemoveDependent mocks base method.
+func (m *MockHouseholdService) RemoveDependent(householdID, userID, removedBy int64) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependent", householdID, userID, removedBy)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDependent indicates an expected call of RemoveDependent.
+func (mr *MockHouseholdServiceMockRecorder) RemoveDependent(householdID, userID, removedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependent", reflect.TypeOf((*MockHouseholdService)(nil).RemoveDependent), householdID, userID, removedBy)
+}
+
+// Update mocks base method.
+func (m *MockHouseholdService) Update(household *domain.Household) (*domain.Household, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", household)
+	ret0, _ := ret[0].(*domain.Household)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHouseholdServiceMockRecorder) Update(household any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHouseholdService)(nil).Update), household)
+}
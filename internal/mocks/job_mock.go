@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/job.go -destination=internal/mocks/job_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobLockRepository is a mock of JobLockRepository interface.
+type MockJobLockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobLockRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockJobLockRepositoryMockRecorder is the mock recorder for MockJobLockRepository.
+type MockJobLockRepositoryMockRecorder struct {
+	mock *MockJobLockRepository
+}
+
+// NewMockJobLockRepository creates a new mock instance.
+func NewMockJobLockRepository(ctrl *gomock.Controller) *MockJobLockRepository {
+	mock := &MockJobLockRepository{ctrl: ctrl}
+	mock.recorder = &MockJobLockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobLockRepository) EXPECT() *MockJobLockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockJobLockRepository) TryLock(ctx context.Context, job string) (func(), bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, job)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockJobLockRepositoryMockRecorder) TryLock(ctx, job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockJobLockRepository)(nil).TryLock), ctx, job)
+}
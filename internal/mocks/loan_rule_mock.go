@@ -0,0 +1,243 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/loan_rule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/loan_rule.go -destination=internal/mocks/loan_rule_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoanRuleRepository is a mock of LoanRuleRepository interface.
+type MockLoanRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRuleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLoanRuleRepositoryMockRecorder is the mock recorder for MockLoanRuleRepository.
+type MockLoanRuleRepositoryMockRecorder struct {
+	mock *MockLoanRuleRepository
+}
+
+// NewMockLoanRuleRepository creates a new mock instance.
+func NewMockLoanRuleRepository(ctrl *gomock.Controller) *MockLoanRuleRepository {
+	mock := &MockLoanRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRuleRepository) EXPECT() *MockLoanRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoanRuleRepository) Create(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", rule)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanRuleRepositoryMockRecorder) Create(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanRuleRepository)(nil).Create), rule)
+}
+
+// Delete mocks base method.
+func (m *MockLoanRuleRepository) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLoanRuleRepositoryMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoanRuleRepository)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockLoanRuleRepository) GetByID(id int64) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLoanRuleRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLoanRuleRepository)(nil).GetByID), id)
+}
+
+// List mocks base method.
+func (m *MockLoanRuleRepository) List() ([]*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockLoanRuleRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLoanRuleRepository)(nil).List))
+}
+
+// Match mocks base method.
+func (m *MockLoanRuleRepository) Match(bookID, categoryID, planID int64) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Match", bookID, categoryID, planID)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockLoanRuleRepositoryMockRecorder) Match(bookID, categoryID, planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockLoanRuleRepository)(nil).Match), bookID, categoryID, planID)
+}
+
+// Update mocks base method.
+func (m *MockLoanRuleRepository) Update(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", rule)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLoanRuleRepositoryMockRecorder) Update(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoanRuleRepository)(nil).Update), rule)
+}
+
+// MockLoanRuleService is a mock of LoanRuleService interface.
+type MockLoanRuleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRuleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoanRuleServiceMockRecorder is the mock recorder for MockLoanRuleService.
+type MockLoanRuleServiceMockRecorder struct {
+	mock *MockLoanRuleService
+}
+
+// NewMockLoanRuleService creates a new mock instance.
+func NewMockLoanRuleService(ctrl *gomock.Controller) *MockLoanRuleService {
+	mock := &MockLoanRuleService{ctrl: ctrl}
+	mock.recorder = &MockLoanRuleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRuleService) EXPECT() *MockLoanRuleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLoanRuleService) Create(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", rule)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanRuleServiceMockRecorder) Create(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanRuleService)(nil).Create), rule)
+}
+
+// Delete mocks base method.
+func (m *MockLoanRuleService) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLoanRuleServiceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLoanRuleService)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockLoanRuleService) GetByID(id int64) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockLoanRuleServiceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockLoanRuleService)(nil).GetByID), id)
+}
+
+// List mocks base method.
+func (m *MockLoanRuleService) List() ([]*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockLoanRuleServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLoanRuleService)(nil).List))
+}
+
+// Preview mocks base method.
+func (m *MockLoanRuleService) Preview(userID, bookID int64) (*domain.LoanRulePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", userID, bookID)
+	ret0, _ := ret[0].(*domain.LoanRulePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockLoanRuleServiceMockRecorder) Preview(userID, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockLoanRuleService)(nil).Preview), userID, bookID)
+}
+
+// Update mocks base method.
+func (m *MockLoanRuleService) Update(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", rule)
+	ret0, _ := ret[0].(*domain.LoanRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLoanRuleServiceMockRecorder) Update(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLoanRuleService)(nil).Update), rule)
+}
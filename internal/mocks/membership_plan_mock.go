@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/membership_plan.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/membership_plan.go -destination=internal/mocks/membership_plan_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMembershipPlanRepository is a mock of MembershipPlanRepository interface.
+type MockMembershipPlanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMembershipPlanRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMembershipPlanRepositoryMockRecorder is the mock recorder for MockMembershipPlanRepository.
+type MockMembershipPlanRepositoryMockRecorder struct {
+	mock *MockMembershipPlanRepository
+}
+
+// NewMockMembershipPlanRepository creates a new mock instance.
+func NewMockMembershipPlanRepository(ctrl *gomock.Controller) *MockMembershipPlanRepository {
+	mock := &MockMembershipPlanRepository{ctrl: ctrl}
+	mock.recorder = &MockMembershipPlanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMembershipPlanRepository) EXPECT() *MockMembershipPlanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMembershipPlanRepository) Create(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", plan)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMembershipPlanRepositoryMockRecorder) Create(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMembershipPlanRepository)(nil).Create), plan)
+}
+
+// Delete mocks base method.
+func (m *MockMembershipPlanRepository) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMembershipPlanRepositoryMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMembershipPlanRepository)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockMembershipPlanRepository) GetByID(id int64) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMembershipPlanRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMembershipPlanRepository)(nil).GetByID), id)
+}
+
+// GetByName mocks base method.
+func (m *MockMembershipPlanRepository) GetByName(name string) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", name)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockMembershipPlanRepositoryMockRecorder) GetByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockMembershipPlanRepository)(nil).GetByName), name)
+}
+
+// GetDefault mocks base method.
+func (m *MockMembershipPlanRepository) GetDefault() (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefault")
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefault indicates an expected call of GetDefault.
+func (mr *MockMembershipPlanRepositoryMockRecorder) GetDefault() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefault", reflect.TypeOf((*MockMembershipPlanRepository)(nil).GetDefault))
+}
+
+// List mocks base method.
+func (m *MockMembershipPlanRepository) List() ([]*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMembershipPlanRepositoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMembershipPlanRepository)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockMembershipPlanRepository) Update(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", plan)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMembershipPlanRepositoryMockRecorder) Update(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMembershipPlanRepository)(nil).Update), plan)
+}
+
+// MockMembershipPlanService is a mock of MembershipPlanService interface.
+type MockMembershipPlanService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMembershipPlanServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMembershipPlanServiceMockRecorder is the mock recorder for MockMembershipPlanService.
+type MockMembershipPlanServiceMockRecorder struct {
+	mock *MockMembershipPlanService
+}
+
+// NewMockMembershipPlanService creates a new mock instance.
+func NewMockMembershipPlanService(ctrl *gomock.Controller) *MockMembershipPlanService {
+	mock := &MockMembershipPlanService{ctrl: ctrl}
+	mock.recorder = &MockMembershipPlanServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMembershipPlanService) EXPECT() *MockMembershipPlanServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignToUser mocks base method.
+func (m *MockMembershipPlanService) AssignToUser(userID int64, planID *int64, assignedBy int64) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignToUser", userID, planID, assignedBy)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignToUser indicates an expected call of AssignToUser.
+func (mr *MockMembershipPlanServiceMockRecorder) AssignToUser(userID, planID, assignedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignToUser", reflect.TypeOf((*MockMembershipPlanService)(nil).AssignToUser), userID, planID, assignedBy)
+}
+
+// Create mocks base method.
+func (m *MockMembershipPlanService) Create(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", plan)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMembershipPlanServiceMockRecorder) Create(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMembershipPlanService)(nil).Create), plan)
+}
+
+// Delete mocks base method.
+func (m *MockMembershipPlanService) Delete(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMembershipPlanServiceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMembershipPlanService)(nil).Delete), id)
+}
+
+// GetByID mocks base method.
+func (m *MockMembershipPlanService) GetByID(id int64) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMembershipPlanServiceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMembershipPlanService)(nil).GetByID), id)
+}
+
+// GetForUser mocks base method.
+func (m *MockMembershipPlanService) GetForUser(userID int64) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUser", userID)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUser indicates an expected call of GetForUser.
+func (mr *MockMembershipPlanServiceMockRecorder) GetForUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUser", reflect.TypeOf((*MockMembershipPlanService)(nil).GetForUser), userID)
+}
+
+// List mocks base method.
+func (m *MockMembershipPlanService) List() ([]*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMembershipPlanServiceMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMembershipPlanService)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockMembershipPlanService) Update(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", plan)
+	ret0, _ := ret[0].(*domain.MembershipPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMembershipPlanServiceMockRecorder) Update(plan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMembershipPlanService)(nil).Update), plan)
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/oidc.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/oidc.go -destination=internal/mocks/oidc_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserIdentityRepository is a mock of UserIdentityRepository interface.
+type MockUserIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserIdentityRepositoryMockRecorder is the mock recorder for MockUserIdentityRepository.
+type MockUserIdentityRepositoryMockRecorder struct {
+	mock *MockUserIdentityRepository
+}
+
+// NewMockUserIdentityRepository creates a new mock instance.
+func NewMockUserIdentityRepository(ctrl *gomock.Controller) *MockUserIdentityRepository {
+	mock := &MockUserIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityRepository) EXPECT() *MockUserIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserIdentityRepository) Create(identity *domain.UserIdentity) (*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", identity)
+	ret0, _ := ret[0].(*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserIdentityRepositoryMockRecorder) Create(identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserIdentityRepository)(nil).Create), identity)
+}
+
+// GetByProviderSubject mocks base method.
+func (m *MockUserIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderSubject", provider, subject)
+	ret0, _ := ret[0].(*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderSubject indicates an expected call of GetByProviderSubject.
+func (mr *MockUserIdentityRepositoryMockRecorder) GetByProviderSubject(provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderSubject", reflect.TypeOf((*MockUserIdentityRepository)(nil).GetByProviderSubject), provider, subject)
+}
+
+// TouchLastLogin mocks base method.
+func (m *MockUserIdentityRepository) TouchLastLogin(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastLogin", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastLogin indicates an expected call of TouchLastLogin.
+func (mr *MockUserIdentityRepositoryMockRecorder) TouchLastLogin(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastLogin", reflect.TypeOf((*MockUserIdentityRepository)(nil).TouchLastLogin), id)
+}
+
+// MockOIDCAuthRequestRepository is a mock of OIDCAuthRequestRepository interface.
+type MockOIDCAuthRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCAuthRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCAuthRequestRepositoryMockRecorder is the mock recorder for MockOIDCAuthRequestRepository.
+type MockOIDCAuthRequestRepositoryMockRecorder struct {
+	mock *MockOIDCAuthRequestRepository
+}
+
+// NewMockOIDCAuthRequestRepository creates a new mock instance.
+func NewMockOIDCAuthRequestRepository(ctrl *gomock.Controller) *MockOIDCAuthRequestRepository {
+	mock := &MockOIDCAuthRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockOIDCAuthRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCAuthRequestRepository) EXPECT() *MockOIDCAuthRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOIDCAuthRequestRepository) Consume(stateHash string) (*domain.OIDCAuthRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", stateHash)
+	ret0, _ := ret[0].(*domain.OIDCAuthRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) Consume(stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).Consume), stateHash)
+}
+
+// Create mocks base method.
+func (m *MockOIDCAuthRequestRepository) Create(request *domain.OIDCAuthRequest) (*domain.OIDCAuthRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", request)
+	ret0, _ := ret[0].(*domain.OIDCAuthRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) Create(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).Create), request)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockOIDCAuthRequestRepository) DeleteExpired() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockOIDCAuthRequestRepositoryMockRecorder) DeleteExpired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockOIDCAuthRequestRepository)(nil).DeleteExpired))
+}
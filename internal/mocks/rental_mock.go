@@ -41,19 +41,49 @@ func (m *MockRentalRepository) EXPECT() *MockRentalRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountActiveByUserAndBook mocks base method.
+func (m *MockRentalRepository) CountActiveByUserAndBook(userID, bookID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveByUserAndBook", userID, bookID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveByUserAndBook indicates an expected call of CountActiveByUserAndBook.
+func (mr *MockRentalRepositoryMockRecorder) CountActiveByUserAndBook(userID, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveByUserAndBook", reflect.TypeOf((*MockRentalRepository)(nil).CountActiveByUserAndBook), userID, bookID)
+}
+
+// CountOverdueByUser mocks base method.
+func (m *MockRentalRepository) CountOverdueByUser(userID int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOverdueByUser", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOverdueByUser indicates an expected call of CountOverdueByUser.
+func (mr *MockRentalRepositoryMockRecorder) CountOverdueByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOverdueByUser", reflect.TypeOf((*MockRentalRepository)(nil).CountOverdueByUser), userID)
+}
+
 // Create mocks base method.
-func (m *MockRentalRepository) Create(rental *domain.Rental) (*domain.Rental, error) {
+func (m *MockRentalRepository) Create(rental *domain.Rental, limits domain.RentalLimits) (*domain.Rental, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", rental)
+	ret := m.ctrl.Call(m, "Create", rental, limits)
 	ret0, _ := ret[0].(*domain.Rental)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockRentalRepositoryMockRecorder) Create(rental any) *gomock.Call {
+func (mr *MockRentalRepositoryMockRecorder) Create(rental, limits any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRentalRepository)(nil).Create), rental)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRentalRepository)(nil).Create), rental, limits)
 }
 
 // Delete mocks base method.
@@ -71,18 +101,18 @@ func (mr *MockRentalRepositoryMockRecorder) Delete(id any) *gomock.Call {
 }
 
 // Extend mocks base method.
-func (m *MockRentalRepository) Extend(id int64, newDueDate time.Time) (*domain.Rental, error) {
+func (m *MockRentalRepository) Extend(id int64, newDueDate time.Time, maxRenewals int) (*domain.Rental, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Extend", id, newDueDate)
+	ret := m.ctrl.Call(m, "Extend", id, newDueDate, maxRenewals)
 	ret0, _ := ret[0].(*domain.Rental)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Extend indicates an expected call of Extend.
-func (mr *MockRentalRepositoryMockRecorder) Extend(id, newDueDate any) *gomock.Call {
+func (mr *MockRentalRepositoryMockRecorder) Extend(id, newDueDate, maxRenewals any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockRentalRepository)(nil).Extend), id, newDueDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockRentalRepository)(nil).Extend), id, newDueDate, maxRenewals)
 }
 
 // GetByID mocks base method.
@@ -100,6 +130,21 @@ func (mr *MockRentalRepositoryMockRecorder) GetByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRentalRepository)(nil).GetByID), id)
 }
 
+// GetOpenByCopy mocks base method.
+func (m *MockRentalRepository) GetOpenByCopy(copyID int64) (*domain.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenByCopy", copyID)
+	ret0, _ := ret[0].(*domain.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenByCopy indicates an expected call of GetOpenByCopy.
+func (mr *MockRentalRepositoryMockRecorder) GetOpenByCopy(copyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenByCopy", reflect.TypeOf((*MockRentalRepository)(nil).GetOpenByCopy), copyID)
+}
+
 // List mocks base method.
 func (m *MockRentalRepository) List(limit, offset int32) ([]*domain.Rental, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +205,21 @@ func (mr *MockRentalRepositoryMockRecorder) ListByUser(userID, limit, offset any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockRentalRepository)(nil).ListByUser), userID, limit, offset)
 }
 
+// ListLateByUser mocks base method.
+func (m *MockRentalRepository) ListLateByUser(userID int64) ([]*domain.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLateByUser", userID)
+	ret0, _ := ret[0].([]*domain.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLateByUser indicates an expected call of ListLateByUser.
+func (mr *MockRentalRepositoryMockRecorder) ListLateByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLateByUser", reflect.TypeOf((*MockRentalRepository)(nil).ListLateByUser), userID)
+}
+
 // ListOverdue mocks base method.
 func (m *MockRentalRepository) ListOverdue(limit, offset int32) ([]*domain.Rental, error) {
 	m.ctrl.T.Helper()
@@ -175,34 +235,34 @@ func (mr *MockRentalRepositoryMockRecorder) ListOverdue(limit, offset any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdue", reflect.TypeOf((*MockRentalRepository)(nil).ListOverdue), limit, offset)
 }
 
-// Return mocks base method.
-func (m *MockRentalRepository) Return(id int64) (*domain.Rental, error) {
+// MarkOverdue mocks base method.
+func (m *MockRentalRepository) MarkOverdue() (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Return", id)
-	ret0, _ := ret[0].(*domain.Rental)
+	ret := m.ctrl.Call(m, "MarkOverdue")
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Return indicates an expected call of Return.
-func (mr *MockRentalRepositoryMockRecorder) Return(id any) *gomock.Call {
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockRentalRepositoryMockRecorder) MarkOverdue() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockRentalRepository)(nil).Return), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockRentalRepository)(nil).MarkOverdue))
 }
 
-// UpdateStatus mocks base method.
-func (m *MockRentalRepository) UpdateStatus(id int64, status domain.RentalStatus) (*domain.Rental, error) {
+// Return mocks base method.
+func (m *MockRentalRepository) Return(id int64, lateFee float64, holdUntil time.Time) (*domain.Rental, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatus", id, status)
+	ret := m.ctrl.Call(m, "Return", id, lateFee, holdUntil)
 	ret0, _ := ret[0].(*domain.Rental)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateStatus indicates an expected call of UpdateStatus.
-func (mr *MockRentalRepositoryMockRecorder) UpdateStatus(id, status any) *gomock.Call {
+// Return indicates an expected call of Return.
+func (mr *MockRentalRepositoryMockRecorder) Return(id, lateFee, holdUntil any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRentalRepository)(nil).UpdateStatus), id, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockRentalRepository)(nil).Return), id, lateFee, holdUntil)
 }
 
 // MockRentalService is a mock of RentalService interface.
@@ -244,6 +304,36 @@ func (mr *MockRentalServiceMockRecorder) CalculateLateFee(rental any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateLateFee", reflect.TypeOf((*MockRentalService)(nil).CalculateLateFee), rental)
 }
 
+// CheckEligibility mocks base method.
+func (m *MockRentalService) CheckEligibility(userID int64) (*domain.Eligibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEligibility", userID)
+	ret0, _ := ret[0].(*domain.Eligibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckEligibility indicates an expected call of CheckEligibility.
+func (mr *MockRentalServiceMockRecorder) CheckEligibility(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEligibility", reflect.TypeOf((*MockRentalService)(nil).CheckEligibility), userID)
+}
+
+// Checkout mocks base method.
+func (m *MockRentalService) Checkout(barcode string, userID int64, dueDate time.Time) (*domain.Rental, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", barcode, userID, dueDate)
+	ret0, _ := ret[0].(*domain.Rental)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockRentalServiceMockRecorder) Checkout(barcode, userID, dueDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockRentalService)(nil).Checkout), barcode, userID, dueDate)
+}
+
 // Create mocks base method.
 func (m *MockRentalService) Create(rental *domain.Rental) (*domain.Rental, error) {
 	m.ctrl.T.Helper()
@@ -378,13 +468,29 @@ func (mr *MockRentalServiceMockRecorder) ListOverdue(limit, offset any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdue", reflect.TypeOf((*MockRentalService)(nil).ListOverdue), limit, offset)
 }
 
+// MarkOverdue mocks base method.
+func (m *MockRentalService) MarkOverdue() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockRentalServiceMockRecorder) MarkOverdue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockRentalService)(nil).MarkOverdue))
+}
+
 // Return mocks base method.
-func (m *MockRentalService) Return(id int64) (*domain.Rental, error) {
+func (m *MockRentalService) Return(id int64) (*domain.Rental, *domain.Fine, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Return", id)
 	ret0, _ := ret[0].(*domain.Rental)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*domain.Fine)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Return indicates an expected call of Return.
@@ -392,3 +498,19 @@ func (mr *MockRentalServiceMockRecorder) Return(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockRentalService)(nil).Return), id)
 }
+
+// ReturnByBarcode mocks base method.
+func (m *MockRentalService) ReturnByBarcode(barcode string) (*domain.Rental, *domain.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnByBarcode", barcode)
+	ret0, _ := ret[0].(*domain.Rental)
+	ret1, _ := ret[1].(*domain.Fine)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReturnByBarcode indicates an expected call of ReturnByBarcode.
+func (mr *MockRentalServiceMockRecorder) ReturnByBarcode(barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnByBarcode", reflect.TypeOf((*MockRentalService)(nil).ReturnByBarcode), barcode)
+}
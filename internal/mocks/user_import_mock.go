@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/user_import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/user_import.go -destination=internal/mocks/user_import_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/SimpleBookRental/backend/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserImportRepository is a mock of UserImportRepository interface.
+type MockUserImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserImportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUserImportRepositoryMockRecorder is the mock recorder for MockUserImportRepository.
+type MockUserImportRepositoryMockRecorder struct {
+	mock *MockUserImportRepository
+}
+
+// NewMockUserImportRepository creates a new mock instance.
+func NewMockUserImportRepository(ctrl *gomock.Controller) *MockUserImportRepository {
+	mock := &MockUserImportRepository{ctrl: ctrl}
+	mock.recorder = &MockUserImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserImportRepository) EXPECT() *MockUserImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockUserImportRepository) Complete(id int64, createdCount, invitesSent int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", id, createdCount, invitesSent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockUserImportRepositoryMockRecorder) Complete(id, createdCount, invitesSent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockUserImportRepository)(nil).Complete), id, createdCount, invitesSent)
+}
+
+// Create mocks base method.
+func (m *MockUserImportRepository) Create(job *domain.UserImportJob) (*domain.UserImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(*domain.UserImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserImportRepositoryMockRecorder) Create(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserImportRepository)(nil).Create), job)
+}
+
+// Fail mocks base method.
+func (m *MockUserImportRepository) Fail(id int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockUserImportRepositoryMockRecorder) Fail(id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockUserImportRepository)(nil).Fail), id, reason)
+}
+
+// FailPendingBefore mocks base method.
+func (m *MockUserImportRepository) FailPendingBefore(before time.Time, reason string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPendingBefore", before, reason)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailPendingBefore indicates an expected call of FailPendingBefore.
+func (mr *MockUserImportRepositoryMockRecorder) FailPendingBefore(before, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPendingBefore", reflect.TypeOf((*MockUserImportRepository)(nil).FailPendingBefore), before, reason)
+}
+
+// GetByID mocks base method.
+func (m *MockUserImportRepository) GetByID(id int64) (*domain.UserImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.UserImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserImportRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserImportRepository)(nil).GetByID), id)
+}
+
+// MockUserImportService is a mock of UserImportService interface.
+type MockUserImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserImportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserImportServiceMockRecorder is the mock recorder for MockUserImportService.
+type MockUserImportServiceMockRecorder struct {
+	mock *MockUserImportService
+}
+
+// NewMockUserImportService creates a new mock instance.
+func NewMockUserImportService(ctrl *gomock.Controller) *MockUserImportService {
+	mock := &MockUserImportService{ctrl: ctrl}
+	mock.recorder = &MockUserImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserImportService) EXPECT() *MockUserImportServiceMockRecorder {
+	return m.recorder
+}
+
+// FailStale mocks base method.
+func (m *MockUserImportService) FailStale() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailStale")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailStale indicates an expected call of FailStale.
+func (mr *MockUserImportServiceMockRecorder) FailStale() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailStale", reflect.TypeOf((*MockUserImportService)(nil).FailStale))
+}
+
+// GetByID mocks base method.
+func (m *MockUserImportService) GetByID(id int64) (*domain.UserImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*domain.UserImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserImportServiceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserImportService)(nil).GetByID), id)
+}
+
+// Import mocks base method.
+func (m *MockUserImportService) Import(file io.Reader, sendInvites bool, importedBy int64) (*domain.UserImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", file, sendInvites, importedBy)
+	ret0, _ := ret[0].(*domain.UserImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserImportServiceMockRecorder) Import(file, sendInvites, importedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserImportService)(nil).Import), file, sendInvites, importedBy)
+}
+
+// Validate mocks base method.
+func (m *MockUserImportService) Validate(file io.Reader) (*domain.UserImportValidation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", file)
+	ret0, _ := ret[0].(*domain.UserImportValidation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockUserImportServiceMockRecorder) Validate(file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockUserImportService)(nil).Validate), file)
+}
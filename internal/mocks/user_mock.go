@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/SimpleBookRental/backend/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserRepository) Anonymize(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserRepositoryMockRecorder) Anonymize(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserRepository)(nil).Anonymize), id)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(user *domain.User) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +70,36 @@ func (mr *MockUserRepositoryMockRecorder) Create(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), user)
 }
 
+// CreateBatch mocks base method.
+func (m *MockUserRepository) CreateBatch(users []*domain.User) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", users)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockUserRepositoryMockRecorder) CreateBatch(users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockUserRepository)(nil).CreateBatch), users)
+}
+
+// CreateWithIdentity mocks base method.
+func (m *MockUserRepository) CreateWithIdentity(user *domain.User, identity *domain.UserIdentity) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithIdentity", user, identity)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithIdentity indicates an expected call of CreateWithIdentity.
+func (mr *MockUserRepositoryMockRecorder) CreateWithIdentity(user, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithIdentity", reflect.TypeOf((*MockUserRepository)(nil).CreateWithIdentity), user, identity)
+}
+
 // Delete mocks base method.
 func (m *MockUserRepository) Delete(id int64) error {
 	m.ctrl.T.Helper()
@@ -114,19 +159,92 @@ func (mr *MockUserRepositoryMockRecorder) GetByUsername(username any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetByUsername), username)
 }
 
+// IncrementFailedLogins mocks base method.
+func (m *MockUserRepository) IncrementFailedLogins(id int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementFailedLogins", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementFailedLogins indicates an expected call of IncrementFailedLogins.
+func (mr *MockUserRepositoryMockRecorder) IncrementFailedLogins(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementFailedLogins", reflect.TypeOf((*MockUserRepository)(nil).IncrementFailedLogins), id)
+}
+
 // List mocks base method.
-func (m *MockUserRepository) List(limit, offset int32) ([]*domain.User, error) {
+func (m *MockUserRepository) List(params domain.UserListParams) ([]*domain.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", limit, offset)
+	ret := m.ctrl.Call(m, "List", params)
 	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), params)
+}
+
+// ListDeletedBefore mocks base method.
+func (m *MockUserRepository) ListDeletedBefore(before time.Time, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedBefore", before, limit)
+	ret0, _ := ret[0].([]int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockUserRepositoryMockRecorder) List(limit, offset any) *gomock.Call {
+// ListDeletedBefore indicates an expected call of ListDeletedBefore.
+func (mr *MockUserRepositoryMockRecorder) ListDeletedBefore(before, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedBefore", reflect.TypeOf((*MockUserRepository)(nil).ListDeletedBefore), before, limit)
+}
+
+// LockUntil mocks base method.
+func (m *MockUserRepository) LockUntil(id int64, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUntil", id, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUntil indicates an expected call of LockUntil.
+func (mr *MockUserRepositoryMockRecorder) LockUntil(id, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUntil", reflect.TypeOf((*MockUserRepository)(nil).LockUntil), id, until)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserRepository) MarkEmailVerified(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserRepositoryMockRecorder) MarkEmailVerified(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserRepository)(nil).MarkEmailVerified), id)
+}
+
+// ResetFailedLogins mocks base method.
+func (m *MockUserRepository) ResetFailedLogins(id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailedLogins", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailedLogins indicates an expected call of ResetFailedLogins.
+func (mr *MockUserRepositoryMockRecorder) ResetFailedLogins(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailedLogins", reflect.TypeOf((*MockUserRepository)(nil).ResetFailedLogins), id)
 }
 
 // Update mocks base method.
@@ -144,6 +262,20 @@ func (mr *MockUserRepositoryMockRecorder) Update(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), user)
 }
 
+// UpdateMembershipPlan mocks base method.
+func (m *MockUserRepository) UpdateMembershipPlan(id int64, planID *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMembershipPlan", id, planID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMembershipPlan indicates an expected call of UpdateMembershipPlan.
+func (mr *MockUserRepositoryMockRecorder) UpdateMembershipPlan(id, planID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMembershipPlan", reflect.TypeOf((*MockUserRepository)(nil).UpdateMembershipPlan), id, planID)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockUserRepository) UpdatePassword(id int64, passwordHash string) error {
 	m.ctrl.T.Helper()
@@ -158,6 +290,20 @@ func (mr *MockUserRepositoryMockRecorder) UpdatePassword(id, passwordHash any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserRepository)(nil).UpdatePassword), id, passwordHash)
 }
 
+// UpdateStatus mocks base method.
+func (m *MockUserRepository) UpdateStatus(id int64, status domain.UserStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockUserRepositoryMockRecorder) UpdateStatus(id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockUserRepository)(nil).UpdateStatus), id, status)
+}
+
 // MockUserService is a mock of UserService interface.
 type MockUserService struct {
 	ctrl     *gomock.Controller
@@ -182,6 +328,35 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// Anonymize mocks base method.
+func (m *MockUserService) Anonymize(id, anonymizedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", id, anonymizedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockUserServiceMockRecorder) Anonymize(id, anonymizedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockUserService)(nil).Anonymize), id, anonymizedBy)
+}
+
+// AnonymizeDeletedUsers mocks base method.
+func (m *MockUserService) AnonymizeDeletedUsers() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeDeletedUsers")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeDeletedUsers indicates an expected call of AnonymizeDeletedUsers.
+func (mr *MockUserServiceMockRecorder) AnonymizeDeletedUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeDeletedUsers", reflect.TypeOf((*MockUserService)(nil).AnonymizeDeletedUsers))
+}
+
 // ChangePassword mocks base method.
 func (m *MockUserService) ChangePassword(id int64, currentPassword, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -212,17 +387,17 @@ func (mr *MockUserServiceMockRecorder) Create(user, password any) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockUserService) Delete(id int64) error {
+func (m *MockUserService) Delete(id, deletedBy int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserServiceMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) Delete(id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserService)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserService)(nil).Delete), id, deletedBy)
 }
 
 // GetByEmail mocks base method.
@@ -271,18 +446,61 @@ func (mr *MockUserServiceMockRecorder) GetByUsername(username any) *gomock.Call
 }
 
 // List mocks base method.
-func (m *MockUserService) List(limit, offset int32) ([]*domain.User, error) {
+func (m *MockUserService) List(params domain.UserListParams) ([]*domain.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", limit, offset)
+	ret := m.ctrl.Call(m, "List", params)
 	ret0, _ := ret[0].([]*domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // List indicates an expected call of List.
-func (mr *MockUserServiceMockRecorder) List(limit, offset any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) List(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), params)
+}
+
+// Reactivate mocks base method.
+func (m *MockUserService) Reactivate(id, reactivatedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactivate", id, reactivatedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reactivate indicates an expected call of Reactivate.
+func (mr *MockUserServiceMockRecorder) Reactivate(id, reactivatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactivate", reflect.TypeOf((*MockUserService)(nil).Reactivate), id, reactivatedBy)
+}
+
+// Suspend mocks base method.
+func (m *MockUserService) Suspend(id, suspendedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", id, suspendedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockUserServiceMockRecorder) Suspend(id, suspendedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockUserService)(nil).Suspend), id, suspendedBy)
+}
+
+// Unlock mocks base method.
+func (m *MockUserService) Unlock(id, unlockedBy int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", id, unlockedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockUserServiceMockRecorder) Unlock(id, unlockedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockUserService)(nil).Unlock), id, unlockedBy)
 }
 
 // Update mocks base method.
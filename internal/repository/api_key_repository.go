@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// APIKeyRepository implements domain.APIKeyRepository
+type APIKeyRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(conn *DBConn, logger *logger.Logger) domain.APIKeyRepository {
+	return &APIKeyRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// apiKeyColumns lists the api_keys columns, joined with the owner's role, in the order expected by scanAPIKey
+const apiKeyColumns = `k.id, k.name, k.prefix, k.key_hash, k.user_id, u.role, k.scopes, k.expires_at,
+		k.last_used_at, k.revoked_at, k.created_by, k.created_at, k.updated_at`
+
+// GetByID retrieves an API key by ID
+func (r *APIKeyRepository) GetByID(id int64) (*domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.id = $1
+	`
+
+	key, err := scanAPIKey(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		r.logger.Error("Failed to get API key by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetByHash retrieves an API key by the hash of the raw key. Keys of suspended or deleted users are not found.
+func (r *APIKeyRepository) GetByHash(keyHash string) (*domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1 AND u.status = 'active'
+	`
+
+	key, err := scanAPIKey(r.db.QueryRow(query, keyHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		r.logger.Error("Failed to get API key by hash", zap.Error(err))
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// List retrieves a list of API keys with pagination
+func (r *APIKeyRepository) List(limit, offset int32) ([]*domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		ORDER BY k.id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		r.logger.Error("Failed to list API keys", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan API key row", zap.Error(err))
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating API key rows", zap.Error(err))
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Create creates a new API key
+func (r *APIKeyRepository) Create(key *domain.APIKey) (*domain.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, user_id, scopes, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRow(
+		query,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.UserID,
+		pq.Array(key.Scopes),
+		key.ExpiresAt,
+		key.CreatedBy,
+	).Scan(&id)
+	if err != nil {
+		r.logger.Error("Failed to create API key", zap.Int64("userID", key.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// Revoke revokes an API key
+func (r *APIKeyRepository) Revoke(id int64) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to revoke API key", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed records that an API key was used. The timestamp is only
+// written once a minute so busy integrations do not update the row on every request.
+func (r *APIKeyRepository) TouchLastUsed(id int64) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to update API key last used time", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// scanAPIKey scans an API key row selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+	var createdBy sql.NullInt64
+
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.UserID,
+		&key.UserRole,
+		pq.Array(&key.Scopes),
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&createdBy,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	key.CreatedBy = createdBy.Int64
+
+	return &key, nil
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// BookCopyRepository implements domain.BookCopyRepository
+type BookCopyRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewBookCopyRepository creates a new BookCopyRepository
+func NewBookCopyRepository(conn *DBConn, logger *logger.Logger) domain.BookCopyRepository {
+	return &BookCopyRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// bookCopyColumns lists the book_copies columns in the order expected by scanBookCopy
+const bookCopyColumns = `bc.id, bc.book_id, bc.barcode, bc.condition, bc.shelf_location, bc.status,
+		bc.created_at, bc.updated_at, b.title`
+
+// GetByID retrieves a book copy by ID
+func (r *BookCopyRepository) GetByID(id int64) (*domain.BookCopy, error) {
+	query := `
+		SELECT ` + bookCopyColumns + `
+		FROM book_copies bc
+		JOIN books b ON bc.book_id = b.id
+		WHERE bc.id = $1
+	`
+
+	bookCopy, err := scanBookCopy(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrBookCopyNotFound
+		}
+		r.logger.Error("Failed to get book copy by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// GetByBarcode retrieves a book copy by barcode
+func (r *BookCopyRepository) GetByBarcode(barcode string) (*domain.BookCopy, error) {
+	query := `
+		SELECT ` + bookCopyColumns + `
+		FROM book_copies bc
+		JOIN books b ON bc.book_id = b.id
+		WHERE bc.barcode = $1
+	`
+
+	bookCopy, err := scanBookCopy(r.db.QueryRow(query, barcode))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrBookCopyNotFound
+		}
+		r.logger.Error("Failed to get book copy by barcode", zap.String("barcode", barcode), zap.Error(err))
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// ListByBook retrieves all copies of a book
+func (r *BookCopyRepository) ListByBook(bookID int64) ([]*domain.BookCopy, error) {
+	query := `
+		SELECT ` + bookCopyColumns + `
+		FROM book_copies bc
+		JOIN books b ON bc.book_id = b.id
+		WHERE bc.book_id = $1
+		ORDER BY bc.barcode
+	`
+
+	rows, err := r.db.Query(query, bookID)
+	if err != nil {
+		r.logger.Error("Failed to list book copies", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	copies := []*domain.BookCopy{}
+	for rows.Next() {
+		bookCopy, err := scanBookCopy(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan book copy row", zap.Error(err))
+			return nil, err
+		}
+
+		copies = append(copies, bookCopy)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating book copy rows", zap.Error(err))
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+// Create adds a copy to a book
+func (r *BookCopyRepository) Create(bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	query := `
+		INSERT INTO book_copies (book_id, barcode, condition, shelf_location, status)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRow(query, bookCopy.BookID, bookCopy.Barcode, bookCopy.Condition, bookCopy.ShelfLocation, bookCopy.Status).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrBookCopyAlreadyExists
+		}
+		r.logger.Error("Failed to create book copy", zap.String("barcode", bookCopy.Barcode), zap.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// Update updates the condition, shelf location and status of a copy. Copies that are
+// checked out keep their status until they are returned, and copies on hold until the
+// hold ends.
+func (r *BookCopyRepository) Update(bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	query := `
+		UPDATE book_copies
+		SET condition = $2, shelf_location = NULLIF($3, ''), status = $4, updated_at = NOW()
+		WHERE id = $1 AND (status NOT IN ('checked_out', 'on_hold') OR status = $4)
+	`
+
+	result, err := r.db.Exec(query, bookCopy.ID, bookCopy.Condition, bookCopy.ShelfLocation, bookCopy.Status)
+	if err != nil {
+		r.logger.Error("Failed to update book copy", zap.Int64("id", bookCopy.ID), zap.Error(err))
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		if _, err := r.GetByID(bookCopy.ID); err != nil {
+			return nil, err
+		}
+		return nil, domain.NewInvalidInputError("a checked out or held copy keeps its status until it is returned or its hold ends")
+	}
+
+	return r.GetByID(bookCopy.ID)
+}
+
+// scanBookCopy scans a book copy row selected with bookCopyColumns
+func scanBookCopy(row rowScanner) (*domain.BookCopy, error) {
+	var bookCopy domain.BookCopy
+	var shelfLocation sql.NullString
+
+	err := row.Scan(
+		&bookCopy.ID,
+		&bookCopy.BookID,
+		&bookCopy.Barcode,
+		&bookCopy.Condition,
+		&shelfLocation,
+		&bookCopy.Status,
+		&bookCopy.CreatedAt,
+		&bookCopy.UpdatedAt,
+		&bookCopy.BookTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bookCopy.ShelfLocation = shelfLocation.String
+
+	return &bookCopy, nil
+}
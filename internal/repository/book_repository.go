@@ -25,11 +25,16 @@ func NewBookRepository(conn *DBConn, logger *logger.Logger) domain.BookRepositor
 	}
 }
 
+// bookCopyCounts derives the copy counts of the book aliased b from its copies. Lost and
+// withdrawn copies are no longer part of the collection.
+const bookCopyCounts = `(SELECT COUNT(*) FROM book_copies bc WHERE bc.book_id = b.id AND bc.status NOT IN ('lost', 'withdrawn')) AS total_copies,
+			   (SELECT COUNT(*) FROM book_copies bc WHERE bc.book_id = b.id AND bc.status = 'available') AS available_copies`
+
 // GetByID retrieves a book by ID
 func (r *BookRepository) GetByID(id int64) (*domain.Book, error) {
 	query := `
 		SELECT b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
-			   b.total_copies, b.available_copies, b.category_id, c.name as category_name,
+			   ` + bookCopyCounts + `, b.category_id, c.name as category_name,
 			   b.created_at, b.updated_at
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
@@ -79,7 +84,7 @@ func (r *BookRepository) GetByID(id int64) (*domain.Book, error) {
 func (r *BookRepository) GetByISBN(isbn string) (*domain.Book, error) {
 	query := `
 		SELECT b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
-			   b.total_copies, b.available_copies, b.category_id, c.name as category_name,
+			   ` + bookCopyCounts + `, b.category_id, c.name as category_name,
 			   b.created_at, b.updated_at
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
@@ -129,7 +134,7 @@ func (r *BookRepository) GetByISBN(isbn string) (*domain.Book, error) {
 func (r *BookRepository) List(limit, offset int32) ([]*domain.Book, error) {
 	query := `
 		SELECT b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
-			   b.total_copies, b.available_copies, b.category_id, c.name as category_name,
+			   ` + bookCopyCounts + `, b.category_id, c.name as category_name,
 			   b.created_at, b.updated_at
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
@@ -144,7 +149,7 @@ func (r *BookRepository) List(limit, offset int32) ([]*domain.Book, error) {
 func (r *BookRepository) ListByCategory(categoryID int64, limit, offset int32) ([]*domain.Book, error) {
 	query := `
 		SELECT b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
-			   b.total_copies, b.available_copies, b.category_id, c.name as category_name,
+			   ` + bookCopyCounts + `, b.category_id, c.name as category_name,
 			   b.created_at, b.updated_at
 		FROM books b
 		JOIN categories c ON b.category_id = c.id
@@ -209,7 +214,7 @@ func (r *BookRepository) ListByCategory(categoryID int64, limit, offset int32) (
 func (r *BookRepository) Search(params domain.BookSearchParams) ([]*domain.Book, error) {
 	query := `
 		SELECT b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
-			   b.total_copies, b.available_copies, b.category_id, c.name as category_name,
+			   ` + bookCopyCounts + `, b.category_id, c.name as category_name,
 			   b.created_at, b.updated_at
 		FROM books b
 		LEFT JOIN categories c ON b.category_id = c.id
@@ -251,7 +256,7 @@ func (r *BookRepository) Search(params domain.BookSearchParams) ([]*domain.Book,
 	}
 
 	if params.Available {
-		conditions = append(conditions, "b.available_copies > 0")
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM book_copies bc WHERE bc.book_id = b.id AND bc.status = 'available')")
 	}
 
 	if len(conditions) > 0 {
@@ -322,9 +327,10 @@ func (r *BookRepository) Search(params domain.BookSearchParams) ([]*domain.Book,
 // Create creates a new book
 func (r *BookRepository) Create(book *domain.Book) (*domain.Book, error) {
 	query := `
-		INSERT INTO books (title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id, created_at, updated_at
+		INSERT INTO books AS b (title, author, isbn, description, published_year, publisher, category_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
+			` + bookCopyCounts + `, b.category_id, b.created_at, b.updated_at
 	`
 
 	var categoryID sql.NullInt64
@@ -341,8 +347,6 @@ func (r *BookRepository) Create(book *domain.Book) (*domain.Book, error) {
 		book.Description,
 		book.PublishedYear,
 		book.Publisher,
-		book.TotalCopies,
-		book.AvailableCopies,
 		categoryID,
 	).Scan(
 		&book.ID,
@@ -380,11 +384,12 @@ func (r *BookRepository) Create(book *domain.Book) (*domain.Book, error) {
 // Update updates an existing book
 func (r *BookRepository) Update(book *domain.Book) (*domain.Book, error) {
 	query := `
-		UPDATE books
+		UPDATE books AS b
 		SET title = $2, author = $3, isbn = $4, description = $5, published_year = $6, 
 			publisher = $7, category_id = $8, updated_at = NOW()
-		WHERE id = $1
-		RETURNING id, title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id, created_at, updated_at
+		WHERE b.id = $1
+		RETURNING b.id, b.title, b.author, b.isbn, b.description, b.published_year, b.publisher,
+			` + bookCopyCounts + `, b.category_id, b.created_at, b.updated_at
 	`
 
 	var categoryID sql.NullInt64
@@ -439,167 +444,6 @@ func (r *BookRepository) Update(book *domain.Book) (*domain.Book, error) {
 	return book, nil
 }
 
-// UpdateCopies updates the total and available copies of a book
-func (r *BookRepository) UpdateCopies(id int64, totalCopies, availableCopies int32) (*domain.Book, error) {
-	query := `
-		UPDATE books
-		SET total_copies = $2, available_copies = $3, updated_at = NOW()
-		WHERE id = $1
-		RETURNING id, title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id, created_at, updated_at
-	`
-
-	var book domain.Book
-	var categoryID sql.NullInt64
-
-	err := r.db.QueryRow(
-		query,
-		id,
-		totalCopies,
-		availableCopies,
-	).Scan(
-		&book.ID,
-		&book.Title,
-		&book.Author,
-		&book.ISBN,
-		&book.Description,
-		&book.PublishedYear,
-		&book.Publisher,
-		&book.TotalCopies,
-		&book.AvailableCopies,
-		&categoryID,
-		&book.CreatedAt,
-		&book.UpdatedAt,
-	)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrBookNotFound
-		}
-		r.logger.Error("Failed to update book copies", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	if categoryID.Valid {
-		book.CategoryID = categoryID.Int64
-		// Get category name
-		var categoryName string
-		err := r.db.QueryRow("SELECT name FROM categories WHERE id = $1", categoryID.Int64).Scan(&categoryName)
-		if err == nil {
-			book.CategoryName = categoryName
-		}
-	}
-
-	return &book, nil
-}
-
-// DecrementAvailableCopies decrements the available copies of a book
-func (r *BookRepository) DecrementAvailableCopies(id int64) (*domain.Book, error) {
-	query := `
-		UPDATE books
-		SET available_copies = available_copies - 1, updated_at = NOW()
-		WHERE id = $1 AND available_copies > 0
-		RETURNING id, title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id, created_at, updated_at
-	`
-
-	var book domain.Book
-	var categoryID sql.NullInt64
-
-	err := r.db.QueryRow(query, id).Scan(
-		&book.ID,
-		&book.Title,
-		&book.Author,
-		&book.ISBN,
-		&book.Description,
-		&book.PublishedYear,
-		&book.Publisher,
-		&book.TotalCopies,
-		&book.AvailableCopies,
-		&categoryID,
-		&book.CreatedAt,
-		&book.UpdatedAt,
-	)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// Check if the book exists
-			exists, _ := r.bookExists(id)
-			if !exists {
-				return nil, domain.ErrBookNotFound
-			}
-			// Book exists but no available copies
-			return nil, domain.ErrBookNotAvailable
-		}
-		r.logger.Error("Failed to decrement available copies", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	if categoryID.Valid {
-		book.CategoryID = categoryID.Int64
-		// Get category name
-		var categoryName string
-		err := r.db.QueryRow("SELECT name FROM categories WHERE id = $1", categoryID.Int64).Scan(&categoryName)
-		if err == nil {
-			book.CategoryName = categoryName
-		}
-	}
-
-	return &book, nil
-}
-
-// IncrementAvailableCopies increments the available copies of a book
-func (r *BookRepository) IncrementAvailableCopies(id int64) (*domain.Book, error) {
-	query := `
-		UPDATE books
-		SET available_copies = available_copies + 1, updated_at = NOW()
-		WHERE id = $1 AND available_copies < total_copies
-		RETURNING id, title, author, isbn, description, published_year, publisher, total_copies, available_copies, category_id, created_at, updated_at
-	`
-
-	var book domain.Book
-	var categoryID sql.NullInt64
-
-	err := r.db.QueryRow(query, id).Scan(
-		&book.ID,
-		&book.Title,
-		&book.Author,
-		&book.ISBN,
-		&book.Description,
-		&book.PublishedYear,
-		&book.Publisher,
-		&book.TotalCopies,
-		&book.AvailableCopies,
-		&categoryID,
-		&book.CreatedAt,
-		&book.UpdatedAt,
-	)
-
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			// Check if the book exists
-			exists, _ := r.bookExists(id)
-			if !exists {
-				return nil, domain.ErrBookNotFound
-			}
-			// Book exists but all copies are available
-			return nil, fmt.Errorf("all copies are already available")
-		}
-		r.logger.Error("Failed to increment available copies", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	if categoryID.Valid {
-		book.CategoryID = categoryID.Int64
-		// Get category name
-		var categoryName string
-		err := r.db.QueryRow("SELECT name FROM categories WHERE id = $1", categoryID.Int64).Scan(&categoryName)
-		if err == nil {
-			book.CategoryName = categoryName
-		}
-	}
-
-	return &book, nil
-}
-
 // Delete deletes a book
 func (r *BookRepository) Delete(id int64) error {
 	query := `DELETE FROM books WHERE id = $1`
@@ -678,15 +522,3 @@ func (r *BookRepository) queryBooks(query string, args ...interface{}) ([]*domai
 
 	return books, nil
 }
-
-// bookExists checks if a book exists
-func (r *BookRepository) bookExists(id int64) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM books WHERE id = $1)`
-	err := r.db.QueryRow(query, id).Scan(&exists)
-	if err != nil {
-		r.logger.Error("Failed to check if book exists", zap.Int64("id", id), zap.Error(err))
-		return false, err
-	}
-	return exists, nil
-}
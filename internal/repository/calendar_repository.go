@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// CalendarRepository implements domain.CalendarRepository
+type CalendarRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewCalendarRepository creates a new CalendarRepository
+func NewCalendarRepository(conn *DBConn, logger *logger.Logger) domain.CalendarRepository {
+	return &CalendarRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// closureColumns lists the library_closures columns in the order expected by scanClosure
+const closureColumns = `id, closure_date, reason, source, created_by, created_at`
+
+// ListOpeningHours retrieves the opening hours of each day of the week, starting on Sunday
+func (r *CalendarRepository) ListOpeningHours() ([]*domain.OpeningHours, error) {
+	query := `
+		SELECT weekday, COALESCE(TO_CHAR(opens_at, 'HH24:MI'), ''), COALESCE(TO_CHAR(closes_at, 'HH24:MI'), ''), closed
+		FROM opening_hours
+		ORDER BY weekday
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to list opening hours", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	hours := []*domain.OpeningHours{}
+	for rows.Next() {
+		var h domain.OpeningHours
+		if err := rows.Scan(&h.Weekday, &h.OpensAt, &h.ClosesAt, &h.Closed); err != nil {
+			r.logger.Error("Failed to scan opening hours row", zap.Error(err))
+			return nil, err
+		}
+
+		hours = append(hours, &h)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating opening hours rows", zap.Error(err))
+		return nil, err
+	}
+
+	return hours, nil
+}
+
+// UpdateOpeningHours sets the opening hours of the given days of the week in a single transaction
+func (r *CalendarRepository) UpdateOpeningHours(hours []*domain.OpeningHours) (updatedHours []*domain.OpeningHours, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO opening_hours (weekday, opens_at, closes_at, closed, updated_at)
+		VALUES ($1, $2::time, $3::time, $4, NOW())
+		ON CONFLICT (weekday) DO UPDATE
+		SET opens_at = EXCLUDED.opens_at, closes_at = EXCLUDED.closes_at, closed = EXCLUDED.closed, updated_at = NOW()
+	`
+
+	for _, h := range hours {
+		var opensAt, closesAt sql.NullString
+		if !h.Closed {
+			opensAt = sql.NullString{String: h.OpensAt, Valid: true}
+			closesAt = sql.NullString{String: h.ClosesAt, Valid: true}
+		}
+
+		if _, err = tx.Exec(query, h.Weekday, opensAt, closesAt, h.Closed); err != nil {
+			r.logger.Error("Failed to update opening hours", zap.Int("weekday", int(h.Weekday)), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return r.ListOpeningHours()
+}
+
+// ListClosures retrieves the closures from one date up to and including another
+func (r *CalendarRepository) ListClosures(from, to time.Time) ([]*domain.Closure, error) {
+	query := `
+		SELECT ` + closureColumns + `
+		FROM library_closures
+		WHERE closure_date BETWEEN $1::date AND $2::date
+		ORDER BY closure_date
+	`
+
+	rows, err := r.db.Query(query, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		r.logger.Error("Failed to list closures", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := []*domain.Closure{}
+	for rows.Next() {
+		closure, err := scanClosure(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan closure row", zap.Error(err))
+			return nil, err
+		}
+
+		closures = append(closures, closure)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating closure rows", zap.Error(err))
+		return nil, err
+	}
+
+	return closures, nil
+}
+
+// CreateClosure creates a new closure
+func (r *CalendarRepository) CreateClosure(closure *domain.Closure) (*domain.Closure, error) {
+	query := `
+		INSERT INTO library_closures (closure_date, reason, source, created_by)
+		VALUES ($1::date, $2, $3, $4)
+		RETURNING ` + closureColumns + `
+	`
+
+	createdClosure, err := scanClosure(r.db.QueryRow(
+		query,
+		closure.Date.Format("2006-01-02"),
+		closure.Reason,
+		closure.Source,
+		closure.CreatedBy,
+	))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrClosureAlreadyExists
+		}
+		r.logger.Error("Failed to create closure", zap.Error(err))
+		return nil, err
+	}
+
+	return createdClosure, nil
+}
+
+// ImportClosures creates closures in a single transaction, skipping the dates the library is
+// already closed on, and returns how many were created
+func (r *CalendarRepository) ImportClosures(closures []*domain.Closure) (imported int, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `
+		INSERT INTO library_closures (closure_date, reason, source, created_by)
+		VALUES ($1::date, $2, $3, $4)
+		ON CONFLICT (closure_date) DO NOTHING
+	`
+
+	for _, closure := range closures {
+		result, err := tx.Exec(query, closure.Date.Format("2006-01-02"), closure.Reason, closure.Source, closure.CreatedBy)
+		if err != nil {
+			r.logger.Error("Failed to import closure", zap.Time("date", closure.Date), zap.Error(err))
+			return 0, err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			r.logger.Error("Failed to get rows affected", zap.Error(err))
+			return 0, err
+		}
+		imported += int(rowsAffected)
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return 0, err
+	}
+
+	return imported, nil
+}
+
+// DeleteClosure deletes a closure
+func (r *CalendarRepository) DeleteClosure(id int64) error {
+	query := `DELETE FROM library_closures WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete closure", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrClosureNotFound
+	}
+
+	return nil
+}
+
+// scanClosure scans a closure row selected with closureColumns
+func scanClosure(row rowScanner) (*domain.Closure, error) {
+	var closure domain.Closure
+
+	err := row.Scan(
+		&closure.ID,
+		&closure.Date,
+		&closure.Reason,
+		&closure.Source,
+		&closure.CreatedBy,
+		&closure.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &closure, nil
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// DataExportRepository implements domain.DataExportRepository
+type DataExportRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewDataExportRepository creates a new DataExportRepository
+func NewDataExportRepository(conn *DBConn, logger *logger.Logger) domain.DataExportRepository {
+	return &DataExportRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByID retrieves an unexpired data export by ID, without its archive
+func (r *DataExportRepository) GetByID(id int64) (*domain.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, token_hash, NULL::bytea, size_bytes, error, expires_at, completed_at, created_at
+		FROM data_exports
+		WHERE id = $1 AND expires_at > NOW()
+	`
+
+	export, err := scanDataExport(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		r.logger.Error("Failed to get data export by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetByTokenHash retrieves an unexpired data export and its archive by the hash of its download token
+func (r *DataExportRepository) GetByTokenHash(tokenHash string) (*domain.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, token_hash, archive, size_bytes, error, expires_at, completed_at, created_at
+		FROM data_exports
+		WHERE token_hash = $1 AND expires_at > NOW()
+	`
+
+	export, err := scanDataExport(r.db.QueryRow(query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		r.logger.Error("Failed to get data export by token", zap.Error(err))
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// CountUserRecords counts the rentals, payments, sessions and login attempts stored for a user
+func (r *DataExportRepository) CountUserRecords(userID int64) (int, error) {
+	query := `
+		SELECT (SELECT COUNT(*) FROM rentals WHERE user_id = $1) +
+			   (SELECT COUNT(*) FROM payments WHERE user_id = $1) +
+			   (SELECT COUNT(*) FROM sessions WHERE user_id = $1) +
+			   (SELECT COUNT(*) FROM login_attempts WHERE user_id = $1)
+	`
+
+	var count int
+	err := r.db.QueryRow(query, userID).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count user records", zap.Int64("userID", userID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Create creates a new pending data export. The user row is locked so that concurrent
+// requests cannot both start an export.
+func (r *DataExportRepository) Create(export *domain.DataExport) (createdExport *domain.DataExport, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var pending bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM data_exports
+			WHERE user_id = u.id
+			  AND (status = 'pending' OR (status = 'ready' AND expires_at > NOW()))
+		)
+		FROM users u
+		WHERE u.id = $1
+		FOR UPDATE OF u
+	`, export.UserID).Scan(&pending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = domain.ErrUserNotFound
+			return nil, err
+		}
+		r.logger.Error("Failed to check pending data exports", zap.Int64("userID", export.UserID), zap.Error(err))
+		return nil, err
+	}
+	if pending {
+		err = domain.ErrDataExportPending
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO data_exports (user_id, status, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err = tx.QueryRow(query, export.UserID, export.Status, export.TokenHash, export.ExpiresAt).Scan(
+		&export.ID,
+		&export.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create data export", zap.Int64("userID", export.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// Complete stores the generated archive and makes the export downloadable until expiresAt
+func (r *DataExportRepository) Complete(id int64, archive []byte, expiresAt time.Time) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'ready', archive = $2, size_bytes = $3, expires_at = $4, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id, archive, len(archive), expiresAt)
+	if err != nil {
+		r.logger.Error("Failed to complete data export", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrDataExportNotFound
+	}
+
+	return nil
+}
+
+// Fail marks a data export as failed
+func (r *DataExportRepository) Fail(id int64, reason string) error {
+	query := `
+		UPDATE data_exports
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id, reason)
+	if err != nil {
+		r.logger.Error("Failed to mark data export as failed", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrDataExportNotFound
+	}
+
+	return nil
+}
+
+// FailPendingBefore marks the exports still pending that were requested before the given time as failed
+func (r *DataExportRepository) FailPendingBefore(before time.Time, reason string) (int64, error) {
+	query := `
+		UPDATE data_exports
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE status = 'pending' AND created_at < $1
+	`
+
+	result, err := r.db.Exec(query, before, reason)
+	if err != nil {
+		r.logger.Error("Failed to fail pending data exports", zap.Error(err))
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteExpired removes data exports whose download link has expired
+func (r *DataExportRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM data_exports WHERE expires_at <= NOW()`)
+	if err != nil {
+		r.logger.Error("Failed to delete expired data exports", zap.Error(err))
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+// scanDataExport scans a data export row
+func scanDataExport(row rowScanner) (*domain.DataExport, error) {
+	var export domain.DataExport
+	var exportError sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.TokenHash,
+		&export.Archive,
+		&export.SizeBytes,
+		&exportError,
+		&export.ExpiresAt,
+		&completedAt,
+		&export.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	export.Error = exportError.String
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+
+	return &export, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// EmailVerificationRepository implements domain.EmailVerificationRepository
+type EmailVerificationRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository
+func NewEmailVerificationRepository(conn *DBConn, logger *logger.Logger) domain.EmailVerificationRepository {
+	return &EmailVerificationRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByTokenHash retrieves a verification token by its hash
+func (r *EmailVerificationRepository) GetByTokenHash(tokenHash string) (*domain.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1
+	`
+
+	var token domain.EmailVerificationToken
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidVerificationToken
+		}
+		r.logger.Error("Failed to get email verification token", zap.Error(err))
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+// Create creates a new verification token
+func (r *EmailVerificationRepository) Create(token *domain.EmailVerificationToken) (*domain.EmailVerificationToken, error) {
+	query := `
+		INSERT INTO email_verification_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, token.UserID, token.TokenHash, token.ExpiresAt).Scan(
+		&token.ID,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create email verification token", zap.Int64("userID", token.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// MarkUsed marks a verification token as used. It fails if the token was already used,
+// so a token cannot be redeemed twice even by concurrent requests.
+func (r *EmailVerificationRepository) MarkUsed(id int64) error {
+	query := `
+		UPDATE email_verification_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to mark email verification token used", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	return nil
+}
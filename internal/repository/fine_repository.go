@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// FineRepository implements domain.FineRepository
+type FineRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewFineRepository creates a new FineRepository
+func NewFineRepository(conn *DBConn, logger *logger.Logger) domain.FineRepository {
+	return &FineRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// fineColumns lists the fine columns in the order expected by scanFine
+const fineColumns = `f.id, f.rental_id, f.user_id, f.amount, f.amount_paid, f.status, f.waived_by,
+		f.waiver_reason, f.waived_at, f.created_at, f.updated_at, u.username, b.title`
+
+// fineJoins joins the tables selected by fineColumns
+const fineJoins = `FROM fines f
+		JOIN users u ON f.user_id = u.id
+		JOIN rentals r ON f.rental_id = r.id
+		JOIN books b ON r.book_id = b.id`
+
+// GetByID retrieves a fine by ID
+func (r *FineRepository) GetByID(id int64) (*domain.Fine, error) {
+	query := `SELECT ` + fineColumns + ` ` + fineJoins + ` WHERE f.id = $1`
+
+	fine, err := scanFine(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrFineNotFound
+		}
+		r.logger.Error("Failed to get fine by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return fine, nil
+}
+
+// GetByRental retrieves the fine assessed for a rental
+func (r *FineRepository) GetByRental(rentalID int64) (*domain.Fine, error) {
+	query := `SELECT ` + fineColumns + ` ` + fineJoins + ` WHERE f.rental_id = $1`
+
+	fine, err := scanFine(r.db.QueryRow(query, rentalID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrFineNotFound
+		}
+		r.logger.Error("Failed to get fine by rental", zap.Int64("rentalID", rentalID), zap.Error(err))
+		return nil, err
+	}
+
+	return fine, nil
+}
+
+// List retrieves fines with pagination, newest first
+func (r *FineRepository) List(status domain.FineStatus, limit, offset int32) ([]*domain.Fine, error) {
+	query := `SELECT ` + fineColumns + ` ` + fineJoins + `
+		WHERE ($1 = '' OR f.status = $1)
+		ORDER BY f.created_at DESC, f.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryFines(query, string(status), limit, offset)
+}
+
+// ListByUser retrieves every fine of a user, newest first
+func (r *FineRepository) ListByUser(userID int64) ([]*domain.Fine, error) {
+	query := `SELECT ` + fineColumns + ` ` + fineJoins + `
+		WHERE f.user_id = $1
+		ORDER BY f.created_at DESC, f.id DESC
+	`
+
+	return r.queryFines(query, userID)
+}
+
+// SumOutstandingByUser totals what a user still owes on their outstanding fines
+func (r *FineRepository) SumOutstandingByUser(userID int64) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount - amount_paid), 0)
+		FROM fines
+		WHERE user_id = $1 AND status = 'outstanding'
+	`
+
+	var total float64
+	if err := r.db.QueryRow(query, userID).Scan(&total); err != nil {
+		r.logger.Error("Failed to sum outstanding fines", zap.Int64("userID", userID), zap.Error(err))
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// Waive waives an outstanding fine, recording who waived it and why
+func (r *FineRepository) Waive(id, waivedBy int64, reason string) (*domain.Fine, error) {
+	query := `
+		UPDATE fines
+		SET status = 'waived', waived_by = $2, waiver_reason = $3, waived_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'outstanding'
+	`
+
+	result, err := r.db.Exec(query, id, waivedBy, reason)
+	if err != nil {
+		r.logger.Error("Failed to waive fine", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		// Tell a missing fine apart from one that is no longer outstanding
+		if _, err := r.GetByID(id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrFineNotOutstanding
+	}
+
+	return r.GetByID(id)
+}
+
+// Helper methods
+
+// queryFines executes a query and returns a list of fines
+func (r *FineRepository) queryFines(query string, args ...interface{}) ([]*domain.Fine, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query fines", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	fines := []*domain.Fine{}
+	for rows.Next() {
+		fine, err := scanFine(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan fine row", zap.Error(err))
+			return nil, err
+		}
+
+		fines = append(fines, fine)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating fine rows", zap.Error(err))
+		return nil, err
+	}
+
+	return fines, nil
+}
+
+// scanFine scans a fine row selected with fineColumns
+func scanFine(row rowScanner) (*domain.Fine, error) {
+	var fine domain.Fine
+
+	err := row.Scan(
+		&fine.ID,
+		&fine.RentalID,
+		&fine.UserID,
+		&fine.Amount,
+		&fine.AmountPaid,
+		&fine.Status,
+		&fine.WaivedBy,
+		&fine.WaiverReason,
+		&fine.WaivedAt,
+		&fine.CreatedAt,
+		&fine.UpdatedAt,
+		&fine.UserUsername,
+		&fine.BookTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fine, nil
+}
+
+// assessFine records the fine for a rental returned late
+func assessFine(tx *sql.Tx, rentalID, userID int64, amount float64) error {
+	_, err := tx.Exec(`
+		INSERT INTO fines (rental_id, user_id, amount, status)
+		VALUES ($1, $2, $3, 'outstanding')
+	`, rentalID, userID, amount)
+	return err
+}
+
+// applyFinePayment credits a completed payment to a fine. A negative amount takes a refunded
+// payment back, reopening a fine it had settled unless the fine was waived since. The fine row
+// is locked before a credit so concurrent payments cannot together pay more than the balance.
+func applyFinePayment(tx *sql.Tx, fineID int64, amount float64) error {
+	if amount > 0 {
+		var status domain.FineStatus
+		var overpaid bool
+		err := tx.QueryRow(`
+			SELECT status, amount_paid + $2 > amount
+			FROM fines
+			WHERE id = $1
+			FOR UPDATE
+		`, fineID, amount).Scan(&status, &overpaid)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrFineNotFound
+			}
+			return err
+		}
+
+		if status != domain.FineStatusOutstanding {
+			return domain.ErrFineNotOutstanding
+		}
+		if overpaid {
+			return domain.ErrFineOverpaid
+		}
+	}
+
+	_, err := tx.Exec(`
+		UPDATE fines
+		SET amount_paid = GREATEST(amount_paid + $2, 0),
+			status = CASE
+				WHEN status = 'waived' THEN status
+				WHEN amount_paid + $2 >= amount THEN 'paid'
+				ELSE 'outstanding'
+			END,
+			updated_at = NOW()
+		WHERE id = $1
+	`, fineID, amount)
+	return err
+}
+
+// isFinePaymentRefused reports whether applyFinePayment refused a credit because of the state
+// of the fine rather than a database failure
+func isFinePaymentRefused(err error) bool {
+	return errors.Is(err, domain.ErrFineNotFound) ||
+		errors.Is(err, domain.ErrFineNotOutstanding) ||
+		errors.Is(err, domain.ErrFineOverpaid)
+}
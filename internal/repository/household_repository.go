@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// HouseholdRepository implements domain.HouseholdRepository
+type HouseholdRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewHouseholdRepository creates a new HouseholdRepository
+func NewHouseholdRepository(conn *DBConn, logger *logger.Logger) domain.HouseholdRepository {
+	return &HouseholdRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// householdColumns lists the households columns in the order expected by scanHousehold
+const householdColumns = `id, name, guardian_id, max_concurrent_rentals, created_at, updated_at`
+
+// GetByID retrieves a household with its dependents by ID
+func (r *HouseholdRepository) GetByID(id int64) (*domain.Household, error) {
+	query := `
+		SELECT ` + householdColumns + `
+		FROM households
+		WHERE id = $1
+	`
+
+	household, err := scanHousehold(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrHouseholdNotFound
+		}
+		r.logger.Error("Failed to get household by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.loadDependents(household); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// GetByMember retrieves the household a user belongs to, either as guardian or as dependent
+func (r *HouseholdRepository) GetByMember(userID int64) (*domain.Household, error) {
+	query := `
+		SELECT ` + householdColumns + `
+		FROM households h
+		WHERE h.guardian_id = $1
+		   OR EXISTS (SELECT 1 FROM household_dependents d WHERE d.household_id = h.id AND d.user_id = $1)
+		LIMIT 1
+	`
+
+	household, err := scanHousehold(r.db.QueryRow(query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrHouseholdNotFound
+		}
+		r.logger.Error("Failed to get household by member", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.loadDependents(household); err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+// List retrieves all households with their dependents
+func (r *HouseholdRepository) List() ([]*domain.Household, error) {
+	query := `
+		SELECT ` + householdColumns + `
+		FROM households
+		ORDER BY name, id
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to list households", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var households []*domain.Household
+	for rows.Next() {
+		household, err := scanHousehold(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan household row", zap.Error(err))
+			return nil, err
+		}
+
+		households = append(households, household)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating household rows", zap.Error(err))
+		return nil, err
+	}
+
+	for _, household := range households {
+		if err := r.loadDependents(household); err != nil {
+			return nil, err
+		}
+	}
+
+	return households, nil
+}
+
+// Create creates a new household without dependents
+func (r *HouseholdRepository) Create(household *domain.Household) (*domain.Household, error) {
+	query := `
+		INSERT INTO households (name, guardian_id, max_concurrent_rentals)
+		VALUES ($1, $2, $3)
+		RETURNING ` + householdColumns + `
+	`
+
+	createdHousehold, err := scanHousehold(r.db.QueryRow(query, household.Name, household.GuardianID, household.MaxConcurrentRentals))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrAlreadyInHousehold
+		}
+		r.logger.Error("Failed to create household", zap.Error(err))
+		return nil, err
+	}
+
+	createdHousehold.Dependents = []*domain.HouseholdDependent{}
+	return createdHousehold, nil
+}
+
+// Update updates the name and shared limit of a household
+func (r *HouseholdRepository) Update(household *domain.Household) (*domain.Household, error) {
+	query := `
+		UPDATE households
+		SET name = $2, max_concurrent_rentals = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + householdColumns + `
+	`
+
+	updatedHousehold, err := scanHousehold(r.db.QueryRow(query, household.ID, household.Name, household.MaxConcurrentRentals))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrHouseholdNotFound
+		}
+		r.logger.Error("Failed to update household", zap.Int64("id", household.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := r.loadDependents(updatedHousehold); err != nil {
+		return nil, err
+	}
+
+	return updatedHousehold, nil
+}
+
+// Delete deletes a household, its dependents are unlinked from the guardian
+func (r *HouseholdRepository) Delete(id int64) error {
+	query := `DELETE FROM households WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete household", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrHouseholdNotFound
+	}
+
+	return nil
+}
+
+// AddDependent links a user to a household as a dependent
+func (r *HouseholdRepository) AddDependent(householdID, userID int64) error {
+	query := `INSERT INTO household_dependents (household_id, user_id) VALUES ($1, $2)`
+
+	if _, err := r.db.Exec(query, householdID, userID); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return domain.ErrAlreadyInHousehold
+		}
+		r.logger.Error("Failed to add household dependent", zap.Int64("householdID", householdID), zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RemoveDependent unlinks a dependent from a household
+func (r *HouseholdRepository) RemoveDependent(householdID, userID int64) error {
+	query := `DELETE FROM household_dependents WHERE household_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, householdID, userID)
+	if err != nil {
+		r.logger.Error("Failed to remove household dependent", zap.Int64("householdID", householdID), zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotHouseholdDependent
+	}
+
+	return nil
+}
+
+// IsGuardianOf checks if a user is the guardian of a household the other user is a dependent of
+func (r *HouseholdRepository) IsGuardianOf(guardianID, userID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM households h
+			JOIN household_dependents d ON d.household_id = h.id
+			WHERE h.guardian_id = $1 AND d.user_id = $2
+		)
+	`
+
+	var isGuardian bool
+	if err := r.db.QueryRow(query, guardianID, userID).Scan(&isGuardian); err != nil {
+		r.logger.Error("Failed to check household guardian", zap.Int64("guardianID", guardianID), zap.Int64("userID", userID), zap.Error(err))
+		return false, err
+	}
+
+	return isGuardian, nil
+}
+
+// loadDependents fills in the dependents of a household
+func (r *HouseholdRepository) loadDependents(household *domain.Household) error {
+	query := `
+		SELECT d.user_id, u.username, u.first_name, u.last_name, d.added_at
+		FROM household_dependents d
+		JOIN users u ON u.id = d.user_id
+		WHERE d.household_id = $1
+		ORDER BY d.added_at, d.user_id
+	`
+
+	rows, err := r.db.Query(query, household.ID)
+	if err != nil {
+		r.logger.Error("Failed to list household dependents", zap.Int64("householdID", household.ID), zap.Error(err))
+		return err
+	}
+	defer rows.Close()
+
+	household.Dependents = []*domain.HouseholdDependent{}
+	for rows.Next() {
+		var dependent domain.HouseholdDependent
+		var firstName, lastName sql.NullString
+
+		if err := rows.Scan(&dependent.UserID, &dependent.Username, &firstName, &lastName, &dependent.AddedAt); err != nil {
+			r.logger.Error("Failed to scan household dependent row", zap.Error(err))
+			return err
+		}
+
+		dependent.FirstName = firstName.String
+		dependent.LastName = lastName.String
+		household.Dependents = append(household.Dependents, &dependent)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating household dependent rows", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// scanHousehold scans a household row selected with householdColumns
+func scanHousehold(row rowScanner) (*domain.Household, error) {
+	var household domain.Household
+	var maxConcurrentRentals sql.NullInt32
+
+	err := row.Scan(
+		&household.ID,
+		&household.Name,
+		&household.GuardianID,
+		&maxConcurrentRentals,
+		&household.CreatedAt,
+		&household.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxConcurrentRentals.Valid {
+		limit := int(maxConcurrentRentals.Int32)
+		household.MaxConcurrentRentals = &limit
+	}
+
+	return &household, nil
+}
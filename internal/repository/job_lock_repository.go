@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// JobLockRepository implements domain.JobLockRepository with Postgres advisory locks
+type JobLockRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewJobLockRepository creates a new JobLockRepository
+func NewJobLockRepository(conn *DBConn, logger *logger.Logger) domain.JobLockRepository {
+	return &JobLockRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// TryLock takes the session-level advisory lock of a job. Advisory locks belong to the
+// connection that took them, so the lock keeps a connection out of the pool until it is
+// released. A replica that dies releases its locks with its connections.
+func (r *JobLockRepository) TryLock(ctx context.Context, job string) (func(), bool, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		r.logger.Error("Failed to get connection for job lock", zap.String("job", job), zap.Error(err))
+		return nil, false, err
+	}
+
+	key := jobLockKey(job)
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired)
+	if err != nil {
+		conn.Close()
+		r.logger.Error("Failed to take job lock", zap.String("job", job), zap.Error(err))
+		return nil, false, err
+	}
+
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// The job context may be cancelled by now, the lock has to be released regardless
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			r.logger.Error("Failed to release job lock", zap.String("job", job), zap.Error(err))
+		}
+		conn.Close()
+	}
+
+	return unlock, true, nil
+}
+
+// jobLockKey derives the advisory lock key of a job from its name
+func jobLockKey(job string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("jobs:" + job))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// LoanRuleRepository implements domain.LoanRuleRepository
+type LoanRuleRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewLoanRuleRepository creates a new LoanRuleRepository
+func NewLoanRuleRepository(conn *DBConn, logger *logger.Logger) domain.LoanRuleRepository {
+	return &LoanRuleRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// loanRuleColumns lists the loan_rules columns in the order expected by scanLoanRule
+const loanRuleColumns = `id, name, category_id, membership_plan_id, book_id, loan_period_days, max_renewals,
+		fine_per_day, fine_cap, grace_period_days, created_at, updated_at`
+
+// GetByID retrieves a loan rule by ID
+func (r *LoanRuleRepository) GetByID(id int64) (*domain.LoanRule, error) {
+	query := `
+		SELECT ` + loanRuleColumns + `
+		FROM loan_rules
+		WHERE id = $1
+	`
+
+	rule, err := scanLoanRule(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrLoanRuleNotFound
+		}
+		r.logger.Error("Failed to get loan rule by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// List retrieves all loan rules, the most specific first
+func (r *LoanRuleRepository) List() ([]*domain.LoanRule, error) {
+	query := `
+		SELECT ` + loanRuleColumns + `
+		FROM loan_rules
+		ORDER BY book_id IS NULL, category_id IS NULL, membership_plan_id IS NULL, name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to list loan rules", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []*domain.LoanRule{}
+	for rows.Next() {
+		rule, err := scanLoanRule(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan loan rule row", zap.Error(err))
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating loan rule rows", zap.Error(err))
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Match retrieves the most specific rule matching a book in the given category rented on
+// the given plan
+func (r *LoanRuleRepository) Match(bookID, categoryID, planID int64) (*domain.LoanRule, error) {
+	query := `
+		SELECT ` + loanRuleColumns + `
+		FROM loan_rules
+		WHERE (book_id IS NULL OR book_id = $1)
+			AND (category_id IS NULL OR category_id = $2)
+			AND (membership_plan_id IS NULL OR membership_plan_id = $3)
+		ORDER BY book_id IS NULL, category_id IS NULL, membership_plan_id IS NULL, id
+		LIMIT 1
+	`
+
+	rule, err := scanLoanRule(r.db.QueryRow(query, bookID, categoryID, planID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrLoanRuleNotFound
+		}
+		r.logger.Error("Failed to match loan rule", zap.Int64("bookID", bookID), zap.Int64("planID", planID), zap.Error(err))
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Create creates a new loan rule
+func (r *LoanRuleRepository) Create(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	query := `
+		INSERT INTO loan_rules (name, category_id, membership_plan_id, book_id, loan_period_days, max_renewals,
+			fine_per_day, fine_cap, grace_period_days)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + loanRuleColumns + `
+	`
+
+	createdRule, err := scanLoanRule(r.db.QueryRow(
+		query,
+		rule.Name,
+		rule.CategoryID,
+		rule.MembershipPlanID,
+		rule.BookID,
+		rule.LoanPeriodDays,
+		rule.MaxRenewals,
+		rule.FinePerDay,
+		rule.FineCap,
+		rule.GracePeriodDays,
+	))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrLoanRuleAlreadyExists
+		}
+		r.logger.Error("Failed to create loan rule", zap.Error(err))
+		return nil, err
+	}
+
+	return createdRule, nil
+}
+
+// Update updates an existing loan rule
+func (r *LoanRuleRepository) Update(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	query := `
+		UPDATE loan_rules
+		SET name = $2, category_id = $3, membership_plan_id = $4, book_id = $5, loan_period_days = $6,
+			max_renewals = $7, fine_per_day = $8, fine_cap = $9, grace_period_days = $10, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + loanRuleColumns + `
+	`
+
+	updatedRule, err := scanLoanRule(r.db.QueryRow(
+		query,
+		rule.ID,
+		rule.Name,
+		rule.CategoryID,
+		rule.MembershipPlanID,
+		rule.BookID,
+		rule.LoanPeriodDays,
+		rule.MaxRenewals,
+		rule.FinePerDay,
+		rule.FineCap,
+		rule.GracePeriodDays,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrLoanRuleNotFound
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrLoanRuleAlreadyExists
+		}
+		r.logger.Error("Failed to update loan rule", zap.Int64("id", rule.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return updatedRule, nil
+}
+
+// Delete deletes a loan rule
+func (r *LoanRuleRepository) Delete(id int64) error {
+	query := `DELETE FROM loan_rules WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete loan rule", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrLoanRuleNotFound
+	}
+
+	return nil
+}
+
+// scanLoanRule scans a loan rule row selected with loanRuleColumns
+func scanLoanRule(row rowScanner) (*domain.LoanRule, error) {
+	var rule domain.LoanRule
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.CategoryID,
+		&rule.MembershipPlanID,
+		&rule.BookID,
+		&rule.LoanPeriodDays,
+		&rule.MaxRenewals,
+		&rule.FinePerDay,
+		&rule.FineCap,
+		&rule.GracePeriodDays,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
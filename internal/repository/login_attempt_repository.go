@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LoginAttemptRepository implements domain.LoginAttemptRepository
+type LoginAttemptRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepository
+func NewLoginAttemptRepository(conn *DBConn, logger *logger.Logger) domain.LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// Record stores a login attempt
+func (r *LoginAttemptRepository) Record(attempt *domain.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (user_id, username, ip_address, success)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	var userID sql.NullInt64
+	if attempt.UserID != nil {
+		userID = sql.NullInt64{Int64: *attempt.UserID, Valid: true}
+	}
+
+	_, err := r.db.Exec(query, userID, attempt.Username, attempt.IPAddress, attempt.Success)
+	if err != nil {
+		r.logger.Error("Failed to record login attempt", zap.String("ip", attempt.IPAddress), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CountFailuresByIP counts failed login attempts from an IP address since the given time
+func (r *LoginAttemptRepository) CountFailuresByIP(ipAddress string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM login_attempts
+		WHERE ip_address = $1 AND success = FALSE AND created_at >= $2
+	`
+
+	var count int
+	err := r.db.QueryRow(query, ipAddress, since).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count failed login attempts", zap.String("ip", ipAddress), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUnknownUserFailures counts the failed attempts for a username that matches no account
+// and returns when the last one was made
+func (r *LoginAttemptRepository) GetUnknownUserFailures(username string) (int, time.Time, error) {
+	query := `
+		SELECT COUNT(*), MAX(created_at)
+		FROM login_attempts
+		WHERE username = $1 AND user_id IS NULL AND success = FALSE
+	`
+
+	var count int
+	var last sql.NullTime
+	err := r.db.QueryRow(query, username).Scan(&count, &last)
+	if err != nil {
+		r.logger.Error("Failed to count failed login attempts by username", zap.String("username", username), zap.Error(err))
+		return 0, time.Time{}, err
+	}
+
+	return count, last.Time, nil
+}
+
+// ListByUser retrieves the recorded login attempts of a user, newest first
+func (r *LoginAttemptRepository) ListByUser(userID int64) ([]*domain.LoginAttempt, error) {
+	query := `
+		SELECT id, user_id, username, ip_address, success, created_at
+		FROM login_attempts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		r.logger.Error("Failed to list login attempts by user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*domain.LoginAttempt
+	for rows.Next() {
+		var attempt domain.LoginAttempt
+		var attemptUserID sql.NullInt64
+
+		err := rows.Scan(
+			&attempt.ID,
+			&attemptUserID,
+			&attempt.Username,
+			&attempt.IPAddress,
+			&attempt.Success,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			r.logger.Error("Failed to scan login attempt row", zap.Error(err))
+			return nil, err
+		}
+
+		if attemptUserID.Valid {
+			attempt.UserID = &attemptUserID.Int64
+		}
+
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating login attempt rows", zap.Error(err))
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// DeleteBefore removes login attempts older than the given time
+func (r *LoginAttemptRepository) DeleteBefore(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM login_attempts WHERE created_at < $1`, before)
+	if err != nil {
+		r.logger.Error("Failed to delete old login attempts", zap.Error(err))
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
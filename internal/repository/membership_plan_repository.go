@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MembershipPlanRepository implements domain.MembershipPlanRepository
+type MembershipPlanRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewMembershipPlanRepository creates a new MembershipPlanRepository
+func NewMembershipPlanRepository(conn *DBConn, logger *logger.Logger) domain.MembershipPlanRepository {
+	return &MembershipPlanRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// membershipPlanColumns lists the membership_plans columns in the order expected by scanMembershipPlan
+const membershipPlanColumns = `id, name, description, max_concurrent_rentals, loan_period_days, max_extensions,
+		max_extension_days, monthly_fee, is_default, created_at, updated_at`
+
+// GetByID retrieves a membership plan by ID
+func (r *MembershipPlanRepository) GetByID(id int64) (*domain.MembershipPlan, error) {
+	query := `
+		SELECT ` + membershipPlanColumns + `
+		FROM membership_plans
+		WHERE id = $1
+	`
+
+	plan, err := scanMembershipPlan(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMembershipPlanNotFound
+		}
+		r.logger.Error("Failed to get membership plan by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// GetByName retrieves a membership plan by name
+func (r *MembershipPlanRepository) GetByName(name string) (*domain.MembershipPlan, error) {
+	query := `
+		SELECT ` + membershipPlanColumns + `
+		FROM membership_plans
+		WHERE name = $1
+	`
+
+	plan, err := scanMembershipPlan(r.db.QueryRow(query, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMembershipPlanNotFound
+		}
+		r.logger.Error("Failed to get membership plan by name", zap.String("name", name), zap.Error(err))
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// GetDefault retrieves the plan that applies to users without an assigned plan
+func (r *MembershipPlanRepository) GetDefault() (*domain.MembershipPlan, error) {
+	query := `
+		SELECT ` + membershipPlanColumns + `
+		FROM membership_plans
+		WHERE is_default
+	`
+
+	plan, err := scanMembershipPlan(r.db.QueryRow(query))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMembershipPlanNotFound
+		}
+		r.logger.Error("Failed to get default membership plan", zap.Error(err))
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// List retrieves all membership plans
+func (r *MembershipPlanRepository) List() ([]*domain.MembershipPlan, error) {
+	query := `
+		SELECT ` + membershipPlanColumns + `
+		FROM membership_plans
+		ORDER BY monthly_fee, name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to list membership plans", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []*domain.MembershipPlan
+	for rows.Next() {
+		plan, err := scanMembershipPlan(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan membership plan row", zap.Error(err))
+			return nil, err
+		}
+
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating membership plan rows", zap.Error(err))
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+// Create creates a new membership plan. A new default plan replaces the previous default.
+func (r *MembershipPlanRepository) Create(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if plan.IsDefault {
+		if _, err = tx.Exec("UPDATE membership_plans SET is_default = FALSE, updated_at = NOW() WHERE is_default"); err != nil {
+			r.logger.Error("Failed to clear default membership plan", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	query := `
+		INSERT INTO membership_plans (name, description, max_concurrent_rentals, loan_period_days, max_extensions,
+			max_extension_days, monthly_fee, is_default)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + membershipPlanColumns + `
+	`
+
+	var createdPlan *domain.MembershipPlan
+	createdPlan, err = scanMembershipPlan(tx.QueryRow(
+		query,
+		plan.Name,
+		plan.Description,
+		plan.MaxConcurrentRentals,
+		plan.LoanPeriodDays,
+		plan.MaxExtensions,
+		plan.MaxExtensionDays,
+		plan.MonthlyFee,
+		plan.IsDefault,
+	))
+	if err != nil {
+		r.logger.Error("Failed to create membership plan", zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return createdPlan, nil
+}
+
+// Update updates an existing membership plan. Making a plan the default replaces the previous default.
+func (r *MembershipPlanRepository) Update(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if plan.IsDefault {
+		if _, err = tx.Exec("UPDATE membership_plans SET is_default = FALSE, updated_at = NOW() WHERE is_default AND id <> $1", plan.ID); err != nil {
+			r.logger.Error("Failed to clear default membership plan", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	query := `
+		UPDATE membership_plans
+		SET name = $2, description = $3, max_concurrent_rentals = $4, loan_period_days = $5, max_extensions = $6,
+			max_extension_days = $7, monthly_fee = $8, is_default = $9, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + membershipPlanColumns + `
+	`
+
+	var updatedPlan *domain.MembershipPlan
+	updatedPlan, err = scanMembershipPlan(tx.QueryRow(
+		query,
+		plan.ID,
+		plan.Name,
+		plan.Description,
+		plan.MaxConcurrentRentals,
+		plan.LoanPeriodDays,
+		plan.MaxExtensions,
+		plan.MaxExtensionDays,
+		plan.MonthlyFee,
+		plan.IsDefault,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMembershipPlanNotFound
+		}
+		r.logger.Error("Failed to update membership plan", zap.Int64("id", plan.ID), zap.Error(err))
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return updatedPlan, nil
+}
+
+// Delete deletes a membership plan, its members fall back to the default plan
+func (r *MembershipPlanRepository) Delete(id int64) error {
+	query := `DELETE FROM membership_plans WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to delete membership plan", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrMembershipPlanNotFound
+	}
+
+	return nil
+}
+
+// scanMembershipPlan scans a membership plan row selected with membershipPlanColumns
+func scanMembershipPlan(row rowScanner) (*domain.MembershipPlan, error) {
+	var plan domain.MembershipPlan
+	var description sql.NullString
+
+	err := row.Scan(
+		&plan.ID,
+		&plan.Name,
+		&description,
+		&plan.MaxConcurrentRentals,
+		&plan.LoanPeriodDays,
+		&plan.MaxExtensions,
+		&plan.MaxExtensionDays,
+		&plan.MonthlyFee,
+		&plan.IsDefault,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Description = description.String
+
+	return &plan, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+)
+
+// MemoryRevokedTokenRepository implements domain.RevokedTokenRepository in memory.
+// It is intended for tests and single-instance development setups only.
+type MemoryRevokedTokenRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]*domain.RevokedToken
+}
+
+// NewMemoryRevokedTokenRepository creates a new MemoryRevokedTokenRepository
+func NewMemoryRevokedTokenRepository() domain.RevokedTokenRepository {
+	return &MemoryRevokedTokenRepository{
+		tokens: make(map[string]*domain.RevokedToken),
+	}
+}
+
+// Revoke adds a token to the revocation store
+func (r *MemoryRevokedTokenRepository) Revoke(token *domain.RevokedToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tokens[token.JTI]; exists {
+		return nil
+	}
+
+	revoked := *token
+	if revoked.RevokedAt.IsZero() {
+		revoked.RevokedAt = time.Now()
+	}
+	r.tokens[token.JTI] = &revoked
+
+	return nil
+}
+
+// IsRevoked checks if a token has been revoked
+func (r *MemoryRevokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.tokens[jti]
+	return exists, nil
+}
+
+// DeleteExpired removes revocation entries for tokens that have expired anyway
+func (r *MemoryRevokedTokenRepository) DeleteExpired() (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var deleted int64
+	for jti, token := range r.tokens {
+		if token.ExpiresAt.Before(now) {
+			delete(r.tokens, jti)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
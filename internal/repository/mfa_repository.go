@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MFARepository implements domain.MFARepository
+type MFARepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewMFARepository creates a new MFARepository
+func NewMFARepository(conn *DBConn, logger *logger.Logger) domain.MFARepository {
+	return &MFARepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByUserID retrieves the two-factor settings of a user
+func (r *MFARepository) GetByUserID(userID int64) (*domain.MFASettings, error) {
+	query := `
+		SELECT user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+
+	settings, err := scanMFASettings(r.db.QueryRow(query, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMFANotEnrolled
+		}
+		r.logger.Error("Failed to get MFA settings", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// Upsert starts a new enrollment for a user, replacing any unconfirmed one
+func (r *MFARepository) Upsert(settings *domain.MFASettings) (*domain.MFASettings, error) {
+	query := `
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = NULL, last_used_step = 0, updated_at = NOW()
+		RETURNING user_id, secret, confirmed_at, last_used_step, created_at, updated_at
+	`
+
+	saved, err := scanMFASettings(r.db.QueryRow(query, settings.UserID, settings.Secret))
+	if err != nil {
+		r.logger.Error("Failed to save MFA settings", zap.Int64("userID", settings.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// Confirm marks the enrollment of a user as confirmed
+func (r *MFARepository) Confirm(userID int64) error {
+	query := `
+		UPDATE user_mfa
+		SET confirmed_at = NOW(), updated_at = NOW()
+		WHERE user_id = $1 AND confirmed_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, userID)
+	if err != nil {
+		r.logger.Error("Failed to confirm MFA", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrMFANotEnrolled
+	}
+
+	return nil
+}
+
+// UseStep records the time step of an accepted code. It fails if the same or a
+// later step was already used, so every code can be redeemed only once.
+func (r *MFARepository) UseStep(userID int64, step int64) error {
+	query := `
+		UPDATE user_mfa
+		SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND last_used_step < $2
+	`
+
+	result, err := r.db.Exec(query, userID, step)
+	if err != nil {
+		r.logger.Error("Failed to record MFA step", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes discards the recovery codes of a user and stores new ones
+func (r *MFARepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.Exec(`DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID)
+	if err != nil {
+		r.logger.Error("Failed to delete recovery codes", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	for _, codeHash := range codeHashes {
+		_, err = tx.Exec(`INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, codeHash)
+		if err != nil {
+			r.logger.Error("Failed to insert recovery code", zap.Int64("userID", userID), zap.Error(err))
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode redeems an unused recovery code of a user
+func (r *MFARepository) UseRecoveryCode(userID int64, codeHash string) error {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, userID, codeHash)
+	if err != nil {
+		r.logger.Error("Failed to use recovery code", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// scanMFASettings scans a user_mfa row
+func scanMFASettings(row rowScanner) (*domain.MFASettings, error) {
+	var settings domain.MFASettings
+	var confirmedAt sql.NullTime
+
+	err := row.Scan(
+		&settings.UserID,
+		&settings.Secret,
+		&confirmedAt,
+		&settings.LastUsedStep,
+		&settings.CreatedAt,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmedAt.Valid {
+		settings.ConfirmedAt = &confirmedAt.Time
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// OIDCAuthRequestRepository implements domain.OIDCAuthRequestRepository
+type OIDCAuthRequestRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewOIDCAuthRequestRepository creates a new OIDCAuthRequestRepository
+func NewOIDCAuthRequestRepository(conn *DBConn, logger *logger.Logger) domain.OIDCAuthRequestRepository {
+	return &OIDCAuthRequestRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// Create stores a pending login
+func (r *OIDCAuthRequestRepository) Create(request *domain.OIDCAuthRequest) (*domain.OIDCAuthRequest, error) {
+	query := `
+		INSERT INTO oidc_auth_requests (state_hash, provider, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, request.StateHash, request.Provider, request.CodeVerifier, request.Nonce, request.ExpiresAt).Scan(
+		&request.ID,
+		&request.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create OIDC auth request", zap.String("provider", request.Provider), zap.Error(err))
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Consume deletes and returns the unexpired pending login for a state hash,
+// so each state can complete a login only once even under concurrent callbacks
+func (r *OIDCAuthRequestRepository) Consume(stateHash string) (*domain.OIDCAuthRequest, error) {
+	query := `
+		DELETE FROM oidc_auth_requests
+		WHERE state_hash = $1 AND expires_at > NOW()
+		RETURNING id, state_hash, provider, code_verifier, nonce, expires_at, created_at
+	`
+
+	var request domain.OIDCAuthRequest
+	err := r.db.QueryRow(query, stateHash).Scan(
+		&request.ID,
+		&request.StateHash,
+		&request.Provider,
+		&request.CodeVerifier,
+		&request.Nonce,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidOIDCState
+		}
+		r.logger.Error("Failed to consume OIDC auth request", zap.Error(err))
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// DeleteExpired removes pending logins that were never completed
+func (r *OIDCAuthRequestRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM oidc_auth_requests WHERE expires_at < NOW()`)
+	if err != nil {
+		r.logger.Error("Failed to delete expired OIDC auth requests", zap.Error(err))
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
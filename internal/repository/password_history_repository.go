@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PasswordHistoryRepository implements domain.PasswordHistoryRepository
+type PasswordHistoryRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewPasswordHistoryRepository creates a new PasswordHistoryRepository
+func NewPasswordHistoryRepository(conn *DBConn, logger *logger.Logger) domain.PasswordHistoryRepository {
+	return &PasswordHistoryRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// ListRecent retrieves the hashes of a user's most recent passwords, newest first
+func (r *PasswordHistoryRepository) ListRecent(userID int64, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, userID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list password history", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			r.logger.Error("Failed to scan password history", zap.Error(err))
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating password history rows", zap.Error(err))
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// Add records a password hash set by a user
+func (r *PasswordHistoryRepository) Add(userID int64, passwordHash string) error {
+	query := `
+		INSERT INTO password_history (user_id, password_hash)
+		VALUES ($1, $2)
+	`
+
+	_, err := r.db.Exec(query, userID, passwordHash)
+	if err != nil {
+		r.logger.Error("Failed to add password history", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Prune removes all but a user's most recent password hashes
+func (r *PasswordHistoryRepository) Prune(userID int64, keep int) error {
+	query := `
+		DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		)
+	`
+
+	_, err := r.db.Exec(query, userID, keep)
+	if err != nil {
+		r.logger.Error("Failed to prune password history", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
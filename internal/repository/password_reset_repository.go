@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PasswordResetRepository implements domain.PasswordResetRepository
+type PasswordResetRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository
+func NewPasswordResetRepository(conn *DBConn, logger *logger.Logger) domain.PasswordResetRepository {
+	return &PasswordResetRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByTokenHash retrieves a reset token by its hash
+func (r *PasswordResetRepository) GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+	`
+
+	var token domain.PasswordResetToken
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&usedAt,
+		&token.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrInvalidResetToken
+		}
+		r.logger.Error("Failed to get password reset token", zap.Error(err))
+		return nil, err
+	}
+
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+// Create creates a new reset token
+func (r *PasswordResetRepository) Create(token *domain.PasswordResetToken) (*domain.PasswordResetToken, error) {
+	query := `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, token.UserID, token.TokenHash, token.ExpiresAt).Scan(
+		&token.ID,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create password reset token", zap.Int64("userID", token.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// MarkUsed marks a reset token as used. It fails if the token was already used,
+// so a token cannot be redeemed twice even by concurrent requests.
+func (r *PasswordResetRepository) MarkUsed(id int64) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to mark password reset token used", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+// InvalidateByUser marks all outstanding reset tokens of a user as used
+func (r *PasswordResetRepository) InvalidateByUser(userID int64) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = NOW()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+
+	_, err := r.db.Exec(query, userID)
+	if err != nil {
+		r.logger.Error("Failed to invalidate password reset tokens", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
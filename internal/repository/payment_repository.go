@@ -27,7 +27,7 @@ func NewPaymentRepository(conn *DBConn, logger *logger.Logger) domain.PaymentRep
 // GetByID retrieves a payment by ID
 func (r *PaymentRepository) GetByID(id int64) (*domain.Payment, error) {
 	query := `
-		SELECT p.id, p.user_id, p.rental_id, p.amount, p.payment_date, p.payment_method, p.status,
+		SELECT p.id, p.user_id, p.rental_id, p.fine_id, p.amount, p.payment_date, p.payment_method, p.status,
 			   p.transaction_id, p.created_at, p.updated_at, u.username as user_username,
 			   b.title as book_title
 		FROM payments p
@@ -39,12 +39,14 @@ func (r *PaymentRepository) GetByID(id int64) (*domain.Payment, error) {
 
 	var payment domain.Payment
 	var rentalID sql.NullInt64
+	var fineID sql.NullInt64
 	var bookTitle sql.NullString
 
 	err := r.db.QueryRow(query, id).Scan(
 		&payment.ID,
 		&payment.UserID,
 		&rentalID,
+		&fineID,
 		&payment.Amount,
 		&payment.PaymentDate,
 		&payment.PaymentMethod,
@@ -68,6 +70,10 @@ func (r *PaymentRepository) GetByID(id int64) (*domain.Payment, error) {
 		payment.RentalID = &rentalID.Int64
 	}
 
+	if fineID.Valid {
+		payment.FineID = &fineID.Int64
+	}
+
 	if bookTitle.Valid {
 		payment.BookTitle = bookTitle.String
 	}
@@ -78,7 +84,7 @@ func (r *PaymentRepository) GetByID(id int64) (*domain.Payment, error) {
 // List retrieves a list of payments with pagination
 func (r *PaymentRepository) List(limit, offset int32) ([]*domain.Payment, error) {
 	query := `
-		SELECT p.id, p.user_id, p.rental_id, p.amount, p.payment_date, p.payment_method, p.status,
+		SELECT p.id, p.user_id, p.rental_id, p.fine_id, p.amount, p.payment_date, p.payment_method, p.status,
 			   p.transaction_id, p.created_at, p.updated_at, u.username as user_username,
 			   b.title as book_title
 		FROM payments p
@@ -95,7 +101,7 @@ func (r *PaymentRepository) List(limit, offset int32) ([]*domain.Payment, error)
 // ListByUser retrieves a list of payments for a specific user with pagination
 func (r *PaymentRepository) ListByUser(userID int64, limit, offset int32) ([]*domain.Payment, error) {
 	query := `
-		SELECT p.id, p.user_id, p.rental_id, p.amount, p.payment_date, p.payment_method, p.status,
+		SELECT p.id, p.user_id, p.rental_id, p.fine_id, p.amount, p.payment_date, p.payment_method, p.status,
 			   p.transaction_id, p.created_at, p.updated_at, u.username as user_username,
 			   b.title as book_title
 		FROM payments p
@@ -118,12 +124,14 @@ func (r *PaymentRepository) ListByUser(userID int64, limit, offset int32) ([]*do
 	for rows.Next() {
 		var payment domain.Payment
 		var rentalID sql.NullInt64
+		var fineID sql.NullInt64
 		var bookTitle sql.NullString
 
 		err := rows.Scan(
 			&payment.ID,
 			&payment.UserID,
 			&rentalID,
+			&fineID,
 			&payment.Amount,
 			&payment.PaymentDate,
 			&payment.PaymentMethod,
@@ -143,6 +151,10 @@ func (r *PaymentRepository) ListByUser(userID int64, limit, offset int32) ([]*do
 			payment.RentalID = &rentalID.Int64
 		}
 
+		if fineID.Valid {
+			payment.FineID = &fineID.Int64
+		}
+
 		if bookTitle.Valid {
 			payment.BookTitle = bookTitle.String
 		}
@@ -161,7 +173,7 @@ func (r *PaymentRepository) ListByUser(userID int64, limit, offset int32) ([]*do
 // ListByRental retrieves a list of payments for a specific rental
 func (r *PaymentRepository) ListByRental(rentalID int64) ([]*domain.Payment, error) {
 	query := `
-		SELECT p.id, p.user_id, p.rental_id, p.amount, p.payment_date, p.payment_method, p.status,
+		SELECT p.id, p.user_id, p.rental_id, p.fine_id, p.amount, p.payment_date, p.payment_method, p.status,
 			   p.transaction_id, p.created_at, p.updated_at, u.username as user_username,
 			   b.title as book_title
 		FROM payments p
@@ -183,12 +195,14 @@ func (r *PaymentRepository) ListByRental(rentalID int64) ([]*domain.Payment, err
 	for rows.Next() {
 		var payment domain.Payment
 		var paymentRentalID sql.NullInt64
+		var fineID sql.NullInt64
 		var bookTitle sql.NullString
 
 		err := rows.Scan(
 			&payment.ID,
 			&payment.UserID,
 			&paymentRentalID,
+			&fineID,
 			&payment.Amount,
 			&payment.PaymentDate,
 			&payment.PaymentMethod,
@@ -208,6 +222,10 @@ func (r *PaymentRepository) ListByRental(rentalID int64) ([]*domain.Payment, err
 			payment.RentalID = &paymentRentalID.Int64
 		}
 
+		if fineID.Valid {
+			payment.FineID = &fineID.Int64
+		}
+
 		if bookTitle.Valid {
 			payment.BookTitle = bookTitle.String
 		}
@@ -223,24 +241,41 @@ func (r *PaymentRepository) ListByRental(rentalID int64) ([]*domain.Payment, err
 	return payments, nil
 }
 
-// Create creates a new payment
+// Create creates a new payment. A completed payment for a fine is credited to the fine.
 func (r *PaymentRepository) Create(payment *domain.Payment) (*domain.Payment, error) {
 	query := `
-		INSERT INTO payments (user_id, rental_id, amount, payment_date, payment_method, status, transaction_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, user_id, rental_id, amount, payment_date, payment_method, status, transaction_id, created_at, updated_at
+		INSERT INTO payments (user_id, rental_id, fine_id, amount, payment_date, payment_method, status, transaction_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, user_id, rental_id, fine_id, amount, payment_date, payment_method, status, transaction_id, created_at, updated_at
 	`
 
 	var rentalID sql.NullInt64
+	var fineID sql.NullInt64
 	if payment.RentalID != nil {
 		rentalID.Int64 = *payment.RentalID
 		rentalID.Valid = true
 	}
+	if payment.FineID != nil {
+		fineID.Int64 = *payment.FineID
+		fineID.Valid = true
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
 
-	err := r.db.QueryRow(
+	err = tx.QueryRow(
 		query,
 		payment.UserID,
 		rentalID,
+		fineID,
 		payment.Amount,
 		payment.PaymentDate,
 		payment.PaymentMethod,
@@ -250,6 +285,7 @@ func (r *PaymentRepository) Create(payment *domain.Payment) (*domain.Payment, er
 		&payment.ID,
 		&payment.UserID,
 		&rentalID,
+		&fineID,
 		&payment.Amount,
 		&payment.PaymentDate,
 		&payment.PaymentMethod,
@@ -264,10 +300,28 @@ func (r *PaymentRepository) Create(payment *domain.Payment) (*domain.Payment, er
 		return nil, err
 	}
 
+	if fineID.Valid && payment.Status == domain.PaymentStatusCompleted {
+		if err = applyFinePayment(tx, fineID.Int64, payment.Amount); err != nil {
+			if !isFinePaymentRefused(err) {
+				r.logger.Error("Failed to credit payment to fine", zap.Int64("fineID", fineID.Int64), zap.Error(err))
+			}
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
 	if rentalID.Valid {
 		payment.RentalID = &rentalID.Int64
 	}
 
+	if fineID.Valid {
+		payment.FineID = &fineID.Int64
+	}
+
 	// Get user details
 	err = r.db.QueryRow("SELECT username FROM users WHERE id = $1", payment.UserID).Scan(&payment.UserUsername)
 	if err != nil {
@@ -292,22 +346,46 @@ func (r *PaymentRepository) Create(payment *domain.Payment) (*domain.Payment, er
 	return payment, nil
 }
 
-// UpdateStatus updates the status of a payment
+// UpdateStatus updates the status of a payment. A payment for a fine is credited to the fine
+// when it completes and taken back when it stops being completed, e.g. when it is refunded.
 func (r *PaymentRepository) UpdateStatus(id int64, status domain.PaymentStatus) (*domain.Payment, error) {
 	query := `
 		UPDATE payments
 		SET status = $2, updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, user_id, rental_id, amount, payment_date, payment_method, status, transaction_id, created_at, updated_at
+		RETURNING id, user_id, rental_id, fine_id, amount, payment_date, payment_method, status, transaction_id, created_at, updated_at
 	`
 
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var previousStatus domain.PaymentStatus
+	err = tx.QueryRow("SELECT status FROM payments WHERE id = $1 FOR UPDATE", id).Scan(&previousStatus)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPaymentNotFound
+		}
+		r.logger.Error("Failed to get payment status", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
 	var payment domain.Payment
 	var rentalID sql.NullInt64
+	var fineID sql.NullInt64
 
-	err := r.db.QueryRow(query, id, status).Scan(
+	err = tx.QueryRow(query, id, status).Scan(
 		&payment.ID,
 		&payment.UserID,
 		&rentalID,
+		&fineID,
 		&payment.Amount,
 		&payment.PaymentDate,
 		&payment.PaymentMethod,
@@ -318,17 +396,38 @@ func (r *PaymentRepository) UpdateStatus(id int64, status domain.PaymentStatus)
 	)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrPaymentNotFound
-		}
 		r.logger.Error("Failed to update payment status", zap.Int64("id", id), zap.Error(err))
 		return nil, err
 	}
 
+	wasCompleted := previousStatus == domain.PaymentStatusCompleted
+	isCompleted := status == domain.PaymentStatusCompleted
+	if fineID.Valid && wasCompleted != isCompleted {
+		credit := payment.Amount
+		if wasCompleted {
+			credit = -credit
+		}
+		if err = applyFinePayment(tx, fineID.Int64, credit); err != nil {
+			if !isFinePaymentRefused(err) {
+				r.logger.Error("Failed to update fine for payment", zap.Int64("fineID", fineID.Int64), zap.Error(err))
+			}
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
 	if rentalID.Valid {
 		payment.RentalID = &rentalID.Int64
 	}
 
+	if fineID.Valid {
+		payment.FineID = &fineID.Int64
+	}
+
 	// Get user details
 	err = r.db.QueryRow("SELECT username FROM users WHERE id = $1", payment.UserID).Scan(&payment.UserUsername)
 	if err != nil {
@@ -435,12 +534,14 @@ func (r *PaymentRepository) queryPayments(query string, args ...interface{}) ([]
 	for rows.Next() {
 		var payment domain.Payment
 		var rentalID sql.NullInt64
+		var fineID sql.NullInt64
 		var bookTitle sql.NullString
 
 		err := rows.Scan(
 			&payment.ID,
 			&payment.UserID,
 			&rentalID,
+			&fineID,
 			&payment.Amount,
 			&payment.PaymentDate,
 			&payment.PaymentMethod,
@@ -460,6 +561,10 @@ func (r *PaymentRepository) queryPayments(query string, args ...interface{}) ([]
 			payment.RentalID = &rentalID.Int64
 		}
 
+		if fineID.Valid {
+			payment.FineID = &fineID.Int64
+		}
+
 		if bookTitle.Valid {
 			payment.BookTitle = bookTitle.String
 		}
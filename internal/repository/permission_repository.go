@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// PermissionRepository implements domain.PermissionRepository
+type PermissionRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewPermissionRepository creates a new PermissionRepository
+func NewPermissionRepository(conn *DBConn, logger *logger.Logger) domain.PermissionRepository {
+	return &PermissionRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// List retrieves every permission granted to every role
+func (r *PermissionRepository) List() ([]*domain.RolePermission, error) {
+	query := `
+		SELECT role, permission, created_at
+		FROM role_permissions
+		ORDER BY role, permission
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to list role permissions", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var permissions []*domain.RolePermission
+	for rows.Next() {
+		var permission domain.RolePermission
+		if err := rows.Scan(&permission.Role, &permission.Permission, &permission.CreatedAt); err != nil {
+			r.logger.Error("Failed to scan role permission row", zap.Error(err))
+			return nil, err
+		}
+
+		permissions = append(permissions, &permission)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating role permission rows", zap.Error(err))
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+// Grant gives a role a permission. Granting a permission the role already has is a no-op.
+func (r *PermissionRepository) Grant(role domain.UserRole, permission domain.Permission) error {
+	query := `
+		INSERT INTO role_permissions (role, permission)
+		VALUES ($1, $2)
+		ON CONFLICT (role, permission) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query, role, permission)
+	if err != nil {
+		r.logger.Error("Failed to grant permission", zap.String("role", string(role)), zap.String("permission", string(permission)), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Revoke takes a permission away from a role
+func (r *PermissionRepository) Revoke(role domain.UserRole, permission domain.Permission) error {
+	result, err := r.db.Exec(`DELETE FROM role_permissions WHERE role = $1 AND permission = $2`, role, permission)
+	if err != nil {
+		r.logger.Error("Failed to revoke permission", zap.String("role", string(role)), zap.String("permission", string(permission)), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrRolePermissionNotFound
+	}
+
+	return nil
+}
@@ -27,11 +27,13 @@ func NewRentalRepository(conn *DBConn, logger *logger.Logger) domain.RentalRepos
 // GetByID retrieves a rental by ID
 func (r *RentalRepository) GetByID(id int64) (*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
 		WHERE r.id = $1
 	`
 
@@ -42,15 +44,18 @@ func (r *RentalRepository) GetByID(id int64) (*domain.Rental, error) {
 		&rental.ID,
 		&rental.UserID,
 		&rental.BookID,
+		&rental.CopyID,
 		&rental.RentalDate,
 		&rental.DueDate,
 		&returnDate,
 		&rental.Status,
+		&rental.ExtensionCount,
 		&rental.CreatedAt,
 		&rental.UpdatedAt,
 		&rental.UserUsername,
 		&rental.BookTitle,
 		&rental.BookAuthor,
+		&rental.CopyBarcode,
 	)
 
 	if err != nil {
@@ -71,11 +76,13 @@ func (r *RentalRepository) GetByID(id int64) (*domain.Rental, error) {
 // List retrieves a list of rentals with pagination
 func (r *RentalRepository) List(limit, offset int32) ([]*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
 		ORDER BY r.rental_date DESC
 		LIMIT $1 OFFSET $2
 	`
@@ -86,11 +93,13 @@ func (r *RentalRepository) List(limit, offset int32) ([]*domain.Rental, error) {
 // ListByUser retrieves a list of rentals for a specific user with pagination
 func (r *RentalRepository) ListByUser(userID int64, limit, offset int32) ([]*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
 		WHERE r.user_id = $1
 		ORDER BY r.rental_date DESC
 		LIMIT $2 OFFSET $3
@@ -112,15 +121,18 @@ func (r *RentalRepository) ListByUser(userID int64, limit, offset int32) ([]*dom
 			&rental.ID,
 			&rental.UserID,
 			&rental.BookID,
+			&rental.CopyID,
 			&rental.RentalDate,
 			&rental.DueDate,
 			&returnDate,
 			&rental.Status,
+			&rental.ExtensionCount,
 			&rental.CreatedAt,
 			&rental.UpdatedAt,
 			&rental.UserUsername,
 			&rental.BookTitle,
 			&rental.BookAuthor,
+			&rental.CopyBarcode,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan rental row", zap.Error(err))
@@ -145,11 +157,13 @@ func (r *RentalRepository) ListByUser(userID int64, limit, offset int32) ([]*dom
 // ListByBook retrieves a list of rentals for a specific book with pagination
 func (r *RentalRepository) ListByBook(bookID int64, limit, offset int32) ([]*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
 		WHERE r.book_id = $1
 		ORDER BY r.rental_date DESC
 		LIMIT $2 OFFSET $3
@@ -171,15 +185,18 @@ func (r *RentalRepository) ListByBook(bookID int64, limit, offset int32) ([]*dom
 			&rental.ID,
 			&rental.UserID,
 			&rental.BookID,
+			&rental.CopyID,
 			&rental.RentalDate,
 			&rental.DueDate,
 			&returnDate,
 			&rental.Status,
+			&rental.ExtensionCount,
 			&rental.CreatedAt,
 			&rental.UpdatedAt,
 			&rental.UserUsername,
 			&rental.BookTitle,
 			&rental.BookAuthor,
+			&rental.CopyBarcode,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan rental row", zap.Error(err))
@@ -204,11 +221,13 @@ func (r *RentalRepository) ListByBook(bookID int64, limit, offset int32) ([]*dom
 // ListActive retrieves a list of active rentals with pagination
 func (r *RentalRepository) ListActive(limit, offset int32) ([]*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
 		WHERE r.status = 'active'
 		ORDER BY r.due_date ASC
 		LIMIT $1 OFFSET $2
@@ -217,15 +236,18 @@ func (r *RentalRepository) ListActive(limit, offset int32) ([]*domain.Rental, er
 	return r.queryRentals(query, limit, offset)
 }
 
-// ListOverdue retrieves a list of overdue rentals with pagination
+// ListOverdue retrieves a list of overdue rentals with pagination, including rentals that
+// passed their due date since overdue rentals were last marked
 func (r *RentalRepository) ListOverdue(limit, offset int32) ([]*domain.Rental, error) {
 	query := `
-		SELECT r.id, r.user_id, r.book_id, r.rental_date, r.due_date, r.return_date, r.status,
-			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
 		FROM rentals r
 		JOIN users u ON r.user_id = u.id
 		JOIN books b ON r.book_id = b.id
-		WHERE r.status = 'active' AND r.due_date < NOW()
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
+		WHERE r.status IN ('active', 'overdue') AND r.due_date < NOW()
 		ORDER BY r.due_date ASC
 		LIMIT $1 OFFSET $2
 	`
@@ -233,8 +255,53 @@ func (r *RentalRepository) ListOverdue(limit, offset int32) ([]*domain.Rental, e
 	return r.queryRentals(query, limit, offset)
 }
 
+// CountActiveByUserAndBook counts the copies of a book a user has not returned yet
+func (r *RentalRepository) CountActiveByUserAndBook(userID, bookID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM rentals WHERE user_id = $1 AND book_id = $2 AND status IN ('active', 'overdue')`
+
+	var count int
+	err := r.db.QueryRow(query, userID, bookID).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count active rentals by user and book", zap.Int64("userID", userID), zap.Int64("bookID", bookID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountOverdueByUser counts the rentals a user has not returned by their due date
+func (r *RentalRepository) CountOverdueByUser(userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM rentals WHERE user_id = $1 AND status IN ('active', 'overdue') AND due_date < NOW()`
+
+	var count int
+	err := r.db.QueryRow(query, userID).Scan(&count)
+	if err != nil {
+		r.logger.Error("Failed to count overdue rentals by user", zap.Int64("userID", userID), zap.Error(err))
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListLateByUser retrieves the rentals a user keeps past their due date
+func (r *RentalRepository) ListLateByUser(userID int64) ([]*domain.Rental, error) {
+	query := `
+		SELECT r.id, r.user_id, r.book_id, r.copy_id, r.rental_date, r.due_date, r.return_date, r.status, r.extension_count,
+			   r.created_at, r.updated_at, u.username as user_username, b.title as book_title, b.author as book_author,
+			   bc.barcode as copy_barcode
+		FROM rentals r
+		JOIN users u ON r.user_id = u.id
+		JOIN books b ON r.book_id = b.id
+		LEFT JOIN book_copies bc ON r.copy_id = bc.id
+		WHERE r.user_id = $1 AND r.status IN ('active', 'overdue') AND r.due_date < NOW()
+		ORDER BY r.due_date ASC
+	`
+
+	return r.queryRentals(query, userID)
+}
+
 // Create creates a new rental
-func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error) {
+func (r *RentalRepository) Create(rental *domain.Rental, limits domain.RentalLimits) (*domain.Rental, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		r.logger.Error("Failed to begin transaction", zap.Error(err))
@@ -246,33 +313,130 @@ func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error)
 		}
 	}()
 
-	// Check if book is available
-	var availableCopies int32
-	err = tx.QueryRow("SELECT available_copies FROM books WHERE id = $1", rental.BookID).Scan(&availableCopies)
+	// Lock the user so concurrent checkouts for them are counted against the limit one at a time
+	var userID int64
+	err = tx.QueryRow("SELECT id FROM users WHERE id = $1 FOR UPDATE", rental.UserID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrUserNotFound
+		return nil, err
+	}
 	if err != nil {
+		r.logger.Error("Failed to lock user", zap.Int64("userID", rental.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	var activeRentals int
+	err = tx.QueryRow(
+		"SELECT COUNT(*) FROM rentals WHERE user_id = $1 AND status IN ('active', 'overdue')",
+		rental.UserID,
+	).Scan(&activeRentals)
+	if err != nil {
+		r.logger.Error("Failed to count active rentals by user", zap.Int64("userID", rental.UserID), zap.Error(err))
+		return nil, err
+	}
+	if activeRentals >= limits.MaxActiveRentals {
+		err = domain.ErrRentalLimitReached
+		return nil, err
+	}
+
+	// Lock the household too, its members share its limit
+	if limits.HouseholdID != 0 {
+		var maxHouseholdRentals sql.NullInt64
+		err = tx.QueryRow("SELECT max_concurrent_rentals FROM households WHERE id = $1 FOR UPDATE", limits.HouseholdID).Scan(&maxHouseholdRentals)
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrBookNotFound
+			err = domain.ErrHouseholdNotFound
+			return nil, err
 		}
+		if err != nil {
+			r.logger.Error("Failed to lock household", zap.Int64("householdID", limits.HouseholdID), zap.Error(err))
+			return nil, err
+		}
+
+		if maxHouseholdRentals.Valid {
+			var householdRentals int
+			err = tx.QueryRow(`
+				SELECT COUNT(*)
+				FROM rentals
+				WHERE status IN ('active', 'overdue') AND user_id IN (
+					SELECT guardian_id FROM households WHERE id = $1
+					UNION
+					SELECT user_id FROM household_dependents WHERE household_id = $1
+				)
+			`, limits.HouseholdID).Scan(&householdRentals)
+			if err != nil {
+				r.logger.Error("Failed to count household rentals", zap.Int64("householdID", limits.HouseholdID), zap.Error(err))
+				return nil, err
+			}
+			if householdRentals >= int(maxHouseholdRentals.Int64) {
+				err = domain.ErrHouseholdLimitReached
+				return nil, err
+			}
+		}
+	}
+
+	// A copy on hold for the member is handed out before any copy on the shelf
+	var copyID int64
+	err = tx.QueryRow(`
+		UPDATE reservations
+		SET status = 'fulfilled', updated_at = NOW()
+		WHERE user_id = $1 AND book_id = $2 AND status = 'ready' AND ($3::INT IS NULL OR copy_id = $3)
+		RETURNING copy_id
+	`, rental.UserID, rental.BookID, rental.CopyID).Scan(&copyID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Otherwise take the requested copy or any copy on the shelf
+		if rental.CopyID != nil {
+			err = tx.QueryRow(
+				"SELECT id FROM book_copies WHERE id = $1 AND book_id = $2 AND status = 'available' FOR UPDATE",
+				*rental.CopyID, rental.BookID,
+			).Scan(&copyID)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, domain.ErrBookCopyNotAvailable
+			}
+		} else {
+			err = tx.QueryRow(
+				"SELECT id FROM book_copies WHERE book_id = $1 AND status = 'available' ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED",
+				rental.BookID,
+			).Scan(&copyID)
+			if errors.Is(err, sql.ErrNoRows) {
+				var exists bool
+				err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM books WHERE id = $1)", rental.BookID).Scan(&exists)
+				if err == nil {
+					err = domain.ErrBookNotAvailable
+					if !exists {
+						err = domain.ErrBookNotFound
+					}
+				}
+				return nil, err
+			}
+		}
+	}
+	if err != nil {
 		r.logger.Error("Failed to check book availability", zap.Int64("bookID", rental.BookID), zap.Error(err))
 		return nil, err
 	}
 
-	if availableCopies <= 0 {
-		return nil, domain.ErrBookNotAvailable
+	_, err = tx.Exec("UPDATE book_copies SET status = 'checked_out', updated_at = NOW() WHERE id = $1", copyID)
+	if err != nil {
+		r.logger.Error("Failed to check out book copy", zap.Int64("copyID", copyID), zap.Error(err))
+		return nil, err
 	}
 
-	// Decrement available copies
-	_, err = tx.Exec("UPDATE books SET available_copies = available_copies - 1, updated_at = NOW() WHERE id = $1", rental.BookID)
+	// The member no longer needs to wait for the book
+	_, err = tx.Exec(`
+		UPDATE reservations
+		SET status = 'fulfilled', updated_at = NOW()
+		WHERE user_id = $1 AND book_id = $2 AND status = 'waiting'
+	`, rental.UserID, rental.BookID)
 	if err != nil {
-		r.logger.Error("Failed to decrement available copies", zap.Int64("bookID", rental.BookID), zap.Error(err))
+		r.logger.Error("Failed to fulfill reservation", zap.Int64("userID", rental.UserID), zap.Error(err))
 		return nil, err
 	}
 
 	// Create rental
 	query := `
-		INSERT INTO rentals (user_id, book_id, rental_date, due_date, status)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, user_id, book_id, rental_date, due_date, return_date, status, created_at, updated_at
+		INSERT INTO rentals (user_id, book_id, copy_id, rental_date, due_date, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, book_id, copy_id, rental_date, due_date, return_date, status, extension_count, created_at, updated_at
 	`
 
 	var returnDate sql.NullTime
@@ -281,6 +445,7 @@ func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error)
 		query,
 		rental.UserID,
 		rental.BookID,
+		copyID,
 		rental.RentalDate,
 		rental.DueDate,
 		rental.Status,
@@ -288,10 +453,12 @@ func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error)
 		&rental.ID,
 		&rental.UserID,
 		&rental.BookID,
+		&rental.CopyID,
 		&rental.RentalDate,
 		&rental.DueDate,
 		&returnDate,
 		&rental.Status,
+		&rental.ExtensionCount,
 		&rental.CreatedAt,
 		&rental.UpdatedAt,
 	)
@@ -318,6 +485,14 @@ func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error)
 		return nil, err
 	}
 
+	if rental.CopyID != nil {
+		err = tx.QueryRow("SELECT barcode FROM book_copies WHERE id = $1", *rental.CopyID).Scan(&rental.CopyBarcode)
+		if err != nil {
+			r.logger.Error("Failed to get book copy details", zap.Int64("copyID", *rental.CopyID), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		r.logger.Error("Failed to commit transaction", zap.Error(err))
@@ -327,60 +502,27 @@ func (r *RentalRepository) Create(rental *domain.Rental) (*domain.Rental, error)
 	return rental, nil
 }
 
-// UpdateStatus updates the status of a rental
-func (r *RentalRepository) UpdateStatus(id int64, status domain.RentalStatus) (*domain.Rental, error) {
+// MarkOverdue marks every active rental past its due date as overdue
+func (r *RentalRepository) MarkOverdue() (int64, error) {
 	query := `
 		UPDATE rentals
-		SET status = $2, updated_at = NOW()
-		WHERE id = $1
-		RETURNING id, user_id, book_id, rental_date, due_date, return_date, status, created_at, updated_at
+		SET status = 'overdue', updated_at = NOW()
+		WHERE status = 'active' AND due_date < NOW()
 	`
 
-	var rental domain.Rental
-	var returnDate sql.NullTime
-
-	err := r.db.QueryRow(query, id, status).Scan(
-		&rental.ID,
-		&rental.UserID,
-		&rental.BookID,
-		&rental.RentalDate,
-		&rental.DueDate,
-		&returnDate,
-		&rental.Status,
-		&rental.CreatedAt,
-		&rental.UpdatedAt,
-	)
-
+	result, err := r.db.Exec(query)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrRentalNotFound
-		}
-		r.logger.Error("Failed to update rental status", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	if returnDate.Valid {
-		rental.ReturnDate = &returnDate.Time
+		r.logger.Error("Failed to mark overdue rentals", zap.Error(err))
+		return 0, err
 	}
 
-	// Get user and book details
-	err = r.db.QueryRow("SELECT username FROM users WHERE id = $1", rental.UserID).Scan(&rental.UserUsername)
-	if err != nil {
-		r.logger.Error("Failed to get user details", zap.Int64("userID", rental.UserID), zap.Error(err))
-		return nil, err
-	}
-
-	err = r.db.QueryRow("SELECT title, author FROM books WHERE id = $1", rental.BookID).Scan(&rental.BookTitle, &rental.BookAuthor)
-	if err != nil {
-		r.logger.Error("Failed to get book details", zap.Int64("bookID", rental.BookID), zap.Error(err))
-		return nil, err
-	}
-
-	return &rental, nil
+	return result.RowsAffected()
 }
 
-// Return processes the return of a rental
-func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
+// Return processes the return of a rental and assesses a fine of lateFee when it is positive.
+// The returned copy goes on hold until holdUntil for the next member waiting for the book,
+// or back on the shelf when nobody is waiting.
+func (r *RentalRepository) Return(id int64, lateFee float64, holdUntil time.Time) (*domain.Rental, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
 		r.logger.Error("Failed to begin transaction", zap.Error(err))
@@ -398,17 +540,20 @@ func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
 	var bookID int64
 
 	err = tx.QueryRow(`
-		SELECT id, user_id, book_id, rental_date, due_date, return_date, status, created_at, updated_at
+		SELECT id, user_id, book_id, copy_id, rental_date, due_date, return_date, status, extension_count, created_at, updated_at
 		FROM rentals
 		WHERE id = $1
+		FOR UPDATE
 	`, id).Scan(
 		&rental.ID,
 		&rental.UserID,
 		&bookID,
+		&rental.CopyID,
 		&rental.RentalDate,
 		&rental.DueDate,
 		&returnDate,
 		&rental.Status,
+		&rental.ExtensionCount,
 		&rental.CreatedAt,
 		&rental.UpdatedAt,
 	)
@@ -421,8 +566,9 @@ func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
 		return nil, err
 	}
 
-	if rental.Status != domain.RentalStatusActive {
-		return nil, domain.ErrRentalNotActive
+	if rental.Status != domain.RentalStatusActive && rental.Status != domain.RentalStatusOverdue {
+		err = domain.ErrRentalNotActive
+		return nil, err
 	}
 
 	// Update rental
@@ -441,11 +587,29 @@ func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
 		return nil, err
 	}
 
-	// Increment available copies
-	_, err = tx.Exec("UPDATE books SET available_copies = available_copies + 1, updated_at = NOW() WHERE id = $1", bookID)
-	if err != nil {
-		r.logger.Error("Failed to increment available copies", zap.Int64("bookID", bookID), zap.Error(err))
-		return nil, err
+	if lateFee > 0 {
+		if err = assessFine(tx, id, rental.UserID, lateFee); err != nil {
+			r.logger.Error("Failed to assess fine", zap.Int64("id", id), zap.Float64("amount", lateFee), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// Hold the copy for the next reservation or put it back on the shelf
+	if rental.CopyID != nil {
+		var held bool
+		held, err = holdForNextReservation(tx, *rental.CopyID, bookID, holdUntil)
+		if err != nil {
+			r.logger.Error("Failed to hold book copy", zap.Int64("copyID", *rental.CopyID), zap.Error(err))
+			return nil, err
+		}
+
+		if !held {
+			_, err = tx.Exec("UPDATE book_copies SET status = 'available', updated_at = NOW() WHERE id = $1 AND status = 'checked_out'", *rental.CopyID)
+			if err != nil {
+				r.logger.Error("Failed to release book copy", zap.Int64("copyID", *rental.CopyID), zap.Error(err))
+				return nil, err
+			}
+		}
 	}
 
 	// Get user and book details
@@ -461,6 +625,14 @@ func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
 		return nil, err
 	}
 
+	if rental.CopyID != nil {
+		err = tx.QueryRow("SELECT barcode FROM book_copies WHERE id = $1", *rental.CopyID).Scan(&rental.CopyBarcode)
+		if err != nil {
+			r.logger.Error("Failed to get book copy details", zap.Int64("copyID", *rental.CopyID), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	rental.BookID = bookID
 
 	err = tx.Commit()
@@ -473,38 +645,47 @@ func (r *RentalRepository) Return(id int64) (*domain.Rental, error) {
 }
 
 // Extend extends the due date of a rental
-func (r *RentalRepository) Extend(id int64, newDueDate time.Time) (*domain.Rental, error) {
+func (r *RentalRepository) Extend(id int64, newDueDate time.Time, maxRenewals int) (*domain.Rental, error) {
 	query := `
 		UPDATE rentals
-		SET due_date = $2, updated_at = NOW()
-		WHERE id = $1 AND status = 'active'
-		RETURNING id, user_id, book_id, rental_date, due_date, return_date, status, created_at, updated_at
+		SET due_date = $2, extension_count = extension_count + 1, updated_at = NOW()
+		WHERE id = $1 AND status = 'active' AND extension_count < $3
+		RETURNING id, user_id, book_id, copy_id, rental_date, due_date, return_date, status, extension_count, created_at, updated_at
 	`
 
 	var rental domain.Rental
 	var returnDate sql.NullTime
 
-	err := r.db.QueryRow(query, id, newDueDate).Scan(
+	err := r.db.QueryRow(query, id, newDueDate, maxRenewals).Scan(
 		&rental.ID,
 		&rental.UserID,
 		&rental.BookID,
+		&rental.CopyID,
 		&rental.RentalDate,
 		&rental.DueDate,
 		&returnDate,
 		&rental.Status,
+		&rental.ExtensionCount,
 		&rental.CreatedAt,
 		&rental.UpdatedAt,
 	)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			// Check if rental exists
-			exists, _ := r.rentalExists(id)
-			if !exists {
+			// Check whether the rental is missing, not active or out of renewals
+			var status domain.RentalStatus
+			err = r.db.QueryRow("SELECT status FROM rentals WHERE id = $1", id).Scan(&status)
+			if errors.Is(err, sql.ErrNoRows) {
 				return nil, domain.ErrRentalNotFound
 			}
-			// Rental exists but not active
-			return nil, domain.ErrRentalNotActive
+			if err != nil {
+				r.logger.Error("Failed to get rental status", zap.Int64("id", id), zap.Error(err))
+				return nil, err
+			}
+			if status != domain.RentalStatusActive {
+				return nil, domain.ErrRentalNotActive
+			}
+			return nil, domain.ErrExtensionLimitReached
 		}
 		r.logger.Error("Failed to extend rental", zap.Int64("id", id), zap.Error(err))
 		return nil, err
@@ -527,9 +708,32 @@ func (r *RentalRepository) Extend(id int64, newDueDate time.Time) (*domain.Renta
 		return nil, err
 	}
 
+	if rental.CopyID != nil {
+		err = r.db.QueryRow("SELECT barcode FROM book_copies WHERE id = $1", *rental.CopyID).Scan(&rental.CopyBarcode)
+		if err != nil {
+			r.logger.Error("Failed to get book copy details", zap.Int64("copyID", *rental.CopyID), zap.Error(err))
+			return nil, err
+		}
+	}
+
 	return &rental, nil
 }
 
+// GetOpenByCopy gets the active or overdue rental of a book copy
+func (r *RentalRepository) GetOpenByCopy(copyID int64) (*domain.Rental, error) {
+	var id int64
+	err := r.db.QueryRow("SELECT id FROM rentals WHERE copy_id = $1 AND status IN ('active', 'overdue')", copyID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrRentalNotFound
+		}
+		r.logger.Error("Failed to get open rental of book copy", zap.Int64("copyID", copyID), zap.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
 // Delete deletes a rental
 func (r *RentalRepository) Delete(id int64) error {
 	query := `DELETE FROM rentals WHERE id = $1`
@@ -573,15 +777,18 @@ func (r *RentalRepository) queryRentals(query string, args ...interface{}) ([]*d
 			&rental.ID,
 			&rental.UserID,
 			&rental.BookID,
+			&rental.CopyID,
 			&rental.RentalDate,
 			&rental.DueDate,
 			&returnDate,
 			&rental.Status,
+			&rental.ExtensionCount,
 			&rental.CreatedAt,
 			&rental.UpdatedAt,
 			&rental.UserUsername,
 			&rental.BookTitle,
 			&rental.BookAuthor,
+			&rental.CopyBarcode,
 		)
 		if err != nil {
 			r.logger.Error("Failed to scan rental row", zap.Error(err))
@@ -602,15 +809,3 @@ func (r *RentalRepository) queryRentals(query string, args ...interface{}) ([]*d
 
 	return rentals, nil
 }
-
-// rentalExists checks if a rental exists
-func (r *RentalRepository) rentalExists(id int64) (bool, error) {
-	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM rentals WHERE id = $1)`
-	err := r.db.QueryRow(query, id).Scan(&exists)
-	if err != nil {
-		r.logger.Error("Failed to check if rental exists", zap.Int64("id", id), zap.Error(err))
-		return false, err
-	}
-	return exists, nil
-}
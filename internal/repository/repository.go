@@ -7,12 +7,33 @@ import (
 
 // Repository is a factory for all repositories
 type Repository struct {
-	User     domain.UserRepository
-	Category domain.CategoryRepository
-	Book     domain.BookRepository
-	Rental   domain.RentalRepository
-	Payment  domain.PaymentRepository
-	Logger   *logger.Logger
+	User              domain.UserRepository
+	Category          domain.CategoryRepository
+	Book              domain.BookRepository
+	Rental            domain.RentalRepository
+	Payment           domain.PaymentRepository
+	RevokedToken      domain.RevokedTokenRepository
+	Session           domain.SessionRepository
+	PasswordReset     domain.PasswordResetRepository
+	EmailVerification domain.EmailVerificationRepository
+	LoginAttempt      domain.LoginAttemptRepository
+	MFA               domain.MFARepository
+	APIKey            domain.APIKeyRepository
+	UserIdentity      domain.UserIdentityRepository
+	OIDCAuthRequest   domain.OIDCAuthRequestRepository
+	Permission        domain.PermissionRepository
+	PasswordHistory   domain.PasswordHistoryRepository
+	MembershipPlan    domain.MembershipPlanRepository
+	DataExport        domain.DataExportRepository
+	UserImport        domain.UserImportRepository
+	Household         domain.HouseholdRepository
+	BookCopy          domain.BookCopyRepository
+	Reservation       domain.ReservationRepository
+	JobLock           domain.JobLockRepository
+	Fine              domain.FineRepository
+	LoanRule          domain.LoanRuleRepository
+	Calendar          domain.CalendarRepository
+	Logger            *logger.Logger
 }
 
 // NewRepository creates a new repository factory
@@ -20,11 +41,32 @@ func NewRepository(conn *DBConn) *Repository {
 	logger := conn.Logger.Named("repository")
 
 	return &Repository{
-		User:     NewUserRepository(conn, logger.Named("user")),
-		Category: NewCategoryRepository(conn, logger.Named("category")),
-		Book:     NewBookRepository(conn, logger.Named("book")),
-		Rental:   NewRentalRepository(conn, logger.Named("rental")),
-		Payment:  NewPaymentRepository(conn, logger.Named("payment")),
-		Logger:   logger,
+		User:              NewUserRepository(conn, logger.Named("user")),
+		Category:          NewCategoryRepository(conn, logger.Named("category")),
+		Book:              NewBookRepository(conn, logger.Named("book")),
+		Rental:            NewRentalRepository(conn, logger.Named("rental")),
+		Payment:           NewPaymentRepository(conn, logger.Named("payment")),
+		RevokedToken:      NewRevokedTokenRepository(conn, logger.Named("revoked_token")),
+		Session:           NewSessionRepository(conn, logger.Named("session")),
+		PasswordReset:     NewPasswordResetRepository(conn, logger.Named("password_reset")),
+		EmailVerification: NewEmailVerificationRepository(conn, logger.Named("email_verification")),
+		LoginAttempt:      NewLoginAttemptRepository(conn, logger.Named("login_attempt")),
+		MFA:               NewMFARepository(conn, logger.Named("mfa")),
+		APIKey:            NewAPIKeyRepository(conn, logger.Named("api_key")),
+		UserIdentity:      NewUserIdentityRepository(conn, logger.Named("user_identity")),
+		OIDCAuthRequest:   NewOIDCAuthRequestRepository(conn, logger.Named("oidc_auth_request")),
+		Permission:        NewPermissionRepository(conn, logger.Named("permission")),
+		PasswordHistory:   NewPasswordHistoryRepository(conn, logger.Named("password_history")),
+		MembershipPlan:    NewMembershipPlanRepository(conn, logger.Named("membership_plan")),
+		DataExport:        NewDataExportRepository(conn, logger.Named("data_export")),
+		UserImport:        NewUserImportRepository(conn, logger.Named("user_import")),
+		Household:         NewHouseholdRepository(conn, logger.Named("household")),
+		BookCopy:          NewBookCopyRepository(conn, logger.Named("book_copy")),
+		Reservation:       NewReservationRepository(conn, logger.Named("reservation")),
+		JobLock:           NewJobLockRepository(conn, logger.Named("job_lock")),
+		Fine:              NewFineRepository(conn, logger.Named("fine")),
+		LoanRule:          NewLoanRuleRepository(conn, logger.Named("loan_rule")),
+		Calendar:          NewCalendarRepository(conn, logger.Named("calendar")),
+		Logger:            logger,
 	}
 }
@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// ReservationRepository implements domain.ReservationRepository
+type ReservationRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewReservationRepository creates a new ReservationRepository
+func NewReservationRepository(conn *DBConn, logger *logger.Logger) domain.ReservationRepository {
+	return &ReservationRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// reservationColumns lists the reservation columns in the order expected by scanReservation.
+// The queue position counts the waiting reservations for the same book that are not newer.
+const reservationColumns = `rs.id, rs.user_id, rs.book_id, rs.copy_id, rs.status,
+		CASE WHEN rs.status = 'waiting' THEN
+			(SELECT COUNT(*) FROM reservations q WHERE q.book_id = rs.book_id AND q.status = 'waiting' AND q.id <= rs.id)
+		ELSE 0 END AS queue_position,
+		rs.hold_expires_at, rs.created_at, rs.updated_at, u.username, b.title, bc.barcode`
+
+// reservationJoins joins the tables selected by reservationColumns
+const reservationJoins = `FROM reservations rs
+		JOIN users u ON rs.user_id = u.id
+		JOIN books b ON rs.book_id = b.id
+		LEFT JOIN book_copies bc ON rs.copy_id = bc.id`
+
+// GetByID retrieves a reservation by ID
+func (r *ReservationRepository) GetByID(id int64) (*domain.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` ` + reservationJoins + ` WHERE rs.id = $1`
+
+	reservation, err := scanReservation(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrReservationNotFound
+		}
+		r.logger.Error("Failed to get reservation by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ListByUser retrieves the reservations of a user, newest first
+func (r *ReservationRepository) ListByUser(userID int64) ([]*domain.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` ` + reservationJoins + `
+		WHERE rs.user_id = $1
+		ORDER BY rs.created_at DESC, rs.id DESC
+	`
+
+	return r.queryReservations(query, userID)
+}
+
+// ListOpenByBook retrieves the queue of a book: the held reservations followed by the
+// waiting ones in the order they will be served
+func (r *ReservationRepository) ListOpenByBook(bookID int64) ([]*domain.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` ` + reservationJoins + `
+		WHERE rs.book_id = $1 AND rs.status IN ('waiting', 'ready')
+		ORDER BY rs.status = 'waiting', rs.id
+	`
+
+	return r.queryReservations(query, bookID)
+}
+
+// Create places a reservation at the end of the queue of its book
+func (r *ReservationRepository) Create(reservation *domain.Reservation) (*domain.Reservation, error) {
+	query := `
+		INSERT INTO reservations (user_id, book_id, status)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRow(query, reservation.UserID, reservation.BookID, domain.ReservationStatusWaiting).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, domain.ErrAlreadyReserved
+		}
+		r.logger.Error("Failed to create reservation", zap.Int64("userID", reservation.UserID), zap.Int64("bookID", reservation.BookID), zap.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// Cancel cancels an open reservation. A copy on hold for it passes to the next in line.
+func (r *ReservationRepository) Cancel(id int64, holdUntil time.Time) (*domain.Reservation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var bookID int64
+	var copyID sql.NullInt64
+	var status domain.ReservationStatus
+	err = tx.QueryRow("SELECT book_id, copy_id, status FROM reservations WHERE id = $1 FOR UPDATE", id).Scan(&bookID, &copyID, &status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrReservationNotFound
+		}
+		r.logger.Error("Failed to get reservation", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	if status != domain.ReservationStatusWaiting && status != domain.ReservationStatusReady {
+		err = domain.ErrReservationNotOpen
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE reservations SET status = $2, updated_at = NOW() WHERE id = $1", id, domain.ReservationStatusCancelled)
+	if err != nil {
+		r.logger.Error("Failed to cancel reservation", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	if status == domain.ReservationStatusReady && copyID.Valid {
+		if err = passHeldCopy(tx, copyID.Int64, bookID, holdUntil); err != nil {
+			r.logger.Error("Failed to pass held copy on", zap.Int64("copyID", copyID.Int64), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return r.GetByID(id)
+}
+
+// ExpireHolds expires the holds that were not picked up in time and passes their copies on
+func (r *ReservationRepository) ExpireHolds(holdUntil time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.Query(`
+		UPDATE reservations
+		SET status = 'expired', updated_at = NOW()
+		WHERE status = 'ready' AND hold_expires_at < NOW()
+		RETURNING book_id, copy_id
+	`)
+	if err != nil {
+		r.logger.Error("Failed to expire holds", zap.Error(err))
+		return 0, err
+	}
+
+	type heldCopy struct {
+		bookID int64
+		copyID sql.NullInt64
+	}
+	var released []heldCopy
+	for rows.Next() {
+		var c heldCopy
+		if err = rows.Scan(&c.bookID, &c.copyID); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan expired hold", zap.Error(err))
+			return 0, err
+		}
+		released = append(released, c)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error iterating expired holds", zap.Error(err))
+		return 0, err
+	}
+
+	for _, c := range released {
+		if !c.copyID.Valid {
+			continue
+		}
+		if err = passHeldCopy(tx, c.copyID.Int64, c.bookID, holdUntil); err != nil {
+			r.logger.Error("Failed to pass held copy on", zap.Int64("copyID", c.copyID.Int64), zap.Error(err))
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return 0, err
+	}
+
+	return int64(len(released)), nil
+}
+
+// HoldAvailableCopies puts copies on the shelf on hold for books that members are waiting
+// for, such as new copies or copies back from maintenance
+func (r *ReservationRepository) HoldAvailableCopies(holdUntil time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.Query(`
+		SELECT bc.id, bc.book_id
+		FROM book_copies bc
+		WHERE bc.status = 'available'
+		  AND EXISTS (SELECT 1 FROM reservations rs WHERE rs.book_id = bc.book_id AND rs.status = 'waiting')
+		ORDER BY bc.id
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		r.logger.Error("Failed to find copies for waiting reservations", zap.Error(err))
+		return 0, err
+	}
+
+	var copyIDs, bookIDs []int64
+	for rows.Next() {
+		var copyID, bookID int64
+		if err = rows.Scan(&copyID, &bookID); err != nil {
+			rows.Close()
+			r.logger.Error("Failed to scan book copy", zap.Error(err))
+			return 0, err
+		}
+		copyIDs = append(copyIDs, copyID)
+		bookIDs = append(bookIDs, bookID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error iterating book copies", zap.Error(err))
+		return 0, err
+	}
+
+	var held int64
+	for i, copyID := range copyIDs {
+		var ok bool
+		ok, err = holdForNextReservation(tx, copyID, bookIDs[i], holdUntil)
+		if err != nil {
+			r.logger.Error("Failed to hold book copy", zap.Int64("copyID", copyID), zap.Error(err))
+			return 0, err
+		}
+		if ok {
+			held++
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return 0, err
+	}
+
+	return held, nil
+}
+
+// holdForNextReservation puts a copy on hold for the oldest waiting reservation of its book.
+// It reports false, leaving the copy untouched, when nobody is waiting.
+func holdForNextReservation(tx *sql.Tx, copyID, bookID int64, holdUntil time.Time) (bool, error) {
+	var reservationID int64
+	err := tx.QueryRow(`
+		UPDATE reservations
+		SET status = 'ready', copy_id = $2, hold_expires_at = $3, updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM reservations
+			WHERE book_id = $1 AND status = 'waiting'
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE
+		)
+		RETURNING id
+	`, bookID, copyID, holdUntil).Scan(&reservationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec("UPDATE book_copies SET status = 'on_hold', updated_at = NOW() WHERE id = $1", copyID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// passHeldCopy passes a copy whose hold ended to the next reservation in line, or puts it
+// back on the shelf when nobody is waiting. Copies that are no longer on hold are left alone.
+func passHeldCopy(tx *sql.Tx, copyID, bookID int64, holdUntil time.Time) error {
+	var status domain.BookCopyStatus
+	err := tx.QueryRow("SELECT status FROM book_copies WHERE id = $1 FOR UPDATE", copyID).Scan(&status)
+	if err != nil {
+		return err
+	}
+	if status != domain.BookCopyStatusOnHold {
+		return nil
+	}
+
+	held, err := holdForNextReservation(tx, copyID, bookID, holdUntil)
+	if err != nil || held {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE book_copies SET status = 'available', updated_at = NOW() WHERE id = $1", copyID)
+	return err
+}
+
+// queryReservations runs a reservation query selected with reservationColumns
+func (r *ReservationRepository) queryReservations(query string, args ...interface{}) ([]*domain.Reservation, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to query reservations", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []*domain.Reservation{}
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan reservation row", zap.Error(err))
+			return nil, err
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating reservation rows", zap.Error(err))
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// scanReservation scans a reservation row selected with reservationColumns
+func scanReservation(row rowScanner) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+
+	err := row.Scan(
+		&reservation.ID,
+		&reservation.UserID,
+		&reservation.BookID,
+		&reservation.CopyID,
+		&reservation.Status,
+		&reservation.QueuePosition,
+		&reservation.HoldExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+		&reservation.UserUsername,
+		&reservation.BookTitle,
+		&reservation.CopyBarcode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
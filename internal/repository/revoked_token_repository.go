@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// RevokedTokenRepository implements domain.RevokedTokenRepository
+type RevokedTokenRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewRevokedTokenRepository creates a new RevokedTokenRepository
+func NewRevokedTokenRepository(conn *DBConn, logger *logger.Logger) domain.RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// Revoke adds a token to the revocation store
+func (r *RevokedTokenRepository) Revoke(token *domain.RevokedToken) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query, token.JTI, token.UserID, token.ExpiresAt)
+	if err != nil {
+		r.logger.Error("Failed to revoke token", zap.String("jti", token.JTI), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// IsRevoked checks if a token has been revoked
+func (r *RevokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+	err := r.db.QueryRow(query, jti).Scan(&revoked)
+	if err != nil {
+		r.logger.Error("Failed to check token revocation", zap.String("jti", jti), zap.Error(err))
+		return false, err
+	}
+	return revoked, nil
+}
+
+// DeleteExpired removes revocation entries for tokens that have expired anyway
+func (r *RevokedTokenRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM revoked_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		r.logger.Error("Failed to delete expired revoked tokens", zap.Error(err))
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// SessionRepository implements domain.SessionRepository
+type SessionRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewSessionRepository creates a new SessionRepository
+func NewSessionRepository(conn *DBConn, logger *logger.Logger) domain.SessionRepository {
+	return &SessionRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByID retrieves a session by ID
+func (r *SessionRepository) GetByID(id int64) (*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_id, user_agent, ip_address, expires_at, last_used_at,
+			   revoked_at, revoked_reason, mfa_verified, created_at, updated_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	session, err := scanSession(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrSessionNotFound
+		}
+		r.logger.Error("Failed to get session by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ListActiveByUser retrieves the sessions of a user that are neither revoked nor expired
+func (r *SessionRepository) ListActiveByUser(userID int64) ([]*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_id, user_agent, ip_address, expires_at, last_used_at,
+			   revoked_at, revoked_reason, mfa_verified, created_at, updated_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		r.logger.Error("Failed to list sessions by user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan session row", zap.Error(err))
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating session rows", zap.Error(err))
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// ListByUser retrieves every session of a user, including revoked and expired ones
+func (r *SessionRepository) ListByUser(userID int64) ([]*domain.Session, error) {
+	query := `
+		SELECT id, user_id, refresh_token_id, user_agent, ip_address, expires_at, last_used_at,
+			   revoked_at, revoked_reason, mfa_verified, created_at, updated_at
+		FROM sessions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		r.logger.Error("Failed to list all sessions by user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan session row", zap.Error(err))
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating session rows", zap.Error(err))
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// Create creates a new session
+func (r *SessionRepository) Create(session *domain.Session) (*domain.Session, error) {
+	query := `
+		INSERT INTO sessions (user_id, refresh_token_id, user_agent, ip_address, expires_at, mfa_verified)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, refresh_token_id, user_agent, ip_address, expires_at, last_used_at,
+				  revoked_at, revoked_reason, mfa_verified, created_at, updated_at
+	`
+
+	created, err := scanSession(r.db.QueryRow(
+		query,
+		session.UserID,
+		session.RefreshTokenID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+		session.MFAVerified,
+	))
+	if err != nil {
+		r.logger.Error("Failed to create session", zap.Int64("userID", session.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// Rotate replaces the current refresh token of a session. The update only
+// succeeds if oldTokenID is still the current token, so two concurrent
+// refreshes with the same token cannot both win.
+func (r *SessionRepository) Rotate(id int64, oldTokenID, newTokenID string, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET refresh_token_id = $3, expires_at = $4, last_used_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND refresh_token_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id, oldTokenID, newTokenID, expiresAt)
+	if err != nil {
+		r.logger.Error("Failed to rotate session token", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrTokenReuseDetected
+	}
+
+	return nil
+}
+
+// Revoke revokes a session
+func (r *SessionRepository) Revoke(id int64, reason string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = NOW(), revoked_reason = $2, updated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id, reason)
+	if err != nil {
+		r.logger.Error("Failed to revoke session", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RevokeAllByUser revokes every active session of a user
+func (r *SessionRepository) RevokeAllByUser(userID int64, reason string) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = NOW(), revoked_reason = $2, updated_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	_, err := r.db.Exec(query, userID, reason)
+	if err != nil {
+		r.logger.Error("Failed to revoke sessions by user", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSession scans a session row
+func scanSession(row rowScanner) (*domain.Session, error) {
+	var session domain.Session
+	var userAgent, ipAddress, revokedReason sql.NullString
+	var revokedAt sql.NullTime
+
+	err := row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenID,
+		&userAgent,
+		&ipAddress,
+		&session.ExpiresAt,
+		&session.LastUsedAt,
+		&revokedAt,
+		&revokedReason,
+		&session.MFAVerified,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session.UserAgent = userAgent.String
+	session.IPAddress = ipAddress.String
+	session.RevokedReason = revokedReason.String
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UserIdentityRepository implements domain.UserIdentityRepository
+type UserIdentityRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewUserIdentityRepository creates a new UserIdentityRepository
+func NewUserIdentityRepository(conn *DBConn, logger *logger.Logger) domain.UserIdentityRepository {
+	return &UserIdentityRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByProviderSubject retrieves the identity a provider knows by the given subject
+func (r *UserIdentityRepository) GetByProviderSubject(provider, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, last_login_at, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	var identity domain.UserIdentity
+	var email sql.NullString
+	var lastLoginAt sql.NullTime
+
+	err := r.db.QueryRow(query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&email,
+		&lastLoginAt,
+		&identity.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrIdentityNotFound
+		}
+		r.logger.Error("Failed to get user identity", zap.String("provider", provider), zap.Error(err))
+		return nil, err
+	}
+
+	identity.Email = email.String
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+
+	return &identity, nil
+}
+
+// Create links a user to an external identity
+func (r *UserIdentityRepository) Create(identity *domain.UserIdentity) (*domain.UserIdentity, error) {
+	query := `
+		INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+		RETURNING id, last_login_at, created_at
+	`
+
+	var lastLoginAt sql.NullTime
+	err := r.db.QueryRow(query, identity.UserID, identity.Provider, identity.Subject, identity.Email).Scan(
+		&identity.ID,
+		&lastLoginAt,
+		&identity.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create user identity", zap.Int64("userID", identity.UserID), zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, err
+	}
+
+	if lastLoginAt.Valid {
+		identity.LastLoginAt = &lastLoginAt.Time
+	}
+
+	return identity, nil
+}
+
+// TouchLastLogin records a login through an external identity
+func (r *UserIdentityRepository) TouchLastLogin(id int64) error {
+	_, err := r.db.Exec(`UPDATE user_identities SET last_login_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		r.logger.Error("Failed to update identity last login", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UserImportRepository implements domain.UserImportRepository
+type UserImportRepository struct {
+	db     *sql.DB
+	logger *logger.Logger
+}
+
+// NewUserImportRepository creates a new UserImportRepository
+func NewUserImportRepository(conn *DBConn, logger *logger.Logger) domain.UserImportRepository {
+	return &UserImportRepository{
+		db:     conn.DB,
+		logger: logger,
+	}
+}
+
+// GetByID retrieves a user import job by ID
+func (r *UserImportRepository) GetByID(id int64) (*domain.UserImportJob, error) {
+	query := `
+		SELECT id, status, send_invites, total_rows, created_count, invites_sent, error,
+			   created_by, created_at, completed_at
+		FROM user_import_jobs
+		WHERE id = $1
+	`
+
+	var job domain.UserImportJob
+	var jobError sql.NullString
+	var completedAt sql.NullTime
+
+	err := r.db.QueryRow(query, id).Scan(
+		&job.ID,
+		&job.Status,
+		&job.SendInvites,
+		&job.TotalRows,
+		&job.CreatedCount,
+		&job.InvitesSent,
+		&jobError,
+		&job.CreatedBy,
+		&job.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserImportNotFound
+		}
+		r.logger.Error("Failed to get user import job by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	job.Error = jobError.String
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+
+	return &job, nil
+}
+
+// Create creates a new user import job
+func (r *UserImportRepository) Create(job *domain.UserImportJob) (*domain.UserImportJob, error) {
+	query := `
+		INSERT INTO user_import_jobs (status, send_invites, total_rows, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRow(query, job.Status, job.SendInvites, job.TotalRows, job.CreatedBy).Scan(
+		&job.ID,
+		&job.CreatedAt,
+	)
+	if err != nil {
+		r.logger.Error("Failed to create user import job", zap.Error(err))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Complete marks a user import job as completed
+func (r *UserImportRepository) Complete(id int64, createdCount, invitesSent int) error {
+	query := `
+		UPDATE user_import_jobs
+		SET status = 'completed', created_count = $2, invites_sent = $3, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	return r.finish(id, query, createdCount, invitesSent)
+}
+
+// Fail marks a user import job as failed
+func (r *UserImportRepository) Fail(id int64, reason string) error {
+	query := `
+		UPDATE user_import_jobs
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`
+
+	return r.finish(id, query, reason)
+}
+
+// FailPendingBefore marks the jobs still pending that were created before the given time as failed
+func (r *UserImportRepository) FailPendingBefore(before time.Time, reason string) (int64, error) {
+	query := `
+		UPDATE user_import_jobs
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE status = 'pending' AND created_at < $1
+	`
+
+	result, err := r.db.Exec(query, before, reason)
+	if err != nil {
+		r.logger.Error("Failed to fail pending user import jobs", zap.Error(err))
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// finish runs an update that ends a job
+func (r *UserImportRepository) finish(id int64, query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, append([]interface{}{id}, args...)...)
+	if err != nil {
+		r.logger.Error("Failed to finish user import job", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserImportNotFound
+	}
+
+	return nil
+}
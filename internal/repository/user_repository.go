@@ -3,12 +3,19 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
 	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
 // UserRepository implements domain.UserRepository
 type UserRepository struct {
 	db     *sql.DB
@@ -23,26 +30,19 @@ func NewUserRepository(conn *DBConn, logger *logger.Logger) domain.UserRepositor
 	}
 }
 
+// userColumns lists the users columns in the order expected by scanUser
+const userColumns = `id, username, email, password_hash, first_name, last_name, role, email_verified_at,
+		failed_login_attempts, locked_until, membership_plan_id, status, deleted_at, anonymized_at, must_change_password, created_at, updated_at`
+
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(id int64) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE id = $1
 	`
 
-	var user domain.User
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FirstName,
-		&user.LastName,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(query, id))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -52,29 +52,18 @@ func (r *UserRepository) GetByID(id int64) (*domain.User, error) {
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // GetByUsername retrieves a user by username
 func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE username = $1
 	`
 
-	var user domain.User
-	err := r.db.QueryRow(query, username).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FirstName,
-		&user.LastName,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(query, username))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -84,29 +73,18 @@ func (r *UserRepository) GetByUsername(username string) (*domain.User, error) {
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // GetByEmail retrieves a user by email
 func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
+		SELECT ` + userColumns + `
 		FROM users
 		WHERE email = $1
 	`
 
-	var user domain.User
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FirstName,
-		&user.LastName,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(r.db.QueryRow(query, email))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -116,52 +94,117 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 		return nil, err
 	}
 
-	return &user, nil
+	return user, nil
 }
 
-// List retrieves a list of users with pagination
-func (r *UserRepository) List(limit, offset int32) ([]*domain.User, error) {
-	query := `
-		SELECT id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
-		FROM users
-		ORDER BY id
-		LIMIT $1 OFFSET $2
-	`
+// userSortColumns maps the sort fields of UserListParams to columns
+var userSortColumns = map[string][]string{
+	domain.UserSortID:        {"id"},
+	domain.UserSortUsername:  {"username"},
+	domain.UserSortEmail:     {"email"},
+	domain.UserSortName:      {"last_name", "first_name"},
+	domain.UserSortCreatedAt: {"created_at"},
+}
+
+// List searches and filters users, returning one page and the total number of matches
+func (r *UserRepository) List(params domain.UserListParams) ([]*domain.User, int64, error) {
+	var conditions []string
+	var args []interface{}
+	var argIndex int = 1
+
+	if params.Search != "" {
+		// The name expression matches idx_users_full_name_trgm
+		conditions = append(conditions, fmt.Sprintf(
+			"(username ILIKE $%[1]d OR email ILIKE $%[1]d OR (COALESCE(first_name, '') || ' ' || COALESCE(last_name, '')) ILIKE $%[1]d)", argIndex))
+		args = append(args, "%"+params.Search+"%")
+		argIndex++
+	}
+
+	if params.Role != "" {
+		conditions = append(conditions, fmt.Sprintf("role = $%d", argIndex))
+		args = append(args, params.Role)
+		argIndex++
+	}
+
+	if params.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, params.Status)
+		argIndex++
+	} else {
+		conditions = append(conditions, "status <> 'deleted'")
+	}
+
+	if !params.CreatedFrom.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, params.CreatedFrom)
+		argIndex++
+	}
 
-	rows, err := r.db.Query(query, limit, offset)
+	if !params.CreatedBefore.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argIndex))
+		args = append(args, params.CreatedBefore)
+		argIndex++
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	err := r.db.QueryRow("SELECT COUNT(*) FROM users"+where, args...).Scan(&total)
+	if err != nil {
+		r.logger.Error("Failed to count users", zap.Error(err))
+		return nil, 0, err
+	}
+
+	columns, ok := userSortColumns[params.SortBy]
+	if !ok {
+		columns = userSortColumns[domain.UserSortID]
+	}
+	direction := "ASC"
+	if params.SortDesc {
+		direction = "DESC"
+	}
+	var order []string
+	for _, column := range columns {
+		order = append(order, column+" "+direction)
+	}
+	// Keep pages stable when the sort column has duplicates
+	if columns[0] != "id" {
+		order = append(order, "id")
+	}
+
+	if params.Limit == 0 {
+		params.Limit = 10
+	}
+
+	query := "SELECT " + userColumns + " FROM users" + where +
+		" ORDER BY " + strings.Join(order, ", ") +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, params.Limit, params.Offset)
+
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		r.logger.Error("Failed to list users", zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	var users []*domain.User
 	for rows.Next() {
-		var user domain.User
-		err := rows.Scan(
-			&user.ID,
-			&user.Username,
-			&user.Email,
-			&user.PasswordHash,
-			&user.FirstName,
-			&user.LastName,
-			&user.Role,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			r.logger.Error("Failed to scan user row", zap.Error(err))
-			return nil, err
+			return nil, 0, err
 		}
-		users = append(users, &user)
+
+		users = append(users, user)
 	}
 
 	if err := rows.Err(); err != nil {
 		r.logger.Error("Error iterating user rows", zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
 
-	return users, nil
+	return users, total, nil
 }
 
 // Create creates a new user
@@ -169,10 +212,10 @@ func (r *UserRepository) Create(user *domain.User) (*domain.User, error) {
 	query := `
 		INSERT INTO users (username, email, password_hash, first_name, last_name, role)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
+		RETURNING ` + userColumns + `
 	`
 
-	err := r.db.QueryRow(
+	createdUser, err := scanUser(r.db.QueryRow(
 		query,
 		user.Username,
 		user.Email,
@@ -180,36 +223,132 @@ func (r *UserRepository) Create(user *domain.User) (*domain.User, error) {
 		user.FirstName,
 		user.LastName,
 		user.Role,
-	).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FirstName,
-		&user.LastName,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	))
 
 	if err != nil {
 		r.logger.Error("Failed to create user", zap.Error(err))
 		return nil, err
 	}
 
-	return user, nil
+	return createdUser, nil
 }
 
-// Update updates an existing user
+// CreateWithIdentity creates a user and links it to an external identity in one transaction,
+// so a failed link does not leave an account behind that cannot be signed in to
+func (r *UserRepository) CreateWithIdentity(user *domain.User, identity *domain.UserIdentity) (createdUser *domain.User, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	createdUser, err = scanUser(tx.QueryRow(`
+		INSERT INTO users (username, email, password_hash, first_name, last_name, role, email_verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+userColumns,
+		user.Username,
+		user.Email,
+		user.PasswordHash,
+		user.FirstName,
+		user.LastName,
+		user.Role,
+		user.EmailVerifiedAt,
+	))
+	if err != nil {
+		r.logger.Error("Failed to create user", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NOW())
+	`, createdUser.ID, identity.Provider, identity.Subject, identity.Email)
+	if err != nil {
+		r.logger.Error("Failed to create user identity", zap.Int64("userID", createdUser.ID), zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return createdUser, nil
+}
+
+// CreateBatch creates several users in one transaction, so either all of them or none are created
+func (r *UserRepository) CreateBatch(users []*domain.User) ([]*domain.User, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO users (username, email, password_hash, first_name, last_name, role, must_change_password)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + userColumns + `
+	`)
+	if err != nil {
+		r.logger.Error("Failed to prepare user insert", zap.Error(err))
+		return nil, err
+	}
+	defer stmt.Close()
+
+	createdUsers := make([]*domain.User, 0, len(users))
+	for _, user := range users {
+		var createdUser *domain.User
+		createdUser, err = scanUser(stmt.QueryRow(
+			user.Username,
+			user.Email,
+			user.PasswordHash,
+			user.FirstName,
+			user.LastName,
+			user.Role,
+			user.MustChangePassword,
+		))
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+				err = domain.ErrUserAlreadyExists
+				return nil, err
+			}
+			r.logger.Error("Failed to create user", zap.String("username", user.Username), zap.Error(err))
+			return nil, err
+		}
+		createdUsers = append(createdUsers, createdUser)
+	}
+
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return nil, err
+	}
+
+	return createdUsers, nil
+}
+
+// Update updates an existing user. A new email address has to be verified again.
 func (r *UserRepository) Update(user *domain.User) (*domain.User, error) {
 	query := `
 		UPDATE users
-		SET username = $2, email = $3, first_name = $4, last_name = $5, role = $6, updated_at = NOW()
+		SET username = $2, email = $3, first_name = $4, last_name = $5, role = $6,
+			email_verified_at = CASE WHEN email = $3 THEN email_verified_at END,
+			updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, username, email, password_hash, first_name, last_name, role, created_at, updated_at
+		RETURNING ` + userColumns + `
 	`
 
-	err := r.db.QueryRow(
+	updatedUser, err := scanUser(r.db.QueryRow(
 		query,
 		user.ID,
 		user.Username,
@@ -217,17 +356,7 @@ func (r *UserRepository) Update(user *domain.User) (*domain.User, error) {
 		user.FirstName,
 		user.LastName,
 		user.Role,
-	).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.FirstName,
-		&user.LastName,
-		&user.Role,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -237,14 +366,14 @@ func (r *UserRepository) Update(user *domain.User) (*domain.User, error) {
 		return nil, err
 	}
 
-	return user, nil
+	return updatedUser, nil
 }
 
 // UpdatePassword updates a user's password
 func (r *UserRepository) UpdatePassword(id int64, passwordHash string) error {
 	query := `
 		UPDATE users
-		SET password_hash = $2, updated_at = NOW()
+		SET password_hash = $2, must_change_password = FALSE, updated_at = NOW()
 		WHERE id = $1
 	`
 
@@ -267,9 +396,160 @@ func (r *UserRepository) UpdatePassword(id int64, passwordHash string) error {
 	return nil
 }
 
-// Delete deletes a user
+// MarkEmailVerified records that a user has verified their email address
+func (r *UserRepository) MarkEmailVerified(id int64) error {
+	query := `
+		UPDATE users
+		SET email_verified_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND email_verified_at IS NULL
+	`
+
+	_, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to mark email verified", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// IncrementFailedLogins records a failed login attempt and returns the number of consecutive failures
+func (r *UserRepository) IncrementFailedLogins(id int64) (int, error) {
+	query := `
+		UPDATE users
+		SET failed_login_attempts = failed_login_attempts + 1
+		WHERE id = $1
+		RETURNING failed_login_attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRow(query, id).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrUserNotFound
+		}
+		r.logger.Error("Failed to increment failed logins", zap.Int64("id", id), zap.Error(err))
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+// LockUntil blocks logins for a user until the given time
+func (r *UserRepository) LockUntil(id int64, until time.Time) error {
+	query := `
+		UPDATE users
+		SET locked_until = $2
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id, until)
+	if err != nil {
+		r.logger.Error("Failed to lock user", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ResetFailedLogins clears the failed login counter and any lock on a user
+func (r *UserRepository) ResetFailedLogins(id int64) error {
+	query := `
+		UPDATE users
+		SET failed_login_attempts = 0, locked_until = NULL
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		r.logger.Error("Failed to reset failed logins", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateMembershipPlan assigns a membership plan to a user, nil assigns the default plan
+func (r *UserRepository) UpdateMembershipPlan(id int64, planID *int64) error {
+	query := `
+		UPDATE users
+		SET membership_plan_id = $2, updated_at = NOW()
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id, planID)
+	if err != nil {
+		r.logger.Error("Failed to update user membership plan", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus suspends or reactivates a user that has not been deleted
+func (r *UserRepository) UpdateStatus(id int64, status domain.UserStatus) error {
+	query := `
+		UPDATE users
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status <> 'deleted'
+	`
+
+	result, err := r.db.Exec(query, id, status)
+	if err != nil {
+		r.logger.Error("Failed to update user status", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected", zap.Error(err))
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Delete soft-deletes a user. The row is kept so rentals and payments still reference it.
 func (r *UserRepository) Delete(id int64) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `
+		UPDATE users
+		SET status = 'deleted', deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status <> 'deleted'
+	`
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -289,3 +569,161 @@ func (r *UserRepository) Delete(id int64) error {
 
 	return nil
 }
+
+// ListDeletedBefore retrieves the IDs of users deleted before the given time that still hold personal data
+func (r *UserRepository) ListDeletedBefore(before time.Time, limit int) ([]int64, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE status = 'deleted' AND anonymized_at IS NULL AND deleted_at < $1
+		ORDER BY deleted_at
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, before, limit)
+	if err != nil {
+		r.logger.Error("Failed to list deleted users", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			r.logger.Error("Failed to scan user ID", zap.Error(err))
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error iterating user rows", zap.Error(err))
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Anonymize scrubs the personal data of a deleted user and everything tied to their logins.
+// Rentals and payments keep pointing at the anonymized row.
+func (r *UserRepository) Anonymize(id int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var status domain.UserStatus
+	err = tx.QueryRow("SELECT status FROM users WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		r.logger.Error("Failed to get user status", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	if status != domain.UserStatusDeleted {
+		err = domain.ErrUserNotDeleted
+		return err
+	}
+
+	// The placeholder username and email keep the unique constraints satisfied
+	// and the password hash can never match a password
+	_, err = tx.Exec(`
+		UPDATE users
+		SET username = 'deleted-user-' || id, email = 'deleted-user-' || id || '@anonymized.invalid',
+			password_hash = '', first_name = '', last_name = '', email_verified_at = NULL,
+			failed_login_attempts = 0, locked_until = NULL, anonymized_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		r.logger.Error("Failed to anonymize user", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	// Remove login history, credentials, linked identities and household links, and leave
+	// reservation queues. Held copies pass on when their hold expires.
+	for _, query := range []string{
+		"DELETE FROM sessions WHERE user_id = $1",
+		"DELETE FROM login_attempts WHERE user_id = $1",
+		"DELETE FROM password_reset_tokens WHERE user_id = $1",
+		"DELETE FROM email_verification_tokens WHERE user_id = $1",
+		"DELETE FROM password_history WHERE user_id = $1",
+		"DELETE FROM mfa_recovery_codes WHERE user_id = $1",
+		"DELETE FROM user_mfa WHERE user_id = $1",
+		"DELETE FROM user_identities WHERE user_id = $1",
+		"DELETE FROM api_keys WHERE user_id = $1",
+		"DELETE FROM data_exports WHERE user_id = $1",
+		"DELETE FROM household_dependents WHERE user_id = $1",
+		"DELETE FROM households WHERE guardian_id = $1",
+		"UPDATE reservations SET status = 'cancelled', updated_at = NOW() WHERE user_id = $1 AND status = 'waiting'",
+	} {
+		if _, err = tx.Exec(query, id); err != nil {
+			r.logger.Error("Failed to remove personal data", zap.Int64("id", id), zap.String("query", query), zap.Error(err))
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// scanUser scans a user row selected with userColumns
+func scanUser(row rowScanner) (*domain.User, error) {
+	var user domain.User
+	var emailVerifiedAt, lockedUntil, deletedAt, anonymizedAt sql.NullTime
+	var membershipPlanID sql.NullInt64
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.FirstName,
+		&user.LastName,
+		&user.Role,
+		&emailVerifiedAt,
+		&user.FailedLoginAttempts,
+		&lockedUntil,
+		&membershipPlanID,
+		&user.Status,
+		&deletedAt,
+		&anonymizedAt,
+		&user.MustChangePassword,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &emailVerifiedAt.Time
+	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
+	if membershipPlanID.Valid {
+		user.MembershipPlanID = &membershipPlanID.Int64
+	}
+	if deletedAt.Valid {
+		user.DeletedAt = &deletedAt.Time
+	}
+	if anonymizedAt.Valid {
+		user.AnonymizedAt = &anonymizedAt.Time
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// apiKeyPrefix marks raw keys so they are easy to recognise, e.g. in secret scanners
+	apiKeyPrefix = "sbr_"
+	// apiKeyDisplayLength is the number of leading characters stored in clear to identify a key
+	apiKeyDisplayLength = 12
+)
+
+// APIKeyServiceImpl implements domain.APIKeyService
+type APIKeyServiceImpl struct {
+	repo                domain.APIKeyRepository
+	userRepo            domain.UserRepository
+	mfaRequiredForStaff bool
+	logger              *logger.Logger
+}
+
+// NewAPIKeyService creates a new APIKeyService
+func NewAPIKeyService(repo domain.APIKeyRepository, userRepo domain.UserRepository, mfaRequiredForStaff bool, logger *logger.Logger) domain.APIKeyService {
+	return &APIKeyServiceImpl{
+		repo:                repo,
+		userRepo:            userRepo,
+		mfaRequiredForStaff: mfaRequiredForStaff,
+		logger:              logger,
+	}
+}
+
+// GetByID retrieves an API key by ID
+func (s *APIKeyServiceImpl) GetByID(id int64) (*domain.APIKey, error) {
+	key, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get API key by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return key, nil
+}
+
+// List retrieves a list of API keys with pagination
+func (s *APIKeyServiceImpl) List(limit, offset int32) ([]*domain.APIKey, error) {
+	keys, err := s.repo.List(limit, offset)
+	if err != nil {
+		s.logger.Error("Failed to list API keys", zap.Error(err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Create issues a new API key and returns it together with the raw key,
+// which is not stored and cannot be retrieved again. Keys skip two-factor authentication,
+// so when staff must use it, keys of staff accounts are limited to read scopes.
+func (s *APIKeyServiceImpl) Create(key *domain.APIKey) (*domain.APIKey, string, error) {
+	if len(key.Scopes) == 0 {
+		return nil, "", domain.NewInvalidInputError("at least one scope is required")
+	}
+	for _, scope := range key.Scopes {
+		if !domain.IsValidAPIKeyScope(scope) {
+			return nil, "", domain.NewInvalidInputError("invalid scope: " + scope)
+		}
+	}
+
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(time.Now()) {
+		return nil, "", domain.NewInvalidInputError("expiry must be in the future")
+	}
+
+	// The key acts as its owner, so the owner must exist
+	owner, err := s.userRepo.GetByID(key.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, "", domain.NewInvalidInputError("user does not exist")
+		}
+		s.logger.Error("Failed to get API key owner", zap.Int64("userID", key.UserID), zap.Error(err))
+		return nil, "", err
+	}
+
+	if s.mfaRequiredForStaff && (owner.Role == domain.RoleAdmin || owner.Role == domain.RoleLibrarian) {
+		for _, scope := range key.Scopes {
+			if strings.HasSuffix(scope, ":"+domain.ScopeWrite) {
+				return nil, "", domain.NewInvalidInputError("keys of staff accounts are limited to read scopes while two-factor authentication is required: " + scope)
+			}
+		}
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate API key", zap.Error(err))
+		return nil, "", err
+	}
+	rawKey := apiKeyPrefix + token
+
+	key.Prefix = rawKey[:apiKeyDisplayLength]
+	key.KeyHash = auth.HashOpaqueToken(rawKey)
+
+	createdKey, err := s.repo.Create(key)
+	if err != nil {
+		s.logger.Error("Failed to create API key", zap.Error(err))
+		return nil, "", err
+	}
+
+	s.logger.Info("API key created",
+		zap.String("event", "api_key_created"),
+		zap.Int64("apiKeyID", createdKey.ID),
+		zap.Int64("userID", createdKey.UserID),
+		zap.Int64("createdBy", createdKey.CreatedBy),
+		zap.Strings("scopes", createdKey.Scopes))
+	return createdKey, rawKey, nil
+}
+
+// Revoke revokes an API key
+func (s *APIKeyServiceImpl) Revoke(id int64) error {
+	err := s.repo.Revoke(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAPIKeyNotFound) {
+			s.logger.Error("Failed to revoke API key", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+
+	s.logger.Info("API key revoked", zap.String("event", "api_key_revoked"), zap.Int64("apiKeyID", id))
+	return nil
+}
+
+// Authenticate resolves a raw API key to an active key and records its use
+func (s *APIKeyServiceImpl) Authenticate(rawKey string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(auth.HashOpaqueToken(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if !key.IsActive() {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	// Failing to record the timestamp must not fail the request
+	if err := s.repo.TouchLastUsed(key.ID); err != nil {
+		s.logger.Error("Failed to record API key use", zap.Int64("apiKeyID", key.ID), zap.Error(err))
+	}
+
+	return key, nil
+}
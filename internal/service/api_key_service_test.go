@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestAPIKeyServiceCreate(t *testing.T) {
+	tests := []struct {
+		name                string
+		ownerRole           domain.UserRole
+		scopes              []string
+		mfaRequiredForStaff bool
+		wantErr             error
+	}{
+		{"member key with write scope", domain.RoleMember, []string{"rentals:write"}, true, nil},
+		{"staff key with read scope", domain.RoleLibrarian, []string{"books:read", "reports:read"}, true, nil},
+		{"staff key with write scope", domain.RoleLibrarian, []string{"books:read", "books:write"}, true, domain.ErrInvalidInput},
+		{"staff key with write scope without mandatory MFA", domain.RoleAdmin, []string{"books:write"}, false, nil},
+		{"unknown scope", domain.RoleMember, []string{"everything:all"}, false, domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().GetByID(int64(2)).Return(&domain.User{ID: 2, Role: tt.ownerRole}, nil).AnyTimes()
+
+			repo := mocks.NewMockAPIKeyRepository(ctrl)
+			if tt.wantErr == nil {
+				repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(key *domain.APIKey) (*domain.APIKey, error) {
+					key.ID = 3
+					return key, nil
+				})
+			}
+
+			s := NewAPIKeyService(repo, userRepo, tt.mfaRequiredForStaff, &logger.Logger{Logger: zap.NewNop()})
+
+			key, rawKey, err := s.Create(&domain.APIKey{Name: "Integration", UserID: 2, Scopes: tt.scopes, CreatedBy: 1})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (key.ID != 3 || !strings.HasPrefix(rawKey, apiKeyPrefix)) {
+				t.Errorf("Create() = key %d, raw key %q", key.ID, rawKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Impersonate issues a short-lived access token that acts as the target user on behalf of the actor,
+// so support staff can see exactly what a user sees. Admins and inactive accounts cannot be impersonated and the token
+// is bound to the actor's session, which has to be a regular login rather than an API key.
+func (s *AuthServiceImpl) Impersonate(actorID, actorSessionID, targetID int64) (*ImpersonationResult, error) {
+	if actorSessionID == 0 {
+		return nil, domain.NewForbiddenError("impersonation requires a login session")
+	}
+	if actorID == targetID {
+		return nil, domain.NewInvalidInputError("cannot impersonate yourself")
+	}
+
+	session, err := s.sessionRepo.GetByID(actorSessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if session.UserID != actorID || session.RevokedAt != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	actor, err := s.userRepo.GetByID(actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.userRepo.GetByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Role == domain.RoleAdmin {
+		s.logger.Warn("Refused to impersonate admin",
+			zap.String("event", "impersonation_denied"), zap.Int64("actorID", actorID), zap.Int64("userID", targetID))
+		return nil, domain.ErrImpersonationForbidden
+	}
+	if !target.IsActive() {
+		s.logger.Warn("Refused to impersonate inactive user",
+			zap.String("event", "impersonation_denied"), zap.Int64("actorID", actorID), zap.Int64("userID", targetID))
+		return nil, domain.ErrImpersonationForbidden
+	}
+
+	accessToken, expiresAt, err := s.jwtService.GenerateImpersonationToken(target, actor, actorSessionID, session.MFAVerified)
+	if err != nil {
+		s.logger.Error("Failed to generate impersonation token", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Warn("Impersonation started",
+		zap.String("event", "impersonation_started"),
+		zap.Int64("actorID", actorID),
+		zap.Int64("userID", targetID),
+		zap.Time("expiresAt", expiresAt))
+
+	return &ImpersonationResult{AccessToken: accessToken, ExpiresAt: expiresAt, User: target}, nil
+}
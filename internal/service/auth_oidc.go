@@ -0,0 +1,290 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/oidc"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxUsernameLength matches the users.username column
+	maxUsernameLength = 50
+	// usernameAttempts is how many suffixed usernames are tried when provisioning a taken name
+	usernameAttempts = 5
+)
+
+// OIDCProviders returns the names of the configured identity providers
+func (s *AuthServiceImpl) OIDCProviders() []string {
+	names := make([]string, 0, len(s.oidcProviders))
+	for name := range s.oidcProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OIDCAuthorize starts an OpenID Connect login and returns the provider URL to send the browser to,
+// along with the state parameter to bind to the browser. The PKCE code verifier and nonce stay on
+// the server, keyed by the hash of the state parameter.
+func (s *AuthServiceImpl) OIDCAuthorize(providerName string) (string, string, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return "", "", domain.ErrOIDCProviderNotFound
+	}
+
+	state, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate OIDC state", zap.Error(err))
+		return "", "", err
+	}
+	nonce, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate OIDC nonce", zap.Error(err))
+		return "", "", err
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		s.logger.Error("Failed to generate PKCE code verifier", zap.Error(err))
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		s.logger.Error("Failed to build OIDC authorization URL", zap.String("provider", providerName), zap.Error(err))
+		return "", "", err
+	}
+
+	_, err = s.oidcRequestRepo.Create(&domain.OIDCAuthRequest{
+		StateHash:    auth.HashOpaqueToken(state),
+		Provider:     providerName,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(s.oidcConfig.StateExpiration),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, state, nil
+}
+
+// OIDCCallback completes an OpenID Connect login. The user is found through a linked identity,
+// linked by verified email address or provisioned on first sign-in.
+func (s *AuthServiceImpl) OIDCCallback(providerName, code, state, userAgent, ipAddress string) (*LoginResult, error) {
+	provider, ok := s.oidcProviders[providerName]
+	if !ok {
+		return nil, domain.ErrOIDCProviderNotFound
+	}
+
+	request, err := s.oidcRequestRepo.Consume(auth.HashOpaqueToken(state))
+	if err != nil {
+		return nil, err
+	}
+	if request.Provider != providerName {
+		return nil, domain.ErrInvalidOIDCState
+	}
+
+	token, err := provider.Exchange(code, request.CodeVerifier)
+	if err != nil {
+		s.logger.Warn("OIDC code exchange failed",
+			zap.String("event", "oidc_login_failed"), zap.String("provider", providerName), zap.Error(err))
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	claims, err := provider.VerifyIDToken(token.IDToken, request.Nonce)
+	if err != nil {
+		s.logger.Warn("OIDC ID token rejected",
+			zap.String("event", "oidc_login_failed"), zap.String("provider", providerName), zap.Error(err))
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	user, err := s.resolveOIDCUser(providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsLocked() {
+		return nil, s.lockedError(user.FailedLoginAttempts)
+	}
+	s.recordLoginAttempt(&user.ID, user.Username, ipAddress, true)
+
+	return s.completeLogin(user, userAgent, ipAddress)
+}
+
+// PurgeOIDCAuthRequests removes OpenID Connect logins that were started but never completed
+func (s *AuthServiceImpl) PurgeOIDCAuthRequests() (int64, error) {
+	deleted, err := s.oidcRequestRepo.DeleteExpired()
+	if err != nil {
+		s.logger.Error("Failed to purge OIDC auth requests", zap.Error(err))
+		return 0, err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Purged expired OIDC auth requests", zap.Int64("count", deleted))
+	}
+	return deleted, nil
+}
+
+// resolveOIDCUser returns the local user for a verified external identity
+func (s *AuthServiceImpl) resolveOIDCUser(providerName string, claims *oidc.IDTokenClaims) (*domain.User, error) {
+	identity, err := s.identityRepo.GetByProviderSubject(providerName, claims.Subject)
+	if err == nil {
+		if err := s.identityRepo.TouchLastLogin(identity.ID); err != nil {
+			return nil, err
+		}
+		return s.userRepo.GetByID(identity.UserID)
+	}
+	if !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if claims.Email == "" {
+		s.logger.Warn("OIDC ID token has no email claim",
+			zap.String("event", "oidc_login_failed"), zap.String("provider", providerName))
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	user, err := s.userRepo.GetByEmail(claims.Email)
+	switch {
+	case err == nil:
+		// Only link when both sides have proven ownership of the address,
+		// otherwise an unverified email at either end would allow an account takeover
+		if !claims.EmailVerified || !user.IsEmailVerified() {
+			s.logger.Warn("Refused to link external identity to existing account",
+				zap.String("event", "oidc_link_refused"), zap.String("provider", providerName), zap.Int64("userID", user.ID))
+			return nil, domain.ErrOIDCAccountConflict
+		}
+	case errors.Is(err, domain.ErrUserNotFound):
+		return s.provisionOIDCUser(providerName, claims)
+	default:
+		s.logger.Error("Failed to get user by email", zap.String("email", claims.Email), zap.Error(err))
+		return nil, err
+	}
+
+	_, err = s.identityRepo.Create(&domain.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("External identity linked",
+		zap.String("event", "oidc_identity_linked"), zap.String("provider", providerName), zap.Int64("userID", user.ID))
+	return user, nil
+}
+
+// provisionOIDCUser creates a member account linked to the external identity for a first sign-in
+// through an identity provider. The account gets an unusable random password; a local password
+// can be set through a password reset.
+func (s *AuthServiceImpl) provisionOIDCUser(providerName string, claims *oidc.IDTokenClaims) (*domain.User, error) {
+	username, err := s.availableUsername(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	randomPassword, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate password", zap.Error(err))
+		return nil, err
+	}
+	hashedPassword, err := hashPassword(randomPassword)
+	if err != nil {
+		s.logger.Error("Failed to hash password", zap.Error(err))
+		return nil, err
+	}
+
+	newUser := &domain.User{
+		Username:     username,
+		Email:        claims.Email,
+		PasswordHash: hashedPassword,
+		FirstName:    claims.GivenName,
+		LastName:     claims.FamilyName,
+		Role:         domain.RoleMember,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		newUser.EmailVerifiedAt = &now
+	}
+
+	user, err := s.userRepo.CreateWithIdentity(newUser, &domain.UserIdentity{
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create user", zap.Error(err))
+		return nil, err
+	}
+
+	if !user.IsEmailVerified() {
+		if err := s.verifier.Send(user); err != nil {
+			s.logger.Error("Failed to send verification email", zap.Int64("userID", user.ID), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("User provisioned from identity provider",
+		zap.String("event", "oidc_user_provisioned"), zap.String("provider", providerName), zap.Int64("userID", user.ID))
+	return user, nil
+}
+
+// availableUsername derives an unused username from the ID token claims
+func (s *AuthServiceImpl) availableUsername(claims *oidc.IDTokenClaims) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if len(base) < 3 {
+		base = sanitizeUsername(strings.SplitN(claims.Email, "@", 2)[0])
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < usernameAttempts; i++ {
+		_, err := s.userRepo.GetByUsername(candidate)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			s.logger.Error("Error checking username existence", zap.String("username", candidate), zap.Error(err))
+			return "", err
+		}
+
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s-%04d", truncate(base, maxUsernameLength-5), suffix.Int64())
+	}
+
+	return "", domain.ErrUserAlreadyExists
+}
+
+// sanitizeUsername keeps the characters of a name that are safe in a username
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	return truncate(b.String(), maxUsernameLength)
+}
+
+// truncate shortens an ASCII string to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -1,27 +1,74 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/base32"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
 	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/config"
 	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/SimpleBookRental/backend/pkg/mailer"
+	"github.com/SimpleBookRental/backend/pkg/oidc"
+	"github.com/SimpleBookRental/backend/pkg/totp"
 	"go.uber.org/zap"
 )
 
+const (
+	// mfaSkew is the number of 30 second steps of clock drift tolerated for TOTP codes
+	mfaSkew = 1
+	// recoveryCodeCount is the number of recovery codes issued when two-factor authentication is enabled
+	recoveryCodeCount = 10
+	// dummyPasswordHash is checked against when a username is unknown, so that logins take as long
+	// whether or not the account exists. It uses the same cost as hashPassword.
+	dummyPasswordHash = "$2a$10$KZbXa2TyuTgLd4B9GI0xy.wtwwrezC9jMydzeCePnVKa8eBKEbzCm"
+)
+
 // AuthServiceImpl implements AuthService
 type AuthServiceImpl struct {
-	userRepo   domain.UserRepository
-	jwtService *auth.JWTService
-	logger     *logger.Logger
+	userRepo          domain.UserRepository
+	revokedTokenRepo  domain.RevokedTokenRepository
+	sessionRepo       domain.SessionRepository
+	passwordResetRepo domain.PasswordResetRepository
+	verificationRepo  domain.EmailVerificationRepository
+	loginAttemptRepo  domain.LoginAttemptRepository
+	mfaRepo           domain.MFARepository
+	identityRepo      domain.UserIdentityRepository
+	oidcRequestRepo   domain.OIDCAuthRequestRepository
+	passwordPolicy    domain.PasswordPolicy
+	verifier          domain.EmailVerifier
+	jwtService        *auth.JWTService
+	mailer            mailer.Mailer
+	oidcProviders     map[string]*oidc.Provider
+	config            config.AuthConfig
+	oidcConfig        config.OIDCConfig
+	logger            *logger.Logger
 }
 
 // NewAuthService creates a new AuthService
-func NewAuthService(userRepo domain.UserRepository, jwtService *auth.JWTService, logger *logger.Logger) AuthService {
+func NewAuthService(userRepo domain.UserRepository, revokedTokenRepo domain.RevokedTokenRepository, sessionRepo domain.SessionRepository, passwordResetRepo domain.PasswordResetRepository, verificationRepo domain.EmailVerificationRepository, loginAttemptRepo domain.LoginAttemptRepository, mfaRepo domain.MFARepository, identityRepo domain.UserIdentityRepository, oidcRequestRepo domain.OIDCAuthRequestRepository, passwordPolicy domain.PasswordPolicy, verifier domain.EmailVerifier, jwtService *auth.JWTService, mailer mailer.Mailer, config config.AuthConfig, oidcConfig config.OIDCConfig, logger *logger.Logger) AuthService {
 	return &AuthServiceImpl{
-		userRepo:   userRepo,
-		jwtService: jwtService,
-		logger:     logger,
+		userRepo:          userRepo,
+		revokedTokenRepo:  revokedTokenRepo,
+		sessionRepo:       sessionRepo,
+		passwordResetRepo: passwordResetRepo,
+		verificationRepo:  verificationRepo,
+		loginAttemptRepo:  loginAttemptRepo,
+		mfaRepo:           mfaRepo,
+		identityRepo:      identityRepo,
+		oidcRequestRepo:   oidcRequestRepo,
+		passwordPolicy:    passwordPolicy,
+		verifier:          verifier,
+		jwtService:        jwtService,
+		mailer:            mailer,
+		oidcProviders:     oidc.NewProviders(oidcConfig),
+		config:            config,
+		oidcConfig:        oidcConfig,
+		logger:            logger,
 	}
 }
 
@@ -47,6 +94,10 @@ func (s *AuthServiceImpl) Register(user *domain.User, password string) (*domain.
 		return nil, err
 	}
 
+	if err := s.passwordPolicy.Validate(password, nil); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := hashPassword(password)
 	if err != nil {
@@ -67,43 +118,302 @@ func (s *AuthServiceImpl) Register(user *domain.User, password string) (*domain.
 		return nil, err
 	}
 
+	if err := s.passwordPolicy.Remember(createdUser.ID, hashedPassword); err != nil {
+		s.logger.Error("Failed to record password history", zap.Int64("userID", createdUser.ID), zap.Error(err))
+	}
+
+	// The account exists either way; the user can still verify once mail is working again
+	if err := s.verifier.Send(createdUser); err != nil {
+		s.logger.Error("Failed to send verification email", zap.Int64("userID", createdUser.ID), zap.Error(err))
+	}
+
 	return createdUser, nil
 }
 
-// Login authenticates a user and returns access and refresh tokens
-func (s *AuthServiceImpl) Login(username, password string) (string, string, error) {
+// VerifyEmail marks the email address of the user owning the verification token as verified
+func (s *AuthServiceImpl) VerifyEmail(token string) error {
+	verificationToken, err := s.verificationRepo.GetByTokenHash(auth.HashOpaqueToken(token))
+	if err != nil {
+		return err
+	}
+
+	if verificationToken.UsedAt != nil || verificationToken.ExpiresAt.Before(time.Now()) {
+		return domain.ErrInvalidVerificationToken
+	}
+
+	if err := s.verificationRepo.MarkUsed(verificationToken.ID); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.MarkEmailVerified(verificationToken.UserID); err != nil {
+		s.logger.Error("Failed to mark email verified", zap.Int64("userID", verificationToken.UserID), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Email verified", zap.Int64("userID", verificationToken.UserID))
+	return nil
+}
+
+// ResendVerificationEmail sends a user a new link to verify their email address
+func (s *AuthServiceImpl) ResendVerificationEmail(userID int64) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	if err := s.verifier.Send(user); err != nil {
+		s.logger.Error("Failed to send verification email", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Verification email resent", zap.Int64("userID", userID))
+	return nil
+}
+
+// Login authenticates a user, starts a new session and returns access and refresh tokens.
+// Users with two-factor authentication only get an MFA token to complete the login with VerifyMFA.
+// Repeated failures slow down further attempts and eventually lock the account for a while.
+func (s *AuthServiceImpl) Login(username, password, userAgent, ipAddress string) (*LoginResult, error) {
+	// Throttle addresses that keep failing, whichever accounts they target
+	failures, err := s.loginAttemptRepo.CountFailuresByIP(ipAddress, time.Now().Add(-s.config.LoginIPWindow))
+	if err != nil {
+		s.logger.Error("Failed to count failed logins", zap.String("ip", ipAddress), zap.Error(err))
+		return nil, err
+	}
+	if failures >= s.config.MaxFailedLoginsPerIP {
+		s.logger.Warn("Login throttled for IP address",
+			zap.String("event", "login_ip_throttled"), zap.String("ip", ipAddress), zap.Int("failures", failures))
+		return nil, domain.ErrTooManyLoginAttempts
+	}
+
 	// Get user by username
 	user, err := s.userRepo.GetByUsername(username)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			return "", "", domain.ErrInvalidCredentials
+			return nil, s.failUnknownUserLogin(username, password, ipAddress)
 		}
 		s.logger.Error("Failed to get user by username", zap.String("username", username), zap.Error(err))
-		return "", "", err
+		return nil, err
+	}
+
+	// Refuse without checking the password while the account is locked or backing off
+	if user.IsLocked() {
+		return nil, s.lockedError(user.FailedLoginAttempts)
 	}
 
 	// Verify password
 	if !verifyPassword(password, user.PasswordHash) {
-		return "", "", domain.ErrInvalidCredentials
+		s.recordLoginAttempt(&user.ID, username, ipAddress, false)
+		if err := s.registerFailedLogin(user, ipAddress); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if user.FailedLoginAttempts > 0 {
+		if err := s.userRepo.ResetFailedLogins(user.ID); err != nil {
+			s.logger.Error("Failed to reset failed logins", zap.Int64("userID", user.ID), zap.Error(err))
+			return nil, err
+		}
+	}
+	s.recordLoginAttempt(&user.ID, username, ipAddress, true)
+
+	return s.completeLogin(user, userAgent, ipAddress)
+}
+
+// completeLogin finishes a login whose first factor succeeded. Accounts with two-factor
+// authentication only get an MFA token; everyone else gets a new session.
+func (s *AuthServiceImpl) completeLogin(user *domain.User, userAgent, ipAddress string) (*LoginResult, error) {
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.mfaRepo.GetByUserID(user.ID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnrolled) {
+		s.logger.Error("Failed to get MFA settings", zap.Int64("userID", user.ID), zap.Error(err))
+		return nil, err
+	}
+	if settings != nil && settings.IsEnabled() {
+		mfaToken, err := s.jwtService.GenerateMFAPendingToken(user)
+		if err != nil {
+			s.logger.Error("Failed to generate MFA token", zap.Error(err))
+			return nil, err
+		}
+		return &LoginResult{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	// Generate tokens
-	accessToken, err := s.jwtService.GenerateAccessToken(user)
+	accessToken, refreshToken, err := s.startSession(user, userAgent, ipAddress, false)
 	if err != nil {
-		s.logger.Error("Failed to generate access token", zap.Error(err))
-		return "", "", err
+		return nil, err
+	}
+	return &LoginResult{AccessToken: accessToken, RefreshToken: refreshToken, PasswordChangeRequired: user.MustChangePassword}, nil
+}
+
+// VerifyMFA completes a two-factor login with a TOTP or recovery code and starts a new session
+func (s *AuthServiceImpl) VerifyMFA(mfaToken, code, userAgent, ipAddress string) (*LoginResult, error) {
+	claims, err := s.jwtService.ValidateToken(mfaToken)
+	if err != nil || claims.TokenType != auth.MFAPendingToken {
+		return nil, domain.ErrUnauthorized
 	}
 
-	refreshToken, err := s.jwtService.GenerateRefreshToken(user)
+	// MFA tokens are single use
+	revoked, err := s.revokedTokenRepo.IsRevoked(claims.ID)
 	if err != nil {
-		s.logger.Error("Failed to generate refresh token", zap.Error(err))
-		return "", "", err
+		s.logger.Error("Failed to check MFA token revocation", zap.Error(err))
+		return nil, err
+	}
+	if revoked {
+		return nil, domain.ErrTokenRevoked
 	}
 
-	return accessToken, refreshToken, nil
+	user, err := s.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		s.logger.Error("Failed to get user by ID", zap.Int64("id", claims.UserID), zap.Error(err))
+		return nil, err
+	}
+
+	if user.IsLocked() {
+		return nil, s.lockedError(user.FailedLoginAttempts)
+	}
+
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.mfaRepo.GetByUserID(user.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMFANotEnrolled) {
+			return nil, domain.ErrUnauthorized
+		}
+		s.logger.Error("Failed to get MFA settings", zap.Int64("userID", user.ID), zap.Error(err))
+		return nil, err
+	}
+	if !settings.IsEnabled() {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords
+	if err := s.checkMFACode(settings, code); err != nil {
+		if !errors.Is(err, domain.ErrInvalidMFACode) {
+			return nil, err
+		}
+		s.recordLoginAttempt(&user.ID, user.Username, ipAddress, false)
+		if err := s.registerFailedLogin(user, ipAddress); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	if user.FailedLoginAttempts > 0 {
+		if err := s.userRepo.ResetFailedLogins(user.ID); err != nil {
+			s.logger.Error("Failed to reset failed logins", zap.Int64("userID", user.ID), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	if err := s.revoke(claims); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := s.startSession(user, userAgent, ipAddress, true)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{AccessToken: accessToken, RefreshToken: refreshToken, PasswordChangeRequired: user.MustChangePassword}, nil
+}
+
+// EnrollMFA starts two-factor enrollment by generating a new TOTP secret for the user.
+// The secret only takes effect once confirmed with ConfirmMFA.
+func (s *AuthServiceImpl) EnrollMFA(userID int64) (*MFAEnrollment, error) {
+	settings, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnrolled) {
+		s.logger.Error("Failed to get MFA settings", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	if settings != nil && settings.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		s.logger.Error("Failed to get user by ID", zap.Int64("id", userID), zap.Error(err))
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		s.logger.Error("Failed to generate TOTP secret", zap.Error(err))
+		return nil, err
+	}
+
+	_, err = s.mfaRepo.Upsert(&domain.MFASettings{UserID: userID, Secret: secret})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.config.MFAIssuer, user.Email),
+	}, nil
+}
+
+// ConfirmMFA enables two-factor authentication once the user proves their authenticator
+// works, and returns a fresh set of single-use recovery codes
+func (s *AuthServiceImpl) ConfirmMFA(userID int64, code string) ([]string, error) {
+	settings, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := totp.Validate(settings.Secret, code, time.Now(), mfaSkew)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+	if err := s.mfaRepo.UseStep(userID, step); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		recoveryCode, err := generateRecoveryCode()
+		if err != nil {
+			s.logger.Error("Failed to generate recovery code", zap.Error(err))
+			return nil, err
+		}
+		codes[i] = recoveryCode
+		hashes[i] = auth.HashOpaqueToken(normalizeRecoveryCode(recoveryCode))
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.Confirm(userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Two-factor authentication enabled", zap.String("event", "mfa_enabled"), zap.Int64("userID", userID))
+	return codes, nil
 }
 
-// RefreshToken refreshes an access token using a refresh token
+// MFARequired checks if users with the role must use two-factor authentication
+func (s *AuthServiceImpl) MFARequired(role domain.UserRole) bool {
+	return s.config.MFARequiredForStaff && (role == domain.RoleAdmin || role == domain.RoleLibrarian)
+}
+
+// RefreshToken rotates a refresh token and returns a new token pair.
+// Presenting a refresh token that was already rotated revokes the whole session.
 func (s *AuthServiceImpl) RefreshToken(refreshToken string) (string, string, error) {
 	// Validate refresh token
 	claims, err := s.jwtService.ValidateToken(refreshToken)
@@ -118,6 +428,48 @@ func (s *AuthServiceImpl) RefreshToken(refreshToken string) (string, string, err
 		return "", "", domain.ErrUnauthorized
 	}
 
+	// Refresh tokens issued before sessions existed cannot be rotated
+	if claims.SessionID == 0 || claims.ID == "" {
+		s.logger.Warn("Refresh token without session presented", zap.Int64("userID", claims.UserID))
+		return "", "", domain.ErrUnauthorized
+	}
+
+	// Check if the refresh token has been revoked
+	revoked, err := s.revokedTokenRepo.IsRevoked(claims.ID)
+	if err != nil {
+		s.logger.Error("Failed to check refresh token revocation", zap.Error(err))
+		return "", "", err
+	}
+	if revoked {
+		s.logger.Warn("Revoked refresh token presented", zap.Int64("userID", claims.UserID))
+		return "", "", domain.ErrTokenRevoked
+	}
+
+	// Get session
+	session, err := s.sessionRepo.GetByID(claims.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return "", "", domain.ErrUnauthorized
+		}
+		s.logger.Error("Failed to get session", zap.Int64("sessionID", claims.SessionID), zap.Error(err))
+		return "", "", err
+	}
+
+	if session.UserID != claims.UserID {
+		s.logger.Warn("Refresh token session belongs to another user",
+			zap.Int64("userID", claims.UserID), zap.Int64("sessionID", session.ID))
+		return "", "", domain.ErrUnauthorized
+	}
+
+	if !session.IsActive() {
+		return "", "", domain.ErrTokenRevoked
+	}
+
+	// Any token other than the current one was already used: treat it as stolen
+	if session.RefreshTokenID != claims.ID {
+		return "", "", s.handleTokenReuse(session)
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(claims.UserID)
 	if err != nil {
@@ -125,37 +477,472 @@ func (s *AuthServiceImpl) RefreshToken(refreshToken string) (string, string, err
 		return "", "", err
 	}
 
-	// Generate new tokens
-	newAccessToken, err := s.jwtService.GenerateAccessToken(user)
+	if !user.IsActive() {
+		return "", "", domain.ErrUnauthorized
+	}
+
+	// Rotate the refresh token, marking the presented one as used
+	newTokenID, err := auth.NewTokenID()
 	if err != nil {
-		s.logger.Error("Failed to generate access token", zap.Error(err))
+		s.logger.Error("Failed to generate token ID", zap.Error(err))
 		return "", "", err
 	}
 
-	newRefreshToken, err := s.jwtService.GenerateRefreshToken(user)
+	err = s.sessionRepo.Rotate(session.ID, claims.ID, newTokenID, s.jwtService.RefreshTokenExpiry())
 	if err != nil {
-		s.logger.Error("Failed to generate refresh token", zap.Error(err))
+		if errors.Is(err, domain.ErrTokenReuseDetected) {
+			// Another request rotated the same token first
+			return "", "", s.handleTokenReuse(session)
+		}
+		s.logger.Error("Failed to rotate session token", zap.Int64("sessionID", session.ID), zap.Error(err))
 		return "", "", err
 	}
 
-	return newAccessToken, newRefreshToken, nil
+	return s.generateTokens(user, session.ID, newTokenID, session.MFAVerified)
 }
 
-// Logout logs out a user
-func (s *AuthServiceImpl) Logout(token string) error {
+// Logout logs out a user by revoking the access token and, if provided, the refresh token
+func (s *AuthServiceImpl) Logout(accessToken, refreshToken string) error {
 	// Validate token
-	claims, err := s.jwtService.ValidateToken(token)
+	claims, err := s.jwtService.ValidateToken(accessToken)
 	if err != nil {
 		s.logger.Error("Invalid token", zap.Error(err))
 		return domain.ErrUnauthorized
 	}
 
-	// In a real-world application, you would add the token to a blacklist
-	// For example, store in Redis with expiration set to token's remaining lifetime
-	// For simplicity in this implementation, we'll just return success
+	if err := s.revoke(claims); err != nil {
+		return err
+	}
+
+	// Impersonation tokens share the admin's session, which stays active
+	if claims.Actor != nil {
+		s.logger.Info("Impersonation ended",
+			zap.String("event", "impersonation_ended"), zap.Int64("actorID", claims.Actor.UserID), zap.Int64("userID", claims.UserID))
+		return nil
+	}
+
+	// End the session so its refresh token family can no longer be used
+	if claims.SessionID != 0 {
+		err := s.sessionRepo.Revoke(claims.SessionID, "logout")
+		if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+			s.logger.Error("Failed to revoke session", zap.Int64("sessionID", claims.SessionID), zap.Error(err))
+			return err
+		}
+	}
+
+	// Revoke the refresh token as well so it cannot be used to mint new access tokens
+	if refreshToken != "" {
+		refreshClaims, err := s.jwtService.ValidateToken(refreshToken)
+		if err != nil {
+			s.logger.Error("Invalid refresh token", zap.Error(err))
+			return domain.ErrUnauthorized
+		}
+
+		if refreshClaims.UserID != claims.UserID || refreshClaims.TokenType != auth.RefreshToken {
+			return domain.NewInvalidInputError("refresh token does not belong to the current user")
+		}
+
+		if err := s.revoke(refreshClaims); err != nil {
+			return err
+		}
+	}
+
 	s.logger.Info("User logged out", zap.Int64("userID", claims.UserID))
 	return nil
 }
 
+// ForgotPassword emails a password reset link to the user with the given email.
+// It succeeds even if no such user exists so the endpoint cannot be used to probe for accounts.
+func (s *AuthServiceImpl) ForgotPassword(email string) error {
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Info("Password reset requested for unknown email")
+			return nil
+		}
+		s.logger.Error("Failed to get user by email", zap.Error(err))
+		return err
+	}
+
+	if !user.IsActive() {
+		s.logger.Info("Password reset requested for inactive account", zap.Int64("userID", user.ID))
+		return nil
+	}
+
+	// Only the most recent link should work
+	if err := s.passwordResetRepo.InvalidateByUser(user.ID); err != nil {
+		s.logger.Error("Failed to invalidate previous reset tokens", zap.Int64("userID", user.ID), zap.Error(err))
+		return err
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate reset token", zap.Error(err))
+		return err
+	}
+
+	_, err = s.passwordResetRepo.Create(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashOpaqueToken(token),
+		ExpiresAt: time.Now().Add(s.config.PasswordResetExpiration),
+	})
+	if err != nil {
+		s.logger.Error("Failed to store reset token", zap.Int64("userID", user.ID), zap.Error(err))
+		return err
+	}
+
+	err = s.mailer.Send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"We received a request to reset your password. Use the link below to choose a new one:\n\n"+
+			"%s?token=%s\n\n"+
+			"The link expires in %s. If you did not request a reset, you can ignore this email.\n",
+			user.Username, s.config.PasswordResetURL, token, s.config.PasswordResetExpiration),
+	})
+	if err != nil {
+		s.logger.Error("Failed to send password reset email", zap.Int64("userID", user.ID), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Password reset email sent", zap.Int64("userID", user.ID))
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and ends all of the user's sessions
+func (s *AuthServiceImpl) ResetPassword(token, newPassword string) error {
+	resetToken, err := s.passwordResetRepo.GetByTokenHash(auth.HashOpaqueToken(token))
+	if err != nil {
+		return err
+	}
+
+	if resetToken.UsedAt != nil || resetToken.ExpiresAt.Before(time.Now()) {
+		return domain.ErrInvalidResetToken
+	}
+
+	// Check the new password first so a rejected one does not use up the token
+	user, err := s.userRepo.GetByID(resetToken.UserID)
+	if err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.Validate(newPassword, user); err != nil {
+		return err
+	}
+
+	// Redeem the token before changing anything so it cannot be used twice
+	if err := s.passwordResetRepo.MarkUsed(resetToken.ID); err != nil {
+		return err
+	}
+
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		s.logger.Error("Failed to hash password", zap.Error(err))
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(resetToken.UserID, hashedPassword); err != nil {
+		s.logger.Error("Failed to update password", zap.Int64("userID", resetToken.UserID), zap.Error(err))
+		return err
+	}
+
+	if err := s.passwordPolicy.Remember(resetToken.UserID, hashedPassword); err != nil {
+		s.logger.Error("Failed to record password history", zap.Int64("userID", resetToken.UserID), zap.Error(err))
+	}
+
+	// Whoever knew the old password must not stay logged in
+	if err := s.sessionRepo.RevokeAllByUser(resetToken.UserID, "password reset"); err != nil {
+		s.logger.Error("Failed to revoke sessions after password reset", zap.Int64("userID", resetToken.UserID), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Password reset completed", zap.Int64("userID", resetToken.UserID))
+	return nil
+}
+
+// IsRevoked checks if the token described by the claims, or the session it belongs to, has been revoked
+func (s *AuthServiceImpl) IsRevoked(claims *auth.Claims) (bool, error) {
+	// Tokens issued before revocation support have no ID and cannot be revoked
+	if claims.ID != "" {
+		revoked, err := s.revokedTokenRepo.IsRevoked(claims.ID)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	if claims.SessionID == 0 {
+		return false, nil
+	}
+
+	session, err := s.sessionRepo.GetByID(claims.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return session.RevokedAt != nil, nil
+}
+
+// ListSessions retrieves the active sessions of a user
+func (s *AuthServiceImpl) ListSessions(userID int64) ([]*domain.Session, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(userID)
+	if err != nil {
+		s.logger.Error("Failed to list sessions", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes one of the user's own sessions
+func (s *AuthServiceImpl) RevokeSession(userID, sessionID int64) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) {
+			s.logger.Error("Failed to get session", zap.Int64("sessionID", sessionID), zap.Error(err))
+		}
+		return err
+	}
+
+	// Do not reveal that sessions of other users exist
+	if session.UserID != userID {
+		return domain.ErrSessionNotFound
+	}
+
+	err = s.sessionRepo.Revoke(sessionID, "revoked by user")
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) {
+			s.logger.Error("Failed to revoke session", zap.Int64("sessionID", sessionID), zap.Error(err))
+		}
+		return err
+	}
+
+	s.logger.Info("Session revoked", zap.Int64("userID", userID), zap.Int64("sessionID", sessionID))
+	return nil
+}
+
+// PurgeRevokedTokens removes revocation entries for tokens that have already expired
+func (s *AuthServiceImpl) PurgeRevokedTokens() (int64, error) {
+	deleted, err := s.revokedTokenRepo.DeleteExpired()
+	if err != nil {
+		s.logger.Error("Failed to purge revoked tokens", zap.Error(err))
+		return 0, err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Purged expired revoked tokens", zap.Int64("count", deleted))
+	}
+	return deleted, nil
+}
+
+// PurgeLoginAttempts removes login attempts older than the retention period
+func (s *AuthServiceImpl) PurgeLoginAttempts() (int64, error) {
+	deleted, err := s.loginAttemptRepo.DeleteBefore(time.Now().Add(-s.config.LoginAttemptRetention))
+	if err != nil {
+		s.logger.Error("Failed to purge login attempts", zap.Error(err))
+		return 0, err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Purged old login attempts", zap.Int64("count", deleted))
+	}
+	return deleted, nil
+}
+
+// lockedError returns the error for a login refused because the account is locked or backing off
+func (s *AuthServiceImpl) lockedError(failedAttempts int) error {
+	if failedAttempts >= s.config.MaxFailedLogins {
+		return domain.ErrAccountLocked
+	}
+	return domain.ErrTooManyLoginAttempts
+}
+
+// checkMFACode accepts either a current TOTP code or an unused recovery code
+func (s *AuthServiceImpl) checkMFACode(settings *domain.MFASettings, code string) error {
+	if step, ok := totp.Validate(settings.Secret, code, time.Now(), mfaSkew); ok {
+		// Fails if the code was already used, so a code seen over someone's shoulder cannot be replayed
+		return s.mfaRepo.UseStep(settings.UserID, step)
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return domain.ErrInvalidMFACode
+	}
+
+	err := s.mfaRepo.UseRecoveryCode(settings.UserID, auth.HashOpaqueToken(normalized))
+	if err != nil {
+		return err
+	}
+
+	s.logger.Warn("Recovery code used", zap.String("event", "mfa_recovery_code_used"), zap.Int64("userID", settings.UserID))
+	return nil
+}
+
+// registerFailedLogin counts a failed password for the user and delays or locks further attempts.
+// Each failure doubles the wait before the next attempt until the account is locked outright.
+func (s *AuthServiceImpl) registerFailedLogin(user *domain.User, ipAddress string) error {
+	attempts, err := s.userRepo.IncrementFailedLogins(user.ID)
+	if err != nil {
+		s.logger.Error("Failed to record failed login", zap.Int64("userID", user.ID), zap.Error(err))
+		return err
+	}
+
+	lockedUntil := time.Now().Add(s.failedLoginDelay(attempts))
+	if err := s.userRepo.LockUntil(user.ID, lockedUntil); err != nil {
+		s.logger.Error("Failed to lock user", zap.Int64("userID", user.ID), zap.Error(err))
+		return err
+	}
+
+	if attempts >= s.config.MaxFailedLogins {
+		s.logger.Warn("Account locked after repeated failed logins",
+			zap.String("event", "account_locked"),
+			zap.Int64("userID", user.ID),
+			zap.String("ip", ipAddress),
+			zap.Int("attempts", attempts),
+			zap.Time("lockedUntil", lockedUntil))
+	}
+
+	return nil
+}
+
+// failedLoginDelay returns how long logins are refused after the given number of failed attempts
+func (s *AuthServiceImpl) failedLoginDelay(attempts int) time.Duration {
+	if attempts >= s.config.MaxFailedLogins {
+		return s.config.LockoutDuration
+	}
+	delay := s.config.LoginThrottleBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > s.config.LockoutDuration {
+		delay = s.config.LockoutDuration
+	}
+	return delay
+}
+
+// failUnknownUserLogin refuses a login for a username that matches no account. The failures
+// of the username back off and lock like those of an account, and the time of a password check
+// is spent, so the responses cannot tell whether the account exists.
+func (s *AuthServiceImpl) failUnknownUserLogin(username, password, ipAddress string) error {
+	failures, lastFailure, err := s.loginAttemptRepo.GetUnknownUserFailures(username)
+	if err != nil {
+		s.logger.Error("Failed to count failed logins", zap.String("username", username), zap.Error(err))
+		return err
+	}
+	if failures > 0 && time.Now().Before(lastFailure.Add(s.failedLoginDelay(failures))) {
+		return s.lockedError(failures)
+	}
+
+	verifyPassword(password, dummyPasswordHash)
+	s.recordLoginAttempt(nil, username, ipAddress, false)
+	return domain.ErrInvalidCredentials
+}
+
+// recordLoginAttempt stores a login attempt; failures are only logged so they never block a login
+func (s *AuthServiceImpl) recordLoginAttempt(userID *int64, username, ipAddress string, success bool) {
+	err := s.loginAttemptRepo.Record(&domain.LoginAttempt{
+		UserID:    userID,
+		Username:  username,
+		IPAddress: ipAddress,
+		Success:   success,
+	})
+	if err != nil {
+		s.logger.Error("Failed to record login attempt", zap.String("ip", ipAddress), zap.Error(err))
+	}
+}
+
+// startSession creates a new session for the user and issues its first token pair
+func (s *AuthServiceImpl) startSession(user *domain.User, userAgent, ipAddress string, mfaVerified bool) (string, string, error) {
+	tokenID, err := auth.NewTokenID()
+	if err != nil {
+		s.logger.Error("Failed to generate token ID", zap.Error(err))
+		return "", "", err
+	}
+
+	session, err := s.sessionRepo.Create(&domain.Session{
+		UserID:         user.ID,
+		RefreshTokenID: tokenID,
+		UserAgent:      userAgent,
+		IPAddress:      ipAddress,
+		ExpiresAt:      s.jwtService.RefreshTokenExpiry(),
+		MFAVerified:    mfaVerified,
+	})
+	if err != nil {
+		s.logger.Error("Failed to create session", zap.Int64("userID", user.ID), zap.Error(err))
+		return "", "", err
+	}
+
+	return s.generateTokens(user, session.ID, tokenID, mfaVerified)
+}
+
+// generateTokens issues an access token and a refresh token for a session
+func (s *AuthServiceImpl) generateTokens(user *domain.User, sessionID int64, refreshTokenID string, mfaVerified bool) (string, string, error) {
+	accessToken, err := s.jwtService.GenerateAccessToken(user, sessionID, mfaVerified)
+	if err != nil {
+		s.logger.Error("Failed to generate access token", zap.Error(err))
+		return "", "", err
+	}
+
+	refreshToken, err := s.jwtService.GenerateRefreshToken(user, sessionID, refreshTokenID)
+	if err != nil {
+		s.logger.Error("Failed to generate refresh token", zap.Error(err))
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// handleTokenReuse revokes a session whose already-used refresh token was presented again
+func (s *AuthServiceImpl) handleTokenReuse(session *domain.Session) error {
+	s.logger.Warn("Refresh token reuse detected, revoking session",
+		zap.Int64("userID", session.UserID), zap.Int64("sessionID", session.ID))
+
+	err := s.sessionRepo.Revoke(session.ID, "refresh token reuse")
+	if err != nil && !errors.Is(err, domain.ErrSessionNotFound) {
+		s.logger.Error("Failed to revoke session", zap.Int64("sessionID", session.ID), zap.Error(err))
+		return err
+	}
+
+	return domain.ErrTokenReuseDetected
+}
+
+// revoke adds the token described by the claims to the revocation store
+func (s *AuthServiceImpl) revoke(claims *auth.Claims) error {
+	if claims.ID == "" {
+		s.logger.Warn("Token has no ID and cannot be revoked", zap.Int64("userID", claims.UserID))
+		return nil
+	}
+
+	expiresAt := time.Now()
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	err := s.revokedTokenRepo.Revoke(&domain.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		s.logger.Error("Failed to revoke token", zap.Int64("userID", claims.UserID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// generateRecoveryCode returns a random recovery code formatted as two groups of five characters
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode strips formatting so codes can be typed with or without the dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
 // Note: Using the shared hashPassword and verifyPassword functions
 // that are defined in user_service.go
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDummyPasswordHashCost(t *testing.T) {
+	// Unknown usernames must pay the same bcrypt cost as real password checks
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	if err != nil {
+		t.Fatalf("dummyPasswordHash is not a bcrypt hash: %v", err)
+	}
+	if cost != bcrypt.DefaultCost {
+		t.Errorf("dummyPasswordHash cost = %d, want %d", cost, bcrypt.DefaultCost)
+	}
+}
+
+func TestAuthServiceLoginUnknownUsername(t *testing.T) {
+	cfg := config.AuthConfig{
+		MaxFailedLogins:        3,
+		MaxFailedLoginsPerIP:   100,
+		LoginIPWindow:          time.Hour,
+		LoginThrottleBaseDelay: time.Minute,
+		LockoutDuration:        time.Hour,
+	}
+
+	tests := []struct {
+		name        string
+		failures    int
+		lastFailure time.Time
+		wantErr     error
+	}{
+		{"first attempt", 0, time.Time{}, domain.ErrInvalidCredentials},
+		{"backing off", 1, time.Now().Add(-30 * time.Second), domain.ErrTooManyLoginAttempts},
+		{"back off over", 1, time.Now().Add(-2 * time.Minute), domain.ErrInvalidCredentials},
+		{"locked", 3, time.Now().Add(-30 * time.Minute), domain.ErrAccountLocked},
+		{"lockout over", 3, time.Now().Add(-2 * time.Hour), domain.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().GetByUsername("nobody").Return(nil, domain.ErrUserNotFound)
+
+			loginAttemptRepo := mocks.NewMockLoginAttemptRepository(ctrl)
+			loginAttemptRepo.EXPECT().CountFailuresByIP("203.0.113.7", gomock.Any()).Return(0, nil)
+			loginAttemptRepo.EXPECT().GetUnknownUserFailures("nobody").Return(tt.failures, tt.lastFailure, nil)
+			if errors.Is(tt.wantErr, domain.ErrInvalidCredentials) {
+				loginAttemptRepo.EXPECT().Record(gomock.Any()).Return(nil)
+			}
+
+			s := NewAuthService(userRepo, nil, nil, nil, nil, loginAttemptRepo, nil, nil, nil, nil, nil, nil, nil,
+				cfg, config.OIDCConfig{}, &logger.Logger{Logger: zap.NewNop()})
+
+			// Unknown usernames get the responses an account with the same failures would get
+			if _, err := s.Login("nobody", "wrong password", "test", "203.0.113.7"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// policyCacheTTL is how long the role permission mapping is cached before it is reloaded,
+// which bounds how long changes made on another replica take to apply here
+const policyCacheTTL = 30 * time.Second
+
+// AuthorizationServiceImpl implements domain.AuthorizationService
+type AuthorizationServiceImpl struct {
+	repo          domain.PermissionRepository
+	householdRepo domain.HouseholdRepository
+	logger        *logger.Logger
+
+	mu       sync.RWMutex
+	policy   map[domain.UserRole]map[domain.Permission]bool
+	loadedAt time.Time
+}
+
+// NewAuthorizationService creates a new AuthorizationService
+func NewAuthorizationService(repo domain.PermissionRepository, householdRepo domain.HouseholdRepository, logger *logger.Logger) domain.AuthorizationService {
+	return &AuthorizationServiceImpl{
+		repo:          repo,
+		householdRepo: householdRepo,
+		logger:        logger,
+	}
+}
+
+// Can checks if the subject's role has been granted a permission.
+// Permissions are denied when the policy cannot be loaded.
+func (s *AuthorizationServiceImpl) Can(subject domain.Subject, permission domain.Permission) bool {
+	policy, err := s.currentPolicy()
+	if err != nil {
+		return false
+	}
+	return policy[subject.Role][permission]
+}
+
+// CanAccess checks if the subject may act on a resource owned by ownerID, either through
+// the ":any" variant of the permission or through the ":own" variant on their own resources.
+// For actions delegated to guardians the ":own" variant also covers the subject's dependents.
+func (s *AuthorizationServiceImpl) CanAccess(subject domain.Subject, permission domain.ScopedPermission, ownerID int64) bool {
+	if s.Can(subject, permission.Any()) {
+		return true
+	}
+	if !s.Can(subject, permission.Own()) {
+		return false
+	}
+	if subject.UserID == ownerID {
+		return true
+	}
+	return permission.DelegatedToGuardians() && s.isGuardianOf(subject.UserID, ownerID)
+}
+
+// CanActFor checks if the subject may rent or pay on behalf of a user, which they may
+// for themselves and for the dependents of the household they are the guardian of
+func (s *AuthorizationServiceImpl) CanActFor(subject domain.Subject, userID int64) bool {
+	return subject.UserID == userID || s.isGuardianOf(subject.UserID, userID)
+}
+
+// ListRolePermissions returns the permissions granted to each role
+func (s *AuthorizationServiceImpl) ListRolePermissions() (map[domain.UserRole][]domain.Permission, error) {
+	rolePermissions, err := s.repo.List()
+	if err != nil {
+		s.logger.Error("Failed to list role permissions", zap.Error(err))
+		return nil, err
+	}
+
+	result := make(map[domain.UserRole][]domain.Permission)
+	for _, rp := range rolePermissions {
+		result[rp.Role] = append(result[rp.Role], rp.Permission)
+	}
+	return result, nil
+}
+
+// Grant gives a role a permission
+func (s *AuthorizationServiceImpl) Grant(role domain.UserRole, permission domain.Permission, grantedBy int64) error {
+	if err := validateRolePermission(role, permission); err != nil {
+		return err
+	}
+
+	if err := s.repo.Grant(role, permission); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.logger.Warn("Permission granted",
+		zap.String("event", "permission_granted"),
+		zap.String("role", string(role)),
+		zap.String("permission", string(permission)),
+		zap.Int64("grantedBy", grantedBy))
+	return nil
+}
+
+// Revoke takes a permission away from a role. Admins always keep the permission to
+// manage permissions so the policy cannot be locked against every change.
+func (s *AuthorizationServiceImpl) Revoke(role domain.UserRole, permission domain.Permission, revokedBy int64) error {
+	if err := validateRolePermission(role, permission); err != nil {
+		return err
+	}
+
+	if role == domain.RoleAdmin && permission == domain.PermPermissionsManage {
+		return domain.NewInvalidInputError("the admin role cannot lose the permission to manage permissions")
+	}
+
+	if err := s.repo.Revoke(role, permission); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.logger.Warn("Permission revoked",
+		zap.String("event", "permission_revoked"),
+		zap.String("role", string(role)),
+		zap.String("permission", string(permission)),
+		zap.Int64("revokedBy", revokedBy))
+	return nil
+}
+
+// currentPolicy returns the cached role permission mapping, reloading it once it is stale.
+// If a reload fails the previous mapping keeps being used.
+func (s *AuthorizationServiceImpl) currentPolicy() (map[domain.UserRole]map[domain.Permission]bool, error) {
+	s.mu.RLock()
+	policy, loadedAt := s.policy, s.loadedAt
+	s.mu.RUnlock()
+
+	if policy != nil && time.Since(loadedAt) < policyCacheTTL {
+		return policy, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another request may have reloaded the policy while we waited for the lock
+	if s.policy != nil && time.Since(s.loadedAt) < policyCacheTTL {
+		return s.policy, nil
+	}
+
+	rolePermissions, err := s.repo.List()
+	if err != nil {
+		s.logger.Error("Failed to load authorization policy", zap.Error(err))
+		if s.policy != nil {
+			return s.policy, nil
+		}
+		return nil, err
+	}
+
+	policy = make(map[domain.UserRole]map[domain.Permission]bool)
+	for _, rp := range rolePermissions {
+		if policy[rp.Role] == nil {
+			policy[rp.Role] = make(map[domain.Permission]bool)
+		}
+		policy[rp.Role][rp.Permission] = true
+	}
+
+	s.policy = policy
+	s.loadedAt = time.Now()
+	return policy, nil
+}
+
+// isGuardianOf checks if a user is the guardian of another user.
+// The relationship is denied when it cannot be looked up.
+func (s *AuthorizationServiceImpl) isGuardianOf(guardianID, userID int64) bool {
+	isGuardian, err := s.householdRepo.IsGuardianOf(guardianID, userID)
+	if err != nil {
+		s.logger.Error("Failed to check household guardian", zap.Int64("guardianID", guardianID), zap.Int64("userID", userID), zap.Error(err))
+		return false
+	}
+	return isGuardian
+}
+
+// invalidate forces the policy to be reloaded on the next check
+func (s *AuthorizationServiceImpl) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// validateRolePermission checks that both the role and the permission exist
+func validateRolePermission(role domain.UserRole, permission domain.Permission) error {
+	if !domain.IsValidRole(role) {
+		return domain.NewInvalidInputError("unknown role: " + string(role))
+	}
+	if !domain.IsValidPermission(permission) {
+		return domain.ErrUnknownPermission
+	}
+	return nil
+}
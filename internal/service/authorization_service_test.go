@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+const (
+	guardianID  = int64(1)
+	dependentID = int64(2)
+	strangerID  = int64(3)
+)
+
+// newTestAuthorizationService creates an authorization service where members may read their own
+// profile and rentals, librarians may read everyone's rentals, and user 1 is the guardian of user 2
+func newTestAuthorizationService(ctrl *gomock.Controller, guardianErr error) domain.AuthorizationService {
+	repo := mocks.NewMockPermissionRepository(ctrl)
+	repo.EXPECT().List().Return([]*domain.RolePermission{
+		{Role: domain.RoleMember, Permission: domain.PermUsersRead.Own()},
+		{Role: domain.RoleMember, Permission: domain.PermRentalsRead.Own()},
+		{Role: domain.RoleLibrarian, Permission: domain.PermRentalsRead.Any()},
+	}, nil).AnyTimes()
+
+	householdRepo := mocks.NewMockHouseholdRepository(ctrl)
+	householdRepo.EXPECT().IsGuardianOf(gomock.Any(), gomock.Any()).DoAndReturn(func(guardian, user int64) (bool, error) {
+		if guardianErr != nil {
+			return false, guardianErr
+		}
+		return guardian == guardianID && user == dependentID, nil
+	}).AnyTimes()
+
+	return NewAuthorizationService(repo, householdRepo, &logger.Logger{Logger: zap.NewNop()})
+}
+
+func TestAuthorizationServiceCanAccess(t *testing.T) {
+	member := domain.Subject{UserID: guardianID, Role: domain.RoleMember}
+	librarian := domain.Subject{UserID: 4, Role: domain.RoleLibrarian}
+
+	tests := []struct {
+		name        string
+		subject     domain.Subject
+		permission  domain.ScopedPermission
+		ownerID     int64
+		guardianErr error
+		want        bool
+	}{
+		{"own resource", member, domain.PermRentalsRead, guardianID, nil, true},
+		{"dependent's resource with a delegated permission", member, domain.PermRentalsRead, dependentID, nil, true},
+		{"dependent's resource with a permission that is not delegated", member, domain.PermUsersRead, dependentID, nil, false},
+		{"another user's resource", member, domain.PermRentalsRead, strangerID, nil, false},
+		{"any resource", librarian, domain.PermRentalsRead, strangerID, nil, true},
+		{"own resource without the own permission", librarian, domain.PermUsersRead, librarian.UserID, nil, false},
+		{"dependent's resource when the household cannot be looked up", member, domain.PermRentalsRead, dependentID, errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAuthorizationService(gomock.NewController(t), tt.guardianErr)
+
+			if got := s.CanAccess(tt.subject, tt.permission, tt.ownerID); got != tt.want {
+				t.Errorf("CanAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationServiceCanActFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		guardianErr error
+		want        bool
+	}{
+		{"themselves", guardianID, nil, true},
+		{"their dependent", dependentID, nil, true},
+		{"another user", strangerID, nil, false},
+		{"their dependent when the household cannot be looked up", dependentID, errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestAuthorizationService(gomock.NewController(t), tt.guardianErr)
+
+			subject := domain.Subject{UserID: guardianID, Role: domain.RoleMember}
+			if got := s.CanActFor(subject, tt.userID); got != tt.want {
+				t.Errorf("CanActFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizationServiceDeniesWhenPolicyCannotBeLoaded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := mocks.NewMockPermissionRepository(ctrl)
+	repo.EXPECT().List().Return(nil, errors.New("connection refused")).AnyTimes()
+
+	s := NewAuthorizationService(repo, mocks.NewMockHouseholdRepository(ctrl), &logger.Logger{Logger: zap.NewNop()})
+
+	subject := domain.Subject{UserID: guardianID, Role: domain.RoleMember}
+	if s.CanAccess(subject, domain.PermRentalsRead, guardianID) {
+		t.Error("CanAccess() = true, want false")
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// BookCopyServiceImpl implements domain.BookCopyService
+type BookCopyServiceImpl struct {
+	repo     domain.BookCopyRepository
+	bookRepo domain.BookRepository
+	logger   *logger.Logger
+}
+
+// NewBookCopyService creates a new BookCopyService
+func NewBookCopyService(repo domain.BookCopyRepository, bookRepo domain.BookRepository, logger *logger.Logger) domain.BookCopyService {
+	return &BookCopyServiceImpl{
+		repo:     repo,
+		bookRepo: bookRepo,
+		logger:   logger,
+	}
+}
+
+// GetByBarcode retrieves a book copy by barcode
+func (s *BookCopyServiceImpl) GetByBarcode(barcode string) (*domain.BookCopy, error) {
+	bookCopy, err := s.repo.GetByBarcode(barcode)
+	if err != nil {
+		s.logger.Error("Failed to get book copy by barcode", zap.String("barcode", barcode), zap.Error(err))
+		return nil, err
+	}
+	return bookCopy, nil
+}
+
+// ListByBook retrieves all copies of a book
+func (s *BookCopyServiceImpl) ListByBook(bookID int64) ([]*domain.BookCopy, error) {
+	// Check if book exists
+	if _, err := s.bookRepo.GetByID(bookID); err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+
+	copies, err := s.repo.ListByBook(bookID)
+	if err != nil {
+		s.logger.Error("Failed to list book copies", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+	return copies, nil
+}
+
+// Create adds a copy to a book. New copies are in good condition and on the shelf unless
+// stated otherwise.
+func (s *BookCopyServiceImpl) Create(bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	// Check if book exists
+	if _, err := s.bookRepo.GetByID(bookCopy.BookID); err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", bookCopy.BookID), zap.Error(err))
+		return nil, err
+	}
+
+	if bookCopy.Condition == "" {
+		bookCopy.Condition = domain.BookCopyConditionGood
+	}
+	if bookCopy.Status == "" {
+		bookCopy.Status = domain.BookCopyStatusAvailable
+	}
+
+	if err := validateBookCopy(bookCopy); err != nil {
+		return nil, err
+	}
+	if isCirculationStatus(bookCopy.Status) {
+		return nil, domain.NewInvalidInputError("copies are checked out and held through rentals and reservations")
+	}
+
+	createdCopy, err := s.repo.Create(bookCopy)
+	if err != nil {
+		s.logger.Error("Failed to create book copy", zap.String("barcode", bookCopy.Barcode), zap.Error(err))
+		return nil, err
+	}
+
+	return createdCopy, nil
+}
+
+// Update updates the condition, shelf location and status of the copy with the given barcode
+func (s *BookCopyServiceImpl) Update(bookCopy *domain.BookCopy) (*domain.BookCopy, error) {
+	existingCopy, err := s.repo.GetByBarcode(bookCopy.Barcode)
+	if err != nil {
+		s.logger.Error("Failed to get book copy by barcode", zap.String("barcode", bookCopy.Barcode), zap.Error(err))
+		return nil, err
+	}
+
+	bookCopy.ID = existingCopy.ID
+	bookCopy.BookID = existingCopy.BookID
+
+	if err := validateBookCopy(bookCopy); err != nil {
+		return nil, err
+	}
+	if isCirculationStatus(bookCopy.Status) && bookCopy.Status != existingCopy.Status {
+		return nil, domain.NewInvalidInputError("copies are checked out and held through rentals and reservations")
+	}
+
+	updatedCopy, err := s.repo.Update(bookCopy)
+	if err != nil {
+		s.logger.Error("Failed to update book copy", zap.String("barcode", bookCopy.Barcode), zap.Error(err))
+		return nil, err
+	}
+
+	return updatedCopy, nil
+}
+
+// validateBookCopy checks the condition and status of a copy
+func validateBookCopy(bookCopy *domain.BookCopy) error {
+	if !domain.IsValidBookCopyCondition(bookCopy.Condition) {
+		return domain.NewInvalidInputError("invalid condition: " + string(bookCopy.Condition))
+	}
+	if !domain.IsValidBookCopyStatus(bookCopy.Status) {
+		return domain.NewInvalidInputError("invalid status: " + string(bookCopy.Status))
+	}
+	return nil
+}
+
+// isCirculationStatus reports whether a status is only set by rentals and reservations
+func isCirculationStatus(status domain.BookCopyStatus) bool {
+	return status == domain.BookCopyStatusCheckedOut || status == domain.BookCopyStatusOnHold
+}
@@ -12,14 +12,16 @@ import (
 type BookServiceImpl struct {
 	repo         domain.BookRepository
 	categoryRepo domain.CategoryRepository
+	copyRepo     domain.BookCopyRepository
 	logger       *logger.Logger
 }
 
 // NewBookService creates a new BookService
-func NewBookService(repo domain.BookRepository, categoryRepo domain.CategoryRepository, logger *logger.Logger) domain.BookService {
+func NewBookService(repo domain.BookRepository, categoryRepo domain.CategoryRepository, copyRepo domain.BookCopyRepository, logger *logger.Logger) domain.BookService {
 	return &BookServiceImpl{
 		repo:         repo,
 		categoryRepo: categoryRepo,
+		copyRepo:     copyRepo,
 		logger:       logger,
 	}
 }
@@ -111,11 +113,6 @@ func (s *BookServiceImpl) Create(book *domain.Book) (*domain.Book, error) {
 		}
 	}
 
-	// Ensure available copies doesn't exceed total copies
-	if book.AvailableCopies > book.TotalCopies {
-		book.AvailableCopies = book.TotalCopies
-	}
-
 	// Create book
 	createdBook, err := s.repo.Create(book)
 	if err != nil {
@@ -156,10 +153,6 @@ func (s *BookServiceImpl) Update(book *domain.Book) (*domain.Book, error) {
 		}
 	}
 
-	// Preserve copies information
-	book.TotalCopies = existingBook.TotalCopies
-	book.AvailableCopies = existingBook.AvailableCopies
-
 	// Update book
 	updatedBook, err := s.repo.Update(book)
 	if err != nil {
@@ -170,64 +163,25 @@ func (s *BookServiceImpl) Update(book *domain.Book) (*domain.Book, error) {
 	return updatedBook, nil
 }
 
-// UpdateCopies updates the total and available copies of a book
-func (s *BookServiceImpl) UpdateCopies(id int64, totalCopies, availableCopies int32) (*domain.Book, error) {
-	// Check if book exists
-	existingBook, err := s.repo.GetByID(id)
-	if err != nil {
-		s.logger.Error("Failed to get book by ID", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	// Validate copies
-	if totalCopies < 0 {
-		return nil, domain.NewInvalidInputError("total copies cannot be negative")
-	}
-
-	if availableCopies < 0 {
-		return nil, domain.NewInvalidInputError("available copies cannot be negative")
-	}
-
-	if availableCopies > totalCopies {
-		return nil, domain.NewInvalidInputError("available copies cannot exceed total copies")
-	}
-
-	// Calculate the difference in available copies
-	availableDiff := availableCopies - existingBook.AvailableCopies
-	totalDiff := totalCopies - existingBook.TotalCopies
-
-	// If reducing total copies, ensure we're not removing copies that are currently borrowed
-	if totalDiff < 0 && availableDiff > 0 {
-		return nil, domain.NewInvalidInputError("cannot increase available copies while decreasing total copies")
-	}
-
-	// If reducing total copies, ensure we're not removing more copies than are available
-	if totalDiff < 0 && -totalDiff > existingBook.AvailableCopies {
-		return nil, domain.NewInvalidInputError("cannot remove more copies than are currently available")
-	}
-
-	// Update copies
-	updatedBook, err := s.repo.UpdateCopies(id, totalCopies, availableCopies)
-	if err != nil {
-		s.logger.Error("Failed to update book copies", zap.Int64("id", id), zap.Error(err))
-		return nil, err
-	}
-
-	return updatedBook, nil
-}
-
 // Delete deletes a book
 func (s *BookServiceImpl) Delete(id int64) error {
 	// Check if book exists
-	book, err := s.repo.GetByID(id)
+	_, err := s.repo.GetByID(id)
 	if err != nil {
 		s.logger.Error("Failed to get book by ID", zap.Int64("id", id), zap.Error(err))
 		return err
 	}
 
-	// Check if all copies are available (no active rentals)
-	if book.AvailableCopies < book.TotalCopies {
-		return domain.NewInvalidInputError("cannot delete book with active rentals")
+	// Check that no copy is checked out (no active rentals)
+	copies, err := s.copyRepo.ListByBook(id)
+	if err != nil {
+		s.logger.Error("Failed to list book copies", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+	for _, bookCopy := range copies {
+		if bookCopy.Status == domain.BookCopyStatusCheckedOut {
+			return domain.NewInvalidInputError("cannot delete book with active rentals")
+		}
 	}
 
 	err = s.repo.Delete(id)
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxImportedClosures caps the number of closed days a single iCalendar file may add
+const maxImportedClosures = 1000
+
+// maxClosureEventDays caps the number of days a single iCalendar event may close the library for
+const maxClosureEventDays = 366
+
+// calendarHorizon is how far past a date closures are loaded to roll it to the next open day
+const calendarHorizon = 366 * 24 * time.Hour
+
+// CalendarServiceImpl implements domain.CalendarService
+type CalendarServiceImpl struct {
+	repo   domain.CalendarRepository
+	logger *logger.Logger
+}
+
+// NewCalendarService creates a new CalendarService
+func NewCalendarService(repo domain.CalendarRepository, logger *logger.Logger) domain.CalendarService {
+	return &CalendarServiceImpl{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetOpeningHours retrieves the opening hours of each day of the week
+func (s *CalendarServiceImpl) GetOpeningHours() ([]*domain.OpeningHours, error) {
+	hours, err := s.repo.ListOpeningHours()
+	if err != nil {
+		s.logger.Error("Failed to list opening hours", zap.Error(err))
+		return nil, err
+	}
+	return hours, nil
+}
+
+// UpdateOpeningHours sets the opening hours of the given days of the week, the other days keep
+// theirs. The library must stay open on at least one day of the week.
+func (s *CalendarServiceImpl) UpdateOpeningHours(hours []*domain.OpeningHours) ([]*domain.OpeningHours, error) {
+	if len(hours) == 0 {
+		return nil, domain.NewInvalidInputError("at least one day of the week is required")
+	}
+
+	current, err := s.repo.ListOpeningHours()
+	if err != nil {
+		s.logger.Error("Failed to list opening hours", zap.Error(err))
+		return nil, err
+	}
+
+	week := make(map[time.Weekday]bool)
+	for _, h := range current {
+		week[h.Weekday] = h.Closed
+	}
+
+	seen := make(map[time.Weekday]bool)
+	for _, h := range hours {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid weekday %d, use 0 (Sunday) to 6 (Saturday)", h.Weekday))
+		}
+		if seen[h.Weekday] {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("%s is listed more than once", h.Weekday))
+		}
+		seen[h.Weekday] = true
+
+		if !h.Closed {
+			opensAt, err := time.Parse("15:04", h.OpensAt)
+			if err != nil {
+				return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid opening time for %s, use HH:MM", h.Weekday))
+			}
+			closesAt, err := time.Parse("15:04", h.ClosesAt)
+			if err != nil {
+				return nil, domain.NewInvalidInputError(fmt.Sprintf("invalid closing time for %s, use HH:MM", h.Weekday))
+			}
+			if !opensAt.Before(closesAt) {
+				return nil, domain.NewInvalidInputError(fmt.Sprintf("the library must open before it closes on %s", h.Weekday))
+			}
+		}
+		week[h.Weekday] = h.Closed
+	}
+
+	open := false
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !week[day] {
+			open = true
+		}
+	}
+	if !open {
+		return nil, domain.NewInvalidInputError("the library must be open on at least one day of the week")
+	}
+
+	updatedHours, err := s.repo.UpdateOpeningHours(hours)
+	if err != nil {
+		s.logger.Error("Failed to update opening hours", zap.Error(err))
+		return nil, err
+	}
+
+	return updatedHours, nil
+}
+
+// ListClosures retrieves the closures from one date up to and including another
+func (s *CalendarServiceImpl) ListClosures(from, to time.Time) ([]*domain.Closure, error) {
+	if to.Before(from) {
+		return nil, domain.NewInvalidInputError("the end date must not be before the start date")
+	}
+
+	closures, err := s.repo.ListClosures(from, to)
+	if err != nil {
+		s.logger.Error("Failed to list closures", zap.Error(err))
+		return nil, err
+	}
+	return closures, nil
+}
+
+// CreateClosure closes the library on a date. Rentals that are already due that day keep their
+// due date, but the day does not count towards their fines.
+func (s *CalendarServiceImpl) CreateClosure(closure *domain.Closure) (*domain.Closure, error) {
+	closure.Reason = strings.TrimSpace(closure.Reason)
+	if closure.Reason == "" {
+		return nil, domain.NewInvalidInputError("a reason is required to close the library")
+	}
+	closure.Source = domain.ClosureSourceManual
+
+	createdClosure, err := s.repo.CreateClosure(closure)
+	if err != nil {
+		s.logger.Error("Failed to create closure", zap.Time("date", closure.Date), zap.Error(err))
+		return nil, err
+	}
+
+	return createdClosure, nil
+}
+
+// DeleteClosure reopens the library on the date of a closure
+func (s *CalendarServiceImpl) DeleteClosure(id int64) error {
+	err := s.repo.DeleteClosure(id)
+	if err != nil {
+		s.logger.Error("Failed to delete closure", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ImportICal adds the days covered by the events of an iCalendar file as closures, with the
+// event summary as the reason. Recurring and cancelled events are skipped, as are dates the
+// library is already closed on. Event times are taken in the server's time zone, which due dates
+// are also set in.
+func (s *CalendarServiceImpl) ImportICal(file io.Reader, importedBy int64) (*domain.ClosureImportResult, error) {
+	closures, skipped, err := parseICalClosures(file, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, closure := range closures {
+		closure.Source = domain.ClosureSourceICal
+		closure.CreatedBy = &importedBy
+	}
+
+	imported, err := s.repo.ImportClosures(closures)
+	if err != nil {
+		s.logger.Error("Failed to import closures", zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Closures imported",
+		zap.String("event", "closures_imported"),
+		zap.Int("imported", imported),
+		zap.Int("skipped", skipped),
+		zap.Int64("importedBy", importedBy))
+
+	return &domain.ClosureImportResult{
+		Imported:   imported,
+		Duplicates: len(closures) - imported,
+		Skipped:    skipped,
+	}, nil
+}
+
+// Helper functions
+
+// loadLibraryCalendar loads the opening hours and the closures from one date up to a year past
+// another, far enough to roll a date in that range to the next open day
+func loadLibraryCalendar(repo domain.CalendarRepository, from, to time.Time) (*domain.LibraryCalendar, error) {
+	hours, err := repo.ListOpeningHours()
+	if err != nil {
+		return nil, err
+	}
+
+	closures, err := repo.ListClosures(from, to.Add(calendarHorizon))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewLibraryCalendar(hours, closures), nil
+}
+
+// icalEvent holds the properties of a VEVENT used to record closures
+type icalEvent struct {
+	start     string
+	startTZID string
+	end       string
+	endTZID   string
+	summary   string
+	recurring bool
+	cancelled bool
+}
+
+// parseICalClosures reads the events of an iCalendar file and returns a closure for each day
+// they cover in the given location, along with the number of events that were skipped
+func parseICalClosures(file io.Reader, loc *time.Location) ([]*domain.Closure, int, error) {
+	lines, err := unfoldICalLines(file)
+	if err != nil {
+		return nil, 0, domain.NewInvalidInputError("the calendar file could not be read")
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, 0, domain.NewInvalidInputError("the file is not an iCalendar file")
+	}
+
+	closures := []*domain.Closure{}
+	seen := make(map[string]bool)
+	skipped := 0
+
+	var event *icalEvent
+	for _, line := range lines {
+		name, params, value := splitICalProperty(line)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &icalEvent{}
+		case event == nil:
+			continue
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			days, ok := event.days(loc)
+			if !ok {
+				skipped++
+			}
+			for _, day := range days {
+				key := day.Format("2006-01-02")
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				closures = append(closures, &domain.Closure{Date: day, Reason: event.reason()})
+			}
+			if len(closures) > maxImportedClosures {
+				return nil, 0, domain.NewInvalidInputError(fmt.Sprintf("the calendar file may close the library on at most %d days", maxImportedClosures))
+			}
+			event = nil
+		case name == "DTSTART":
+			event.start, event.startTZID = value, params["TZID"]
+		case name == "DTEND":
+			event.end, event.endTZID = value, params["TZID"]
+		case name == "SUMMARY":
+			event.summary = unescapeICalText(value)
+		case name == "RRULE" || name == "RDATE":
+			event.recurring = true
+		case name == "STATUS":
+			event.cancelled = strings.EqualFold(value, "CANCELLED")
+		}
+	}
+
+	return closures, skipped, nil
+}
+
+// days returns the days an event covers in the given location. An end date or an end at
+// midnight is exclusive, a timed event covers every day it overlaps.
+func (e *icalEvent) days(loc *time.Location) ([]time.Time, bool) {
+	if e.recurring || e.cancelled {
+		return nil, false
+	}
+
+	start, err := parseICalTime(e.start, e.startTZID, loc)
+	if err != nil {
+		return nil, false
+	}
+
+	first := icalDay(start)
+	last := first
+	if e.end != "" {
+		end, err := parseICalTime(e.end, e.endTZID, loc)
+		if err != nil || end.Before(start) {
+			return nil, false
+		}
+		last = icalDay(end)
+		endsAtMidnight := end.Hour() == 0 && end.Minute() == 0 && end.Second() == 0
+		if endsAtMidnight && last.After(first) {
+			last = last.AddDate(0, 0, -1)
+		}
+	}
+
+	days := []time.Time{}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if len(days) == maxClosureEventDays {
+			return nil, false
+		}
+		days = append(days, day)
+	}
+	return days, true
+}
+
+// reason returns the summary of an event to record as the reason for the closure
+func (e *icalEvent) reason() string {
+	reason := strings.TrimSpace(e.summary)
+	if reason == "" {
+		return "Closed"
+	}
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+	return reason
+}
+
+// unfoldICalLines reads the lines of an iCalendar file, joining the lines folded onto the next
+func unfoldICalLines(file io.Reader) ([]string, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitICalProperty splits a content line into its upper-cased property name, its parameters
+// keyed by upper-cased name and its value
+func splitICalProperty(line string) (name string, params map[string]string, value string) {
+	nameAndParams, value, _ := strings.Cut(line, ":")
+	name, rawParams, _ := strings.Cut(nameAndParams, ";")
+
+	params = make(map[string]string)
+	for _, param := range strings.Split(rawParams, ";") {
+		if key, paramValue, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(paramValue, `"`)
+		}
+	}
+	return strings.ToUpper(name), params, value
+}
+
+// parseICalTime parses a DATE or DATE-TIME value into the given location. Dates start at
+// midnight there. Times in UTC or in the time zone named by tzid are converted; floating times,
+// and times in a time zone that is not known, are taken as they are.
+func parseICalTime(value, tzid string, loc *time.Location) (time.Time, error) {
+	switch {
+	case len(value) == 8:
+		return time.ParseInLocation("20060102", value, loc)
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t.In(loc), err
+	}
+
+	valueLoc := loc
+	if tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			valueLoc = tz
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, valueLoc)
+	return t.In(loc), err
+}
+
+// icalDay returns the date of t as midnight UTC, the way closure dates are stored
+func icalDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// unescapeICalText unescapes the commas, semicolons, backslashes and newlines of a TEXT value
+func unescapeICalText(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICalClosures(t *testing.T) {
+	tests := []struct {
+		name        string
+		events      string
+		loc         *time.Location
+		wantDates   []string
+		wantReason  string
+		wantSkipped int
+	}{
+		{
+			name:       "folded summary",
+			events:     "BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251225\r\nSUMMARY:Christmas\r\n  Day\r\nEND:VEVENT\r\n",
+			loc:        time.UTC,
+			wantDates:  []string{"2025-12-25"},
+			wantReason: "Christmas Day",
+		},
+		{
+			name:       "all-day end date is exclusive",
+			events:     "BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251224\r\nDTEND;VALUE=DATE:20251226\r\nSUMMARY:Holidays\r\nEND:VEVENT\r\n",
+			loc:        time.UTC,
+			wantDates:  []string{"2025-12-24", "2025-12-25"},
+			wantReason: "Holidays",
+		},
+		{
+			name:       "timed event covers every day it overlaps",
+			events:     "BEGIN:VEVENT\r\nDTSTART:20251224T120000\r\nDTEND:20251225T100000\r\nEND:VEVENT\r\n",
+			loc:        time.UTC,
+			wantDates:  []string{"2025-12-24", "2025-12-25"},
+			wantReason: "Closed",
+		},
+		{
+			name: "recurring and cancelled events are skipped",
+			events: "BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251231\r\nRRULE:FREQ=YEARLY\r\nEND:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251226\r\nSTATUS:CANCELLED\r\nEND:VEVENT\r\n",
+			loc:         time.UTC,
+			wantDates:   []string{},
+			wantSkipped: 2,
+		},
+		{
+			name:       "UTC times are converted to the library's time zone",
+			events:     "BEGIN:VEVENT\r\nDTSTART:20251231T230000Z\r\nDTEND:20260101T140000Z\r\nSUMMARY:New Year\r\nEND:VEVENT\r\n",
+			loc:        time.FixedZone("UTC+9", 9*60*60),
+			wantDates:  []string{"2026-01-01"},
+			wantReason: "New Year",
+		},
+		{
+			name:       "TZID times are converted to the library's time zone",
+			events:     "BEGIN:VEVENT\r\nDTSTART;TZID=America/New_York:20251231T220000\r\nDTEND;TZID=America/New_York:20260101T120000\r\nSUMMARY:New Year\r\nEND:VEVENT\r\n",
+			loc:        time.UTC,
+			wantDates:  []string{"2026-01-01"},
+			wantReason: "New Year",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := strings.NewReader("BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + tt.events + "END:VCALENDAR\r\n")
+
+			closures, skipped, err := parseICalClosures(file, tt.loc)
+			if err != nil {
+				t.Fatalf("parseICalClosures() error = %v", err)
+			}
+			if skipped != tt.wantSkipped {
+				t.Errorf("parseICalClosures() skipped = %d, want %d", skipped, tt.wantSkipped)
+			}
+
+			dates := []string{}
+			for _, closure := range closures {
+				dates = append(dates, closure.Date.Format("2006-01-02"))
+				if closure.Reason != tt.wantReason {
+					t.Errorf("parseICalClosures() reason = %q, want %q", closure.Reason, tt.wantReason)
+				}
+			}
+			if strings.Join(dates, ",") != strings.Join(tt.wantDates, ",") {
+				t.Errorf("parseICalClosures() dates = %v, want %v", dates, tt.wantDates)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+)
+
+// exportSection is one kind of record in a data export, written both as JSON and as CSV
+type exportSection struct {
+	name    string
+	records interface{}
+	header  []string
+	rows    [][]string
+}
+
+// buildExportArchive writes a user's data into a ZIP archive with a JSON and a CSV file per section
+func buildExportArchive(data *domain.UserData) ([]byte, error) {
+	sections := []exportSection{
+		profileSection(data.Profile),
+		rentalSection(data.Rentals),
+		paymentSection(data.Payments),
+		sessionSection(data.Sessions),
+		auditEventSection(data.AuditEvents),
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	for _, section := range sections {
+		w, err := zw.Create(section.name + ".json")
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(section.records); err != nil {
+			return nil, err
+		}
+
+		w, err = zw.Create(section.name + ".csv")
+		if err != nil {
+			return nil, err
+		}
+		cw := csv.NewWriter(w)
+		if err := cw.Write(section.header); err != nil {
+			return nil, err
+		}
+		if err := cw.WriteAll(section.rows); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func profileSection(user *domain.User) exportSection {
+	return exportSection{
+		name:    "profile",
+		records: user,
+		header:  []string{"id", "username", "email", "first_name", "last_name", "role", "status", "email_verified_at", "membership_plan_id", "created_at", "updated_at"},
+		rows: [][]string{{
+			formatID(user.ID),
+			csvText(user.Username),
+			csvText(user.Email),
+			csvText(user.FirstName),
+			csvText(user.LastName),
+			string(user.Role),
+			string(user.Status),
+			formatOptionalTime(user.EmailVerifiedAt),
+			formatOptionalID(user.MembershipPlanID),
+			formatTime(user.CreatedAt),
+			formatTime(user.UpdatedAt),
+		}},
+	}
+}
+
+func rentalSection(rentals []*domain.Rental) exportSection {
+	rows := make([][]string, len(rentals))
+	for i, r := range rentals {
+		rows[i] = []string{
+			formatID(r.ID),
+			formatID(r.BookID),
+			csvText(r.BookTitle),
+			csvText(r.BookAuthor),
+			formatTime(r.RentalDate),
+			formatTime(r.DueDate),
+			formatOptionalTime(r.ReturnDate),
+			string(r.Status),
+			strconv.Itoa(r.ExtensionCount),
+		}
+	}
+
+	return exportSection{
+		name:    "rentals",
+		records: rentals,
+		header:  []string{"id", "book_id", "book_title", "book_author", "rental_date", "due_date", "return_date", "status", "extension_count"},
+		rows:    rows,
+	}
+}
+
+func paymentSection(payments []*domain.Payment) exportSection {
+	rows := make([][]string, len(payments))
+	for i, p := range payments {
+		rows[i] = []string{
+			formatID(p.ID),
+			formatOptionalID(p.RentalID),
+			csvText(p.BookTitle),
+			strconv.FormatFloat(p.Amount, 'f', 2, 64),
+			formatTime(p.PaymentDate),
+			csvText(p.PaymentMethod),
+			string(p.Status),
+			csvText(p.TransactionID),
+		}
+	}
+
+	return exportSection{
+		name:    "payments",
+		records: payments,
+		header:  []string{"id", "rental_id", "book_title", "amount", "payment_date", "payment_method", "status", "transaction_id"},
+		rows:    rows,
+	}
+}
+
+func sessionSection(sessions []*domain.Session) exportSection {
+	rows := make([][]string, len(sessions))
+	for i, s := range sessions {
+		rows[i] = []string{
+			formatID(s.ID),
+			csvText(s.UserAgent),
+			s.IPAddress,
+			strconv.FormatBool(s.MFAVerified),
+			formatTime(s.CreatedAt),
+			formatTime(s.LastUsedAt),
+			formatTime(s.ExpiresAt),
+			formatOptionalTime(s.RevokedAt),
+			s.RevokedReason,
+		}
+	}
+
+	return exportSection{
+		name:    "sessions",
+		records: sessions,
+		header:  []string{"id", "user_agent", "ip_address", "mfa_verified", "created_at", "last_used_at", "expires_at", "revoked_at", "revoked_reason"},
+		rows:    rows,
+	}
+}
+
+// auditEventSection exports the recorded sign-in attempts of the user
+func auditEventSection(attempts []*domain.LoginAttempt) exportSection {
+	rows := make([][]string, len(attempts))
+	for i, a := range attempts {
+		event := "login_failed"
+		if a.Success {
+			event = "login_succeeded"
+		}
+		rows[i] = []string{
+			formatID(a.ID),
+			event,
+			csvText(a.Username),
+			a.IPAddress,
+			formatTime(a.CreatedAt),
+		}
+	}
+
+	return exportSection{
+		name:    "audit_events",
+		records: attempts,
+		header:  []string{"id", "event", "username", "ip_address", "created_at"},
+		rows:    rows,
+	}
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return formatID(*id)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+// csvText escapes free text that spreadsheets would otherwise evaluate as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/SimpleBookRental/backend/pkg/mailer"
+	"go.uber.org/zap"
+)
+
+// exportPageSize is the page size used to read a user's rentals and payments for an export
+const exportPageSize int32 = 500
+
+// staleExportAge is how long a background export can stay pending before it is taken to have
+// been interrupted, e.g. by a restart, and is marked as failed
+const staleExportAge = time.Hour
+
+// DataExportServiceImpl implements domain.DataExportService
+type DataExportServiceImpl struct {
+	repo             domain.DataExportRepository
+	userRepo         domain.UserRepository
+	rentalRepo       domain.RentalRepository
+	paymentRepo      domain.PaymentRepository
+	sessionRepo      domain.SessionRepository
+	loginAttemptRepo domain.LoginAttemptRepository
+	mailer           mailer.Mailer
+	config           config.DataExportConfig
+	logger           *logger.Logger
+}
+
+// NewDataExportService creates a new DataExportService
+func NewDataExportService(repo domain.DataExportRepository, userRepo domain.UserRepository, rentalRepo domain.RentalRepository, paymentRepo domain.PaymentRepository, sessionRepo domain.SessionRepository, loginAttemptRepo domain.LoginAttemptRepository, mailer mailer.Mailer, config config.DataExportConfig, logger *logger.Logger) domain.DataExportService {
+	return &DataExportServiceImpl{
+		repo:             repo,
+		userRepo:         userRepo,
+		rentalRepo:       rentalRepo,
+		paymentRepo:      paymentRepo,
+		sessionRepo:      sessionRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		mailer:           mailer,
+		config:           config,
+		logger:           logger,
+	}
+}
+
+// Export exports everything stored about a user as a ZIP archive. Small exports are built
+// right away; exports with more records than configured, or when async is requested, are
+// generated in the background and downloaded later through an expiring link. Only one
+// background export can be pending or downloadable per user at a time.
+func (s *DataExportServiceImpl) Export(userID int64, async bool) ([]byte, *domain.DataExport, error) {
+	if !async {
+		count, err := s.repo.CountUserRecords(userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		async = count > s.config.SyncMaxRecords
+	}
+
+	if !async {
+		data, err := s.collect(userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		archive, err := buildExportArchive(data)
+		if err != nil {
+			s.logger.Error("Failed to build data export", zap.Int64("userID", userID), zap.Error(err))
+			return nil, nil, err
+		}
+
+		s.logger.Info("Personal data exported",
+			zap.String("event", "data_exported"),
+			zap.Int64("userID", userID),
+			zap.Int("sizeBytes", len(archive)))
+		return archive, nil, nil
+	}
+
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		s.logger.Error("Failed to generate export token", zap.Error(err))
+		return nil, nil, err
+	}
+
+	export, err := s.repo.Create(&domain.DataExport{
+		UserID:    userID,
+		Status:    domain.DataExportStatusPending,
+		TokenHash: auth.HashOpaqueToken(token),
+		ExpiresAt: time.Now().Add(s.config.LinkExpiration),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	export.DownloadURL = fmt.Sprintf("%s?token=%s", s.config.DownloadURL, token)
+
+	s.logger.Info("Personal data export requested",
+		zap.String("event", "data_export_requested"),
+		zap.Int64("userID", userID),
+		zap.Int64("exportID", export.ID))
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.failExport(export.ID, fmt.Errorf("panic: %v", r))
+			}
+		}()
+		s.generate(export.ID, userID, export.DownloadURL)
+	}()
+
+	return nil, export, nil
+}
+
+// GetByID retrieves a background export of the given user
+func (s *DataExportServiceImpl) GetByID(id, userID int64) (*domain.DataExport, error) {
+	export, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other users' exports are reported as missing rather than forbidden
+	if export.UserID != userID {
+		return nil, domain.ErrDataExportNotFound
+	}
+
+	return export, nil
+}
+
+// Download retrieves a finished export by the token of its download link
+func (s *DataExportServiceImpl) Download(token string) (*domain.DataExport, error) {
+	export, err := s.repo.GetByTokenHash(auth.HashOpaqueToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	switch export.Status {
+	case domain.DataExportStatusPending:
+		return nil, domain.ErrDataExportNotReady
+	case domain.DataExportStatusFailed:
+		return nil, domain.ErrDataExportNotFound
+	}
+
+	s.logger.Info("Personal data export downloaded",
+		zap.String("event", "data_export_downloaded"),
+		zap.Int64("userID", export.UserID),
+		zap.Int64("exportID", export.ID))
+	return export, nil
+}
+
+// PurgeExpired deletes exports whose download link has expired
+func (s *DataExportServiceImpl) PurgeExpired() (int64, error) {
+	deleted, err := s.repo.DeleteExpired()
+	if err != nil {
+		s.logger.Error("Failed to purge expired data exports", zap.Error(err))
+		return 0, err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Purged expired data exports", zap.Int64("count", deleted))
+	}
+	return deleted, nil
+}
+
+// FailStale marks background exports that have been pending for too long as failed. Their
+// goroutine was lost, e.g. to a restart, so they would otherwise stay pending until they expire
+// and keep the user from requesting a new one.
+func (s *DataExportServiceImpl) FailStale() (int64, error) {
+	failed, err := s.repo.FailPendingBefore(time.Now().Add(-staleExportAge), "the export was interrupted, please request a new one")
+	if err != nil {
+		s.logger.Error("Failed to fail stale data exports", zap.Error(err))
+		return 0, err
+	}
+
+	if failed > 0 {
+		s.logger.Warn("Stale data exports failed", zap.String("event", "data_exports_failed"), zap.Int64("count", failed))
+	}
+	return failed, nil
+}
+
+// generate builds a background export and emails the download link to the user once it is ready
+func (s *DataExportServiceImpl) generate(exportID, userID int64, downloadURL string) {
+	data, err := s.collect(userID)
+	if err == nil {
+		var archive []byte
+		archive, err = buildExportArchive(data)
+		if err == nil {
+			err = s.repo.Complete(exportID, archive, time.Now().Add(s.config.LinkExpiration))
+		}
+	}
+
+	if err != nil {
+		s.failExport(exportID, err)
+		return
+	}
+
+	s.logger.Info("Personal data export generated",
+		zap.String("event", "data_exported"),
+		zap.Int64("userID", userID),
+		zap.Int64("exportID", exportID))
+
+	err = s.mailer.Send(&mailer.Message{
+		To:      data.Profile.Email,
+		Subject: "Your data export is ready",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"The export of your personal data you requested is ready. Download it here:\n\n"+
+			"%s\n\n"+
+			"The link expires in %s.\n",
+			data.Profile.Username, downloadURL, s.config.LinkExpiration),
+	})
+	if err != nil {
+		s.logger.Error("Failed to send data export email", zap.Int64("userID", userID), zap.Error(err))
+	}
+}
+
+// failExport marks a background export as failed
+func (s *DataExportServiceImpl) failExport(exportID int64, cause error) {
+	s.logger.Error("Failed to generate data export", zap.Int64("exportID", exportID), zap.Error(cause))
+	if err := s.repo.Fail(exportID, "the export could not be generated"); err != nil {
+		s.logger.Error("Failed to mark data export as failed", zap.Int64("exportID", exportID), zap.Error(err))
+	}
+}
+
+// collect gathers everything stored about a user
+func (s *DataExportServiceImpl) collect(userID int64) (*domain.UserData, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &domain.UserData{
+		Profile:     user,
+		Rentals:     []*domain.Rental{},
+		Payments:    []*domain.Payment{},
+		Sessions:    []*domain.Session{},
+		AuditEvents: []*domain.LoginAttempt{},
+	}
+
+	for offset := int32(0); ; offset += exportPageSize {
+		rentals, err := s.rentalRepo.ListByUser(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		data.Rentals = append(data.Rentals, rentals...)
+		if len(rentals) < int(exportPageSize) {
+			break
+		}
+	}
+
+	for offset := int32(0); ; offset += exportPageSize {
+		payments, err := s.paymentRepo.ListByUser(userID, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		data.Payments = append(data.Payments, payments...)
+		if len(payments) < int(exportPageSize) {
+			break
+		}
+	}
+
+	sessions, err := s.sessionRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	data.Sessions = append(data.Sessions, sessions...)
+
+	attempts, err := s.loginAttemptRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	data.AuditEvents = append(data.AuditEvents, attempts...)
+
+	return data, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/auth"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/SimpleBookRental/backend/pkg/mailer"
+	"go.uber.org/zap"
+)
+
+// EmailVerifierImpl implements domain.EmailVerifier
+type EmailVerifierImpl struct {
+	repo   domain.EmailVerificationRepository
+	mailer mailer.Mailer
+	config config.AuthConfig
+	logger *logger.Logger
+}
+
+// NewEmailVerifier creates a new EmailVerifier
+func NewEmailVerifier(repo domain.EmailVerificationRepository, mailer mailer.Mailer, config config.AuthConfig, logger *logger.Logger) domain.EmailVerifier {
+	return &EmailVerifierImpl{
+		repo:   repo,
+		mailer: mailer,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Send stores a new verification token for the user and emails the link to their address.
+// Links sent earlier stay valid until they expire.
+func (v *EmailVerifierImpl) Send(user *domain.User) error {
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		v.logger.Error("Failed to generate verification token", zap.Error(err))
+		return err
+	}
+
+	_, err = v.repo.Create(&domain.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashOpaqueToken(token),
+		ExpiresAt: time.Now().Add(v.config.EmailVerificationExpiration),
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.mailer.Send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"Please confirm your email address by opening the link below:\n\n"+
+			"%s?token=%s\n\n"+
+			"The link expires in %s.\n",
+			user.Username, v.config.EmailVerificationURL, token, v.config.EmailVerificationExpiration),
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// FineServiceImpl implements domain.FineService
+type FineServiceImpl struct {
+	repo       domain.FineRepository
+	rentalRepo domain.RentalRepository
+	logger     *logger.Logger
+}
+
+// NewFineService creates a new FineService
+func NewFineService(repo domain.FineRepository, rentalRepo domain.RentalRepository, logger *logger.Logger) domain.FineService {
+	return &FineServiceImpl{
+		repo:       repo,
+		rentalRepo: rentalRepo,
+		logger:     logger,
+	}
+}
+
+// GetByID retrieves a fine by ID
+func (s *FineServiceImpl) GetByID(id int64) (*domain.Fine, error) {
+	fine, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get fine by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return fine, nil
+}
+
+// GetByRental retrieves the fine assessed for a rental
+func (s *FineServiceImpl) GetByRental(rentalID int64) (*domain.Fine, error) {
+	// Check if rental exists
+	if _, err := s.rentalRepo.GetByID(rentalID); err != nil {
+		s.logger.Error("Failed to get rental by ID", zap.Int64("rentalID", rentalID), zap.Error(err))
+		return nil, err
+	}
+
+	fine, err := s.repo.GetByRental(rentalID)
+	if err != nil {
+		s.logger.Error("Failed to get fine by rental", zap.Int64("rentalID", rentalID), zap.Error(err))
+		return nil, err
+	}
+	return fine, nil
+}
+
+// List retrieves fines with pagination, optionally only those with the given status
+func (s *FineServiceImpl) List(status domain.FineStatus, limit, offset int32) ([]*domain.Fine, error) {
+	if status != "" && status != domain.FineStatusOutstanding && status != domain.FineStatusPaid && status != domain.FineStatusWaived {
+		return nil, domain.NewInvalidInputError("invalid status: " + string(status))
+	}
+
+	fines, err := s.repo.List(status, limit, offset)
+	if err != nil {
+		s.logger.Error("Failed to list fines", zap.Error(err))
+		return nil, err
+	}
+	return fines, nil
+}
+
+// ListByUser retrieves every fine of a user
+func (s *FineServiceImpl) ListByUser(userID int64) ([]*domain.Fine, error) {
+	fines, err := s.repo.ListByUser(userID)
+	if err != nil {
+		s.logger.Error("Failed to list fines by user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return fines, nil
+}
+
+// Waive waives what is left of an outstanding fine. Waivers are recorded with the librarian
+// who granted them and the reason they gave.
+func (s *FineServiceImpl) Waive(id, waivedBy int64, reason string) (*domain.Fine, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domain.NewInvalidInputError("a reason is required to waive a fine")
+	}
+
+	fine, err := s.repo.Waive(id, waivedBy, reason)
+	if err != nil {
+		s.logger.Error("Failed to waive fine", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Fine waived",
+		zap.String("event", "fine_waived"),
+		zap.Int64("fineID", fine.ID),
+		zap.Int64("userID", fine.UserID),
+		zap.Int64("waivedBy", waivedBy),
+		zap.Float64("waivedAmount", fine.Amount-fine.AmountPaid))
+
+	return fine, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// HouseholdServiceImpl implements domain.HouseholdService
+type HouseholdServiceImpl struct {
+	repo     domain.HouseholdRepository
+	userRepo domain.UserRepository
+	logger   *logger.Logger
+}
+
+// NewHouseholdService creates a new HouseholdService
+func NewHouseholdService(repo domain.HouseholdRepository, userRepo domain.UserRepository, logger *logger.Logger) domain.HouseholdService {
+	return &HouseholdServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// GetByID retrieves a household by ID
+func (s *HouseholdServiceImpl) GetByID(id int64) (*domain.Household, error) {
+	household, err := s.repo.GetByID(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrHouseholdNotFound) {
+			s.logger.Error("Failed to get household by ID", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+// GetForUser retrieves the household a user is the guardian or a dependent of
+func (s *HouseholdServiceImpl) GetForUser(userID int64) (*domain.Household, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	household, err := s.repo.GetByMember(userID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrHouseholdNotFound) {
+			s.logger.Error("Failed to get household for user", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+// List retrieves all households
+func (s *HouseholdServiceImpl) List() ([]*domain.Household, error) {
+	households, err := s.repo.List()
+	if err != nil {
+		s.logger.Error("Failed to list households", zap.Error(err))
+		return nil, err
+	}
+	return households, nil
+}
+
+// Create creates a household for a guardian who does not belong to a household yet
+func (s *HouseholdServiceImpl) Create(household *domain.Household, createdBy int64) (*domain.Household, error) {
+	if _, err := s.userRepo.GetByID(household.GuardianID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotInHousehold(household.GuardianID); err != nil {
+		return nil, err
+	}
+
+	createdHousehold, err := s.repo.Create(household)
+	if err != nil {
+		if !errors.Is(err, domain.ErrAlreadyInHousehold) {
+			s.logger.Error("Failed to create household", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Household created",
+		zap.String("event", "household_created"),
+		zap.Int64("householdID", createdHousehold.ID),
+		zap.Int64("guardianID", createdHousehold.GuardianID),
+		zap.Int64("createdBy", createdBy))
+	return createdHousehold, nil
+}
+
+// Update updates the name and shared rental limit of a household
+func (s *HouseholdServiceImpl) Update(household *domain.Household) (*domain.Household, error) {
+	updatedHousehold, err := s.repo.Update(household)
+	if err != nil {
+		if !errors.Is(err, domain.ErrHouseholdNotFound) {
+			s.logger.Error("Failed to update household", zap.Int64("id", household.ID), zap.Error(err))
+		}
+		return nil, err
+	}
+	return updatedHousehold, nil
+}
+
+// Delete deletes a household, unlinking the guardian from the dependents
+func (s *HouseholdServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrHouseholdNotFound) {
+			s.logger.Error("Failed to delete household", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+	return nil
+}
+
+// AddDependent links a user who does not belong to a household yet to a household
+func (s *HouseholdServiceImpl) AddDependent(householdID, userID, addedBy int64) (*domain.Household, error) {
+	household, err := s.repo.GetByID(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == household.GuardianID {
+		return nil, domain.NewInvalidInputError("the guardian cannot be their own dependent")
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotInHousehold(userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddDependent(householdID, userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Household dependent added",
+		zap.String("event", "household_dependent_added"),
+		zap.Int64("householdID", householdID),
+		zap.Int64("userID", userID),
+		zap.Int64("addedBy", addedBy))
+	return s.repo.GetByID(householdID)
+}
+
+// RemoveDependent unlinks a dependent from a household
+func (s *HouseholdServiceImpl) RemoveDependent(householdID, userID, removedBy int64) (*domain.Household, error) {
+	if _, err := s.repo.GetByID(householdID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveDependent(householdID, userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Household dependent removed",
+		zap.String("event", "household_dependent_removed"),
+		zap.Int64("householdID", householdID),
+		zap.Int64("userID", userID),
+		zap.Int64("removedBy", removedBy))
+	return s.repo.GetByID(householdID)
+}
+
+// checkNotInHousehold makes sure a user is neither a guardian nor a dependent, so
+// each user belongs to at most one household
+func (s *HouseholdServiceImpl) checkNotInHousehold(userID int64) error {
+	_, err := s.repo.GetByMember(userID)
+	if err == nil {
+		return domain.ErrAlreadyInHousehold
+	}
+	if !errors.Is(err, domain.ErrHouseholdNotFound) {
+		s.logger.Error("Failed to check household membership", zap.Int64("userID", userID), zap.Error(err))
+		return err
+	}
+	return nil
+}
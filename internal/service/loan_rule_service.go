@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LoanRuleServiceImpl implements domain.LoanRuleService
+type LoanRuleServiceImpl struct {
+	repo         domain.LoanRuleRepository
+	bookRepo     domain.BookRepository
+	categoryRepo domain.CategoryRepository
+	planRepo     domain.MembershipPlanRepository
+	userRepo     domain.UserRepository
+	config       config.RentalConfig
+	logger       *logger.Logger
+}
+
+// NewLoanRuleService creates a new LoanRuleService
+func NewLoanRuleService(repo domain.LoanRuleRepository, bookRepo domain.BookRepository, categoryRepo domain.CategoryRepository, planRepo domain.MembershipPlanRepository, userRepo domain.UserRepository, config config.RentalConfig, logger *logger.Logger) domain.LoanRuleService {
+	return &LoanRuleServiceImpl{
+		repo:         repo,
+		bookRepo:     bookRepo,
+		categoryRepo: categoryRepo,
+		planRepo:     planRepo,
+		userRepo:     userRepo,
+		config:       config,
+		logger:       logger,
+	}
+}
+
+// GetByID retrieves a loan rule by ID
+func (s *LoanRuleServiceImpl) GetByID(id int64) (*domain.LoanRule, error) {
+	rule, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get loan rule by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return rule, nil
+}
+
+// List retrieves all loan rules
+func (s *LoanRuleServiceImpl) List() ([]*domain.LoanRule, error) {
+	rules, err := s.repo.List()
+	if err != nil {
+		s.logger.Error("Failed to list loan rules", zap.Error(err))
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Create creates a new loan rule
+func (s *LoanRuleServiceImpl) Create(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	if err := s.validateLoanRule(rule); err != nil {
+		return nil, err
+	}
+
+	createdRule, err := s.repo.Create(rule)
+	if err != nil {
+		s.logger.Error("Failed to create loan rule", zap.Error(err))
+		return nil, err
+	}
+
+	return createdRule, nil
+}
+
+// Update updates an existing loan rule. New terms apply to future rentals, renewals and returns.
+func (s *LoanRuleServiceImpl) Update(rule *domain.LoanRule) (*domain.LoanRule, error) {
+	if _, err := s.repo.GetByID(rule.ID); err != nil {
+		s.logger.Error("Failed to get loan rule by ID", zap.Int64("id", rule.ID), zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.validateLoanRule(rule); err != nil {
+		return nil, err
+	}
+
+	updatedRule, err := s.repo.Update(rule)
+	if err != nil {
+		s.logger.Error("Failed to update loan rule", zap.Int64("id", rule.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return updatedRule, nil
+}
+
+// Delete deletes a loan rule, the rentals it matched fall back to less specific rules
+func (s *LoanRuleServiceImpl) Delete(id int64) error {
+	err := s.repo.Delete(id)
+	if err != nil {
+		s.logger.Error("Failed to delete loan rule", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Preview shows the loan terms that apply when a user rents a book
+func (s *LoanRuleServiceImpl) Preview(userID, bookID int64) (*domain.LoanRulePreview, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := resolveMembershipPlan(s.planRepo, user)
+	if err != nil {
+		s.logger.Error("Failed to resolve membership plan", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	rule, isDefault, err := resolveLoanRule(s.repo, book, plan, s.config.LateFeePerDay)
+	if err != nil {
+		s.logger.Error("Failed to resolve loan rule", zap.Int64("userID", userID), zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+
+	return &domain.LoanRulePreview{
+		UserID:           userID,
+		BookID:           bookID,
+		CategoryID:       book.CategoryID,
+		MembershipPlanID: plan.ID,
+		Rule:             rule,
+		Default:          isDefault,
+	}, nil
+}
+
+// validateLoanRule checks the terms of a rule and that the category, plan and book it
+// matches on exist
+func (s *LoanRuleServiceImpl) validateLoanRule(rule *domain.LoanRule) error {
+	if rule.LoanPeriodDays <= 0 {
+		return domain.NewInvalidInputError("loan period must be positive")
+	}
+	if rule.MaxRenewals < 0 || rule.FinePerDay < 0 || rule.GracePeriodDays < 0 || (rule.FineCap != nil && *rule.FineCap < 0) {
+		return domain.NewInvalidInputError("renewals, fines and grace period cannot be negative")
+	}
+
+	if rule.CategoryID != nil {
+		if _, err := s.categoryRepo.GetByID(*rule.CategoryID); err != nil {
+			return err
+		}
+	}
+	if rule.MembershipPlanID != nil {
+		if _, err := s.planRepo.GetByID(*rule.MembershipPlanID); err != nil {
+			return err
+		}
+	}
+	if rule.BookID != nil {
+		if _, err := s.bookRepo.GetByID(*rule.BookID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Helper functions
+
+// resolveLoanRule returns the most specific loan rule for renting a book on a plan. When no
+// rule matches, the plan's loan period and extensions apply with the default fine rate, and
+// the returned rule is marked as a default.
+func resolveLoanRule(repo domain.LoanRuleRepository, book *domain.Book, plan *domain.MembershipPlan, defaultFinePerDay float64) (*domain.LoanRule, bool, error) {
+	rule, err := repo.Match(book.ID, book.CategoryID, plan.ID)
+	if err == nil {
+		return rule, false, nil
+	}
+	if !errors.Is(err, domain.ErrLoanRuleNotFound) {
+		return nil, false, err
+	}
+
+	return &domain.LoanRule{
+		Name:             plan.Name + " plan",
+		MembershipPlanID: &plan.ID,
+		LoanPeriodDays:   plan.LoanPeriodDays,
+		MaxRenewals:      plan.MaxExtensions,
+		FinePerDay:       defaultFinePerDay,
+	}, true, nil
+}
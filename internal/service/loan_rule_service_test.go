@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResolveLoanRule(t *testing.T) {
+	book := &domain.Book{ID: 2, CategoryID: 3}
+	plan := &domain.MembershipPlan{ID: 1, Name: "basic", LoanPeriodDays: 14, MaxExtensions: 2}
+	dbErr := errors.New("connection refused")
+
+	t.Run("the matching rule applies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		// The repository picks the most specific rule for the book, its category and the plan
+		bookRule := &domain.LoanRule{ID: 7, Name: "Reference copy", BookID: &book.ID, LoanPeriodDays: 3}
+		repo := mocks.NewMockLoanRuleRepository(ctrl)
+		repo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(bookRule, nil)
+
+		rule, isDefault, err := resolveLoanRule(repo, book, plan, 0.25)
+		if err != nil {
+			t.Fatalf("resolveLoanRule() error = %v", err)
+		}
+		if rule != bookRule || isDefault {
+			t.Errorf("resolveLoanRule() = %+v, default %v, want the matched rule", rule, isDefault)
+		}
+	})
+
+	t.Run("the plan terms apply when no rule matches", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		repo := mocks.NewMockLoanRuleRepository(ctrl)
+		repo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(nil, domain.ErrLoanRuleNotFound)
+
+		rule, isDefault, err := resolveLoanRule(repo, book, plan, 0.25)
+		if err != nil {
+			t.Fatalf("resolveLoanRule() error = %v", err)
+		}
+		if !isDefault {
+			t.Errorf("resolveLoanRule() default = false, want true")
+		}
+		if rule.LoanPeriodDays != 14 || rule.MaxRenewals != 2 || rule.FinePerDay != 0.25 || rule.FineCap != nil {
+			t.Errorf("resolveLoanRule() = %+v, want the plan's loan period and renewals with the default fine", rule)
+		}
+	})
+
+	t.Run("lookup errors are returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		repo := mocks.NewMockLoanRuleRepository(ctrl)
+		repo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(nil, dbErr)
+
+		if _, _, err := resolveLoanRule(repo, book, plan, 0.25); !errors.Is(err, dbErr) {
+			t.Errorf("resolveLoanRule() error = %v, want %v", err, dbErr)
+		}
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// MembershipPlanServiceImpl implements domain.MembershipPlanService
+type MembershipPlanServiceImpl struct {
+	repo     domain.MembershipPlanRepository
+	userRepo domain.UserRepository
+	logger   *logger.Logger
+}
+
+// NewMembershipPlanService creates a new MembershipPlanService
+func NewMembershipPlanService(repo domain.MembershipPlanRepository, userRepo domain.UserRepository, logger *logger.Logger) domain.MembershipPlanService {
+	return &MembershipPlanServiceImpl{
+		repo:     repo,
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// GetByID retrieves a membership plan by ID
+func (s *MembershipPlanServiceImpl) GetByID(id int64) (*domain.MembershipPlan, error) {
+	plan, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get membership plan by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return plan, nil
+}
+
+// List retrieves all membership plans
+func (s *MembershipPlanServiceImpl) List() ([]*domain.MembershipPlan, error) {
+	plans, err := s.repo.List()
+	if err != nil {
+		s.logger.Error("Failed to list membership plans", zap.Error(err))
+		return nil, err
+	}
+	return plans, nil
+}
+
+// Create creates a new membership plan
+func (s *MembershipPlanServiceImpl) Create(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	// Check if plan name already exists
+	existingPlan, err := s.repo.GetByName(plan.Name)
+	if err == nil && existingPlan != nil {
+		return nil, domain.ErrMembershipPlanAlreadyExists
+	}
+	if err != nil && !errors.Is(err, domain.ErrMembershipPlanNotFound) {
+		s.logger.Error("Error checking membership plan name existence", zap.String("name", plan.Name), zap.Error(err))
+		return nil, err
+	}
+
+	createdPlan, err := s.repo.Create(plan)
+	if err != nil {
+		s.logger.Error("Failed to create membership plan", zap.Error(err))
+		return nil, err
+	}
+
+	return createdPlan, nil
+}
+
+// Update updates an existing membership plan
+func (s *MembershipPlanServiceImpl) Update(plan *domain.MembershipPlan) (*domain.MembershipPlan, error) {
+	existingPlan, err := s.repo.GetByID(plan.ID)
+	if err != nil {
+		s.logger.Error("Failed to get membership plan by ID", zap.Int64("id", plan.ID), zap.Error(err))
+		return nil, err
+	}
+
+	// There always has to be a default plan for members without an assigned plan
+	if existingPlan.IsDefault && !plan.IsDefault {
+		return nil, domain.NewInvalidInputError("the default plan can only be replaced by making another plan the default")
+	}
+
+	// Check if plan name is being changed and if it already exists
+	if plan.Name != existingPlan.Name {
+		planByName, err := s.repo.GetByName(plan.Name)
+		if err == nil && planByName != nil {
+			return nil, domain.ErrMembershipPlanAlreadyExists
+		}
+		if err != nil && !errors.Is(err, domain.ErrMembershipPlanNotFound) {
+			s.logger.Error("Error checking membership plan name existence", zap.String("name", plan.Name), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	updatedPlan, err := s.repo.Update(plan)
+	if err != nil {
+		s.logger.Error("Failed to update membership plan", zap.Int64("id", plan.ID), zap.Error(err))
+		return nil, err
+	}
+
+	return updatedPlan, nil
+}
+
+// Delete deletes a membership plan, its members fall back to the default plan
+func (s *MembershipPlanServiceImpl) Delete(id int64) error {
+	plan, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get membership plan by ID", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+
+	if plan.IsDefault {
+		return domain.NewInvalidInputError("the default plan cannot be deleted")
+	}
+
+	err = s.repo.Delete(id)
+	if err != nil {
+		s.logger.Error("Failed to delete membership plan", zap.Int64("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// GetForUser retrieves the membership plan that applies to a user
+func (s *MembershipPlanServiceImpl) GetForUser(userID int64) (*domain.MembershipPlan, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := resolveMembershipPlan(s.repo, user)
+	if err != nil {
+		s.logger.Error("Failed to resolve membership plan", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return plan, nil
+}
+
+// AssignToUser assigns a membership plan to a user, a nil plan ID moves the user to the default plan
+func (s *MembershipPlanServiceImpl) AssignToUser(userID int64, planID *int64, assignedBy int64) (*domain.MembershipPlan, error) {
+	var plan *domain.MembershipPlan
+	var err error
+	if planID != nil {
+		plan, err = s.repo.GetByID(*planID)
+	} else {
+		plan, err = s.repo.GetDefault()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepo.UpdateMembershipPlan(userID, planID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Error("Failed to assign membership plan", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Membership plan assigned",
+		zap.String("event", "membership_plan_assigned"),
+		zap.Int64("userID", userID),
+		zap.String("plan", plan.Name),
+		zap.Int64("assignedBy", assignedBy))
+	return plan, nil
+}
+
+// Helper functions
+
+// resolveMembershipPlan returns the plan assigned to a user or the default plan
+func resolveMembershipPlan(planRepo domain.MembershipPlanRepository, user *domain.User) (*domain.MembershipPlan, error) {
+	if user.MembershipPlanID != nil {
+		return planRepo.GetByID(*user.MembershipPlanID)
+	}
+	return planRepo.GetDefault()
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/breach"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxPasswordBytes is the longest password bcrypt can hash
+const maxPasswordBytes = 72
+
+// PasswordPolicyImpl implements domain.PasswordPolicy
+type PasswordPolicyImpl struct {
+	historyRepo domain.PasswordHistoryRepository
+	breached    *breach.Checker
+	config      config.PasswordPolicyConfig
+	logger      *logger.Logger
+}
+
+// NewPasswordPolicy creates a new PasswordPolicy, loading the breached password list if one is configured
+func NewPasswordPolicy(historyRepo domain.PasswordHistoryRepository, config config.PasswordPolicyConfig, logger *logger.Logger) (domain.PasswordPolicy, error) {
+	policy := &PasswordPolicyImpl{
+		historyRepo: historyRepo,
+		config:      config,
+		logger:      logger,
+	}
+
+	if config.BreachedListFile != "" {
+		source, err := breach.LoadFile(config.BreachedListFile)
+		if err != nil {
+			return nil, err
+		}
+		policy.breached = breach.NewChecker(source)
+	}
+
+	return policy, nil
+}
+
+// Validate checks a new password against every rule and reports all the rules it fails
+func (p *PasswordPolicyImpl) Validate(password string, user *domain.User) error {
+	var violations []domain.PasswordViolation
+	fail := func(rule, message string) {
+		violations = append(violations, domain.PasswordViolation{Rule: rule, Message: message})
+	}
+
+	if utf8.RuneCountInString(password) < p.config.MinLength {
+		fail(domain.PasswordRuleMinLength, fmt.Sprintf("must be at least %d characters long", p.config.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		fail(domain.PasswordRuleMaxLength, fmt.Sprintf("must be at most %d bytes long", maxPasswordBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.config.RequireUppercase && !hasUpper {
+		fail(domain.PasswordRuleUppercase, "must contain an uppercase letter")
+	}
+	if p.config.RequireLowercase && !hasLower {
+		fail(domain.PasswordRuleLowercase, "must contain a lowercase letter")
+	}
+	if p.config.RequireDigit && !hasDigit {
+		fail(domain.PasswordRuleDigit, "must contain a digit")
+	}
+	if p.config.RequireSymbol && !hasSymbol {
+		fail(domain.PasswordRuleSymbol, "must contain a symbol")
+	}
+
+	if user != nil && p.config.HistorySize > 0 {
+		reused, err := p.isReused(password, user)
+		if err != nil {
+			return err
+		}
+		if reused {
+			fail(domain.PasswordRuleReused, fmt.Sprintf("must not be one of your last %d passwords", p.config.HistorySize))
+		}
+	}
+
+	if p.breached != nil {
+		count, err := p.breached.Count(password)
+		if err != nil {
+			p.logger.Error("Failed to check breached passwords", zap.Error(err))
+			return err
+		}
+		if count > 0 {
+			fail(domain.PasswordRuleBreached, "has appeared in a data breach and must not be used")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// Remember records a password hash the user has set and forgets the ones beyond the history size
+func (p *PasswordPolicyImpl) Remember(userID int64, passwordHash string) error {
+	if p.config.HistorySize <= 0 {
+		return nil
+	}
+
+	if err := p.historyRepo.Add(userID, passwordHash); err != nil {
+		return err
+	}
+	return p.historyRepo.Prune(userID, p.config.HistorySize)
+}
+
+// isReused checks the password against the current one and the user's recent passwords
+func (p *PasswordPolicyImpl) isReused(password string, user *domain.User) (bool, error) {
+	hashes, err := p.historyRepo.ListRecent(user.ID, p.config.HistorySize)
+	if err != nil {
+		return false, err
+	}
+
+	// The current password counts even if it predates the history
+	if len(hashes) == 0 || hashes[0] != user.PasswordHash {
+		hashes = append(hashes, user.PasswordHash)
+	}
+	for _, hash := range hashes {
+		if verifyPassword(password, hash) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+// mustHashPassword hashes a password the way user passwords are stored
+func mustHashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := hashPassword(password)
+	if err != nil {
+		t.Fatalf("Failed to hash password: %v", err)
+	}
+	return hash
+}
+
+// writeBreachedList writes a breached password list holding the given passwords and returns its path
+func writeBreachedList(t *testing.T, passwords ...string) string {
+	t.Helper()
+	var lines []string
+	for _, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":42")
+	}
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatalf("Failed to write breached password list: %v", err)
+	}
+	return path
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	user := &domain.User{ID: 1, PasswordHash: mustHashPassword(t, "Current-pass-1")}
+	previousHash := mustHashPassword(t, "Previous-pass-2")
+	policyConfig := config.PasswordPolicyConfig{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		HistorySize:      3,
+		BreachedListFile: writeBreachedList(t, "Password123!"),
+	}
+
+	tests := []struct {
+		name      string
+		password  string
+		user      *domain.User
+		wantRules []string
+	}{
+		{"meets every rule", "Brand-new-pass-3", user, nil},
+		{"too short", "Sh0rt!", user, []string{domain.PasswordRuleMinLength}},
+		{"length counted in characters", "Äbc1!Äbc1", user, []string{domain.PasswordRuleMinLength}},
+		{"longer than bcrypt can hash", strings.Repeat("aA1!", 19), user, []string{domain.PasswordRuleMaxLength}},
+		{"missing character classes", "onlylowercaseletters", user, []string{domain.PasswordRuleUppercase, domain.PasswordRuleDigit, domain.PasswordRuleSymbol}},
+		{"current password", "Current-pass-1", user, []string{domain.PasswordRuleReused}},
+		{"previous password", "Previous-pass-2", user, []string{domain.PasswordRuleReused}},
+		{"breached password", "Password123!", user, []string{domain.PasswordRuleBreached}},
+		{"no history before the account exists", "Current-pass-1", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			historyRepo := mocks.NewMockPasswordHistoryRepository(ctrl)
+			if tt.user != nil {
+				historyRepo.EXPECT().ListRecent(int64(1), 3).Return([]string{user.PasswordHash, previousHash}, nil)
+			}
+
+			policy, err := NewPasswordPolicy(historyRepo, policyConfig, &logger.Logger{Logger: zap.NewNop()})
+			if err != nil {
+				t.Fatalf("NewPasswordPolicy() error = %v", err)
+			}
+
+			err = policy.Validate(tt.password, tt.user)
+			if tt.wantRules == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var policyErr *domain.PasswordPolicyError
+			if !errors.As(err, &policyErr) || !errors.Is(err, domain.ErrWeakPassword) {
+				t.Fatalf("Validate() error = %v, want a password policy error", err)
+			}
+			var rules []string
+			for _, v := range policyErr.Violations {
+				rules = append(rules, v.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("Validate() failed rules = %v, want %v", rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyRemember(t *testing.T) {
+	tests := []struct {
+		name        string
+		historySize int
+	}{
+		{"keeps the configured number of passwords", 3},
+		{"history disabled", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			historyRepo := mocks.NewMockPasswordHistoryRepository(ctrl)
+			if tt.historySize > 0 {
+				gomock.InOrder(
+					historyRepo.EXPECT().Add(int64(1), "hash").Return(nil),
+					historyRepo.EXPECT().Prune(int64(1), tt.historySize).Return(nil),
+				)
+			}
+
+			policy, err := NewPasswordPolicy(historyRepo, config.PasswordPolicyConfig{HistorySize: tt.historySize}, &logger.Logger{Logger: zap.NewNop()})
+			if err != nil {
+				t.Fatalf("NewPasswordPolicy() error = %v", err)
+			}
+
+			if err := policy.Remember(1, "hash"); err != nil {
+				t.Fatalf("Remember() error = %v", err)
+			}
+		})
+	}
+}
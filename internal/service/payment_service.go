@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
@@ -12,14 +13,20 @@ import (
 type PaymentServiceImpl struct {
 	repo       domain.PaymentRepository
 	rentalRepo domain.RentalRepository
+	fineRepo   domain.FineRepository
+	userRepo   domain.UserRepository
+	requireVerifiedEmail bool
 	logger     *logger.Logger
 }
 
 // NewPaymentService creates a new PaymentService
-func NewPaymentService(repo domain.PaymentRepository, rentalRepo domain.RentalRepository, logger *logger.Logger) domain.PaymentService {
+func NewPaymentService(repo domain.PaymentRepository, rentalRepo domain.RentalRepository, fineRepo domain.FineRepository, userRepo domain.UserRepository, requireVerifiedEmail bool, logger *logger.Logger) domain.PaymentService {
 	return &PaymentServiceImpl{
 		repo:       repo,
 		rentalRepo: rentalRepo,
+		fineRepo:   fineRepo,
+		userRepo:   userRepo,
+		requireVerifiedEmail: requireVerifiedEmail,
 		logger:     logger,
 	}
 }
@@ -66,6 +73,20 @@ func (s *PaymentServiceImpl) ListByRental(rentalID int64) ([]*domain.Payment, er
 
 // Create creates a new payment
 func (s *PaymentServiceImpl) Create(payment *domain.Payment) (*domain.Payment, error) {
+	// Unverified accounts may not pay when verification is required
+	if s.requireVerifiedEmail {
+		if err := checkEmailVerified(s.userRepo, payment.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate fine if provided
+	if payment.FineID != nil {
+		if err := s.checkFinePayment(payment); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate rental if provided
 	if payment.RentalID != nil {
 		rental, err := s.rentalRepo.GetByID(*payment.RentalID)
@@ -105,6 +126,20 @@ func (s *PaymentServiceImpl) ProcessPayment(payment *domain.Payment) (*domain.Pa
 	// In a real-world application, this would integrate with a payment gateway
 	// For demonstration purposes, we'll implement a mock payment process
 
+	// Unverified accounts may not pay when verification is required
+	if s.requireVerifiedEmail {
+		if err := checkEmailVerified(s.userRepo, payment.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate fine if provided, the payment is credited to it
+	if payment.FineID != nil {
+		if err := s.checkFinePayment(payment); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set payment date to now if not provided
 	if payment.PaymentDate.IsZero() {
 		payment.PaymentDate = time.Now()
@@ -197,6 +232,36 @@ func (s *PaymentServiceImpl) GetRevenueReport(startDate, endDate time.Time) ([]*
 
 // Helper functions
 
+// checkFinePayment checks that a payment can go towards the fine it references and links the
+// payment to the fined rental
+func (s *PaymentServiceImpl) checkFinePayment(payment *domain.Payment) error {
+	fine, err := s.fineRepo.GetByID(*payment.FineID)
+	if err != nil {
+		s.logger.Error("Failed to get fine by ID", zap.Int64("fineID", *payment.FineID), zap.Error(err))
+		return err
+	}
+
+	// Ensure payment is associated with the correct user
+	if payment.UserID != fine.UserID {
+		return domain.NewInvalidInputError("payment user ID does not match fine user ID")
+	}
+
+	if fine.Status != domain.FineStatusOutstanding {
+		return domain.ErrFineNotOutstanding
+	}
+
+	if payment.Amount > fine.Balance() {
+		return domain.NewInvalidInputError(fmt.Sprintf("payment exceeds the outstanding balance of the fine (%.2f)", fine.Balance()))
+	}
+
+	if payment.RentalID != nil && *payment.RentalID != fine.RentalID {
+		return domain.NewInvalidInputError("payment rental ID does not match fine rental ID")
+	}
+	payment.RentalID = &fine.RentalID
+
+	return nil
+}
+
 // generateTransactionID generates a unique transaction ID
 func generateTransactionID() string {
 	return time.Now().Format("20060102150405") + "-" + randomString(8)
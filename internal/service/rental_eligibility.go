@@ -0,0 +1,78 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// CheckEligibility checks whether a user may rent books. Users are blocked when they keep
+// more overdue books or owe more late fees than the configured thresholds allow.
+func (s *RentalServiceImpl) CheckEligibility(userID int64) (*domain.Eligibility, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	overdueRentals, err := s.repo.CountOverdueByUser(userID)
+	if err != nil {
+		s.logger.Error("Failed to count overdue rentals", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	unpaidFees, err := s.unpaidLateFees(userID)
+	if err != nil {
+		s.logger.Error("Failed to calculate unpaid late fees", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	eligibility := &domain.Eligibility{
+		UserID:            userID,
+		OverdueRentals:    overdueRentals,
+		MaxOverdueRentals: s.config.MaxOverdueRentals,
+		UnpaidFees:        unpaidFees,
+		MaxUnpaidFees:     s.config.MaxUnpaidFees,
+	}
+
+	if overdueRentals > s.config.MaxOverdueRentals {
+		eligibility.Reasons = append(eligibility.Reasons, domain.BorrowingBlockReason{
+			Code:    domain.BorrowingBlockOverdueRentals,
+			Message: fmt.Sprintf("%d overdue rental(s), at most %d allowed", overdueRentals, s.config.MaxOverdueRentals),
+		})
+	}
+
+	if unpaidFees > s.config.MaxUnpaidFees {
+		eligibility.Reasons = append(eligibility.Reasons, domain.BorrowingBlockReason{
+			Code:    domain.BorrowingBlockUnpaidFees,
+			Message: fmt.Sprintf("%.2f in unpaid late fees, at most %.2f allowed", unpaidFees, s.config.MaxUnpaidFees),
+		})
+	}
+
+	eligibility.Eligible = len(eligibility.Reasons) == 0
+	return eligibility, nil
+}
+
+// unpaidLateFees totals what a user still owes on their fines and the late fees building up
+// on the books they keep past their due date
+func (s *RentalServiceImpl) unpaidLateFees(userID int64) (float64, error) {
+	unpaid, err := s.fineRepo.SumOutstandingByUser(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	lateRentals, err := s.repo.ListLateByUser(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rental := range lateRentals {
+		fee, err := s.CalculateLateFee(rental)
+		if err != nil {
+			return 0, err
+		}
+		unpaid += fee
+	}
+
+	return math.Round(unpaid*100) / 100, nil
+}
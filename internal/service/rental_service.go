@@ -1,6 +1,8 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
@@ -13,16 +15,32 @@ import (
 type RentalServiceImpl struct {
 	repo       domain.RentalRepository
 	bookRepo   domain.BookRepository
+	copyRepo   domain.BookCopyRepository
+	userRepo   domain.UserRepository
+	planRepo   domain.MembershipPlanRepository
+	householdRepo domain.HouseholdRepository
+	fineRepo   domain.FineRepository
+	ruleRepo   domain.LoanRuleRepository
+	calendarRepo domain.CalendarRepository
 	config     config.RentalConfig
+	requireVerifiedEmail bool
 	logger     *logger.Logger
 }
 
 // NewRentalService creates a new RentalService
-func NewRentalService(repo domain.RentalRepository, bookRepo domain.BookRepository, config config.RentalConfig, logger *logger.Logger) domain.RentalService {
+func NewRentalService(repo domain.RentalRepository, bookRepo domain.BookRepository, copyRepo domain.BookCopyRepository, userRepo domain.UserRepository, planRepo domain.MembershipPlanRepository, householdRepo domain.HouseholdRepository, fineRepo domain.FineRepository, ruleRepo domain.LoanRuleRepository, calendarRepo domain.CalendarRepository, config config.RentalConfig, requireVerifiedEmail bool, logger *logger.Logger) domain.RentalService {
 	return &RentalServiceImpl{
 		repo:       repo,
 		bookRepo:   bookRepo,
+		copyRepo:   copyRepo,
+		userRepo:   userRepo,
+		planRepo:   planRepo,
+		householdRepo: householdRepo,
+		fineRepo:   fineRepo,
+		ruleRepo:   ruleRepo,
+		calendarRepo: calendarRepo,
 		config:     config,
+		requireVerifiedEmail: requireVerifiedEmail,
 		logger:     logger,
 	}
 }
@@ -35,16 +53,6 @@ func (s *RentalServiceImpl) GetByID(id int64) (*domain.Rental, error) {
 		return nil, err
 	}
 
-	// Update status if it's overdue but not marked as overdue
-	if rental.Status == domain.RentalStatusActive && s.IsOverdue(rental) {
-		rental.Status = domain.RentalStatusOverdue
-		rental, err = s.repo.UpdateStatus(rental.ID, domain.RentalStatusOverdue)
-		if err != nil {
-			s.logger.Error("Failed to update rental status to overdue", zap.Int64("id", id), zap.Error(err))
-			// Continue anyway, we'll return the rental with the updated status even if the DB update failed
-		}
-	}
-
 	return rental, nil
 }
 
@@ -56,9 +64,6 @@ func (s *RentalServiceImpl) List(limit, offset int32) ([]*domain.Rental, error)
 		return nil, err
 	}
 
-	// Update status for any overdue rentals
-	s.updateOverdueStatus(rentals)
-
 	return rentals, nil
 }
 
@@ -70,9 +75,6 @@ func (s *RentalServiceImpl) ListByUser(userID int64, limit, offset int32) ([]*do
 		return nil, err
 	}
 
-	// Update status for any overdue rentals
-	s.updateOverdueStatus(rentals)
-
 	return rentals, nil
 }
 
@@ -84,9 +86,6 @@ func (s *RentalServiceImpl) ListByBook(bookID int64, limit, offset int32) ([]*do
 		return nil, err
 	}
 
-	// Update status for any overdue rentals
-	s.updateOverdueStatus(rentals)
-
 	return rentals, nil
 }
 
@@ -98,67 +97,69 @@ func (s *RentalServiceImpl) ListActive(limit, offset int32) ([]*domain.Rental, e
 		return nil, err
 	}
 
-	// Update status for any overdue rentals
-	s.updateOverdueStatus(rentals)
-
 	return rentals, nil
 }
 
 // ListOverdue retrieves a list of overdue rentals with pagination
 func (s *RentalServiceImpl) ListOverdue(limit, offset int32) ([]*domain.Rental, error) {
-	// Get current overdue rentals
 	rentals, err := s.repo.ListOverdue(limit, offset)
 	if err != nil {
 		s.logger.Error("Failed to list overdue rentals", zap.Error(err))
 		return nil, err
 	}
 
-	// Check active rentals to see if any have become overdue
-	activeRentals, err := s.repo.ListActive(limit, offset)
-	if err != nil {
-		s.logger.Error("Failed to list active rentals to check for overdue", zap.Error(err))
-		// Continue with the overdue rentals we already have
-	} else {
-		// Find any active rentals that are now overdue
-		var newOverdueRentals []*domain.Rental
-		for _, rental := range activeRentals {
-			if rental.DueDate.Before(time.Now()) {
-				rental.Status = domain.RentalStatusOverdue
-				updatedRental, err := s.repo.UpdateStatus(rental.ID, domain.RentalStatusOverdue)
-				if err != nil {
-					s.logger.Error("Failed to update rental status to overdue", 
-						zap.Int64("id", rental.ID), zap.Error(err))
-					// Continue with the next rental
-					continue
-				}
-				newOverdueRentals = append(newOverdueRentals, updatedRental)
-			}
-		}
-
-		// Add any newly found overdue rentals to the list
-		if len(newOverdueRentals) > 0 {
-			rentals = append(rentals, newOverdueRentals...)
-			// We might exceed the limit now, so trim the list if needed
-			if int32(len(rentals)) > limit {
-				rentals = rentals[:limit]
-			}
-		}
-	}
-
 	return rentals, nil
 }
 
 // Create creates a new rental
 func (s *RentalServiceImpl) Create(rental *domain.Rental) (*domain.Rental, error) {
-	// Check if book exists and is available
-	isAvailable, err := s.IsBookAvailable(rental.BookID)
+	// Unverified accounts may not borrow when verification is required
+	if s.requireVerifiedEmail {
+		if err := checkEmailVerified(s.userRepo, rental.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Members with overdue books or unpaid fees may not borrow more
+	eligibility, err := s.CheckEligibility(rental.UserID)
 	if err != nil {
-		s.logger.Error("Failed to check book availability", zap.Int64("bookID", rental.BookID), zap.Error(err))
 		return nil, err
 	}
 
-	if !isAvailable {
-		return nil, domain.ErrBookNotAvailable
+	if !eligibility.Eligible {
+		s.logger.Info("Rental refused, borrowing is blocked",
+			zap.String("event", "borrowing_blocked"),
+			zap.Int64("userID", rental.UserID),
+			zap.Int("overdueRentals", eligibility.OverdueRentals),
+			zap.Float64("unpaidFees", eligibility.UnpaidFees))
+		return nil, &domain.BorrowingBlockedError{Reasons: eligibility.Reasons}
+	}
+
+	// The user's membership plan limits how many books they may borrow, which is checked when
+	// the rental is stored
+	plan, err := s.planForUser(rental.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The household of the user, if any, shares a limit that is also checked when the rental is stored
+	household, err := s.limitedHousehold(rental.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if book exists, whether a copy is available or on hold for the user is checked when it is taken
+	book, err := s.bookRepo.GetByID(rental.BookID)
+	if err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", rental.BookID), zap.Error(err))
+		return nil, err
+	}
+
+	// The loan rule for the book and plan sets the loan period
+	rule, _, err := resolveLoanRule(s.ruleRepo, book, plan, s.config.LateFeePerDay)
+	if err != nil {
+		s.logger.Error("Failed to resolve loan rule", zap.Int64("userID", rental.UserID), zap.Int64("bookID", rental.BookID), zap.Error(err))
+		return nil, err
 	}
 
 	// Set rental date to now if not provided
@@ -166,9 +167,27 @@ func (s *RentalServiceImpl) Create(rental *domain.Rental) (*domain.Rental, error
 		rental.RentalDate = time.Now()
 	}
 
-	// Set due date based on rental date and default rental days if not provided
+	// Set due date based on rental date and the rule's loan period if not provided, due dates
+	// roll forward to the next day the library is open
+	maxDueDate := rental.RentalDate.AddDate(0, 0, rule.LoanPeriodDays)
+	calendar, err := s.libraryCalendar(rental.RentalDate, maxDueDate)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDueDate = calendar.NextOpenDay(maxDueDate)
 	if rental.DueDate.IsZero() {
-		rental.DueDate = rental.RentalDate.AddDate(0, 0, s.config.DefaultRentalDays)
+		rental.DueDate = maxDueDate
+	} else if !rental.DueDate.After(maxDueDate) {
+		rental.DueDate = calendar.NextOpenDay(rental.DueDate)
+	}
+
+	if !rental.DueDate.After(rental.RentalDate) {
+		return nil, domain.NewInvalidInputError("due date must be after the rental date")
+	}
+
+	if rental.DueDate.After(maxDueDate) {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("the %s loan rule allows a loan period of at most %d days", rule.Name, rule.LoanPeriodDays))
 	}
 
 	// Set status to active if not provided
@@ -177,8 +196,21 @@ func (s *RentalServiceImpl) Create(rental *domain.Rental) (*domain.Rental, error
 	}
 
 	// Create rental
-	createdRental, err := s.repo.Create(rental)
+	limits := domain.RentalLimits{MaxActiveRentals: plan.MaxConcurrentRentals}
+	if household != nil {
+		limits.HouseholdID = household.ID
+	}
+
+	createdRental, err := s.repo.Create(rental, limits)
 	if err != nil {
+		if errors.Is(err, domain.ErrRentalLimitReached) {
+			return nil, domain.NewLimitReachedError(domain.ErrRentalLimitReached,
+				fmt.Sprintf("the %s plan allows at most %d books at a time, return a book before renting another", plan.Name, plan.MaxConcurrentRentals))
+		}
+		if errors.Is(err, domain.ErrHouseholdLimitReached) {
+			return nil, domain.NewLimitReachedError(domain.ErrHouseholdLimitReached,
+				fmt.Sprintf("the %s household allows at most %d books at a time, return a book before renting another", household.Name, *household.MaxConcurrentRentals))
+		}
 		s.logger.Error("Failed to create rental", zap.Error(err))
 		return nil, err
 	}
@@ -186,27 +218,92 @@ func (s *RentalServiceImpl) Create(rental *domain.Rental) (*domain.Rental, error
 	return createdRental, nil
 }
 
-// Return processes the return of a rental
-func (s *RentalServiceImpl) Return(id int64) (*domain.Rental, error) {
+// Return processes the return of a rental and assesses a fine when it is returned late
+func (s *RentalServiceImpl) Return(id int64) (*domain.Rental, *domain.Fine, error) {
 	// Check if rental exists and is active or overdue
 	rental, err := s.repo.GetByID(id)
 	if err != nil {
 		s.logger.Error("Failed to get rental by ID", zap.Int64("id", id), zap.Error(err))
-		return nil, err
+		return nil, nil, err
 	}
 
 	if rental.Status != domain.RentalStatusActive && rental.Status != domain.RentalStatusOverdue {
-		return nil, domain.ErrRentalNotActive
+		return nil, nil, domain.ErrRentalNotActive
+	}
+
+	// Calculate the late fee as of now, the moment of return
+	lateFee, err := s.CalculateLateFee(rental)
+	if err != nil {
+		s.logger.Error("Failed to calculate late fee", zap.Int64("id", id), zap.Error(err))
+		return nil, nil, err
 	}
 
 	// Return rental
-	returnedRental, err := s.repo.Return(id)
+	// The copy is held for the next reservation, if any, for the pickup window
+	returnedRental, err := s.repo.Return(id, lateFee, time.Now().Add(s.config.HoldPickupWindow))
 	if err != nil {
 		s.logger.Error("Failed to return rental", zap.Int64("id", id), zap.Error(err))
+		return nil, nil, err
+	}
+
+	if lateFee == 0 {
+		return returnedRental, nil, nil
+	}
+
+	fine, err := s.fineRepo.GetByRental(id)
+	if err != nil {
+		s.logger.Error("Failed to get assessed fine", zap.Int64("id", id), zap.Error(err))
+		return nil, nil, err
+	}
+
+	s.logger.Info("Fine assessed",
+		zap.String("event", "fine_assessed"),
+		zap.Int64("fineID", fine.ID),
+		zap.Int64("rentalID", id),
+		zap.Int64("userID", fine.UserID),
+		zap.Float64("amount", fine.Amount))
+
+	return returnedRental, fine, nil
+}
+
+// Checkout lends the copy with the given barcode to a user, as done at the circulation desk
+func (s *RentalServiceImpl) Checkout(barcode string, userID int64, dueDate time.Time) (*domain.Rental, error) {
+	bookCopy, err := s.copyRepo.GetByBarcode(barcode)
+	if err != nil {
+		s.logger.Error("Failed to get book copy by barcode", zap.String("barcode", barcode), zap.Error(err))
 		return nil, err
 	}
 
-	return returnedRental, nil
+	// Copies on hold can only be checked out by the member they are held for
+	if bookCopy.Status != domain.BookCopyStatusAvailable && bookCopy.Status != domain.BookCopyStatusOnHold {
+		return nil, domain.ErrBookCopyNotAvailable
+	}
+
+	rental := &domain.Rental{
+		UserID:  userID,
+		BookID:  bookCopy.BookID,
+		CopyID:  &bookCopy.ID,
+		DueDate: dueDate,
+	}
+
+	return s.Create(rental)
+}
+
+// ReturnByBarcode returns the open rental of the copy with the given barcode
+func (s *RentalServiceImpl) ReturnByBarcode(barcode string) (*domain.Rental, *domain.Fine, error) {
+	bookCopy, err := s.copyRepo.GetByBarcode(barcode)
+	if err != nil {
+		s.logger.Error("Failed to get book copy by barcode", zap.String("barcode", barcode), zap.Error(err))
+		return nil, nil, err
+	}
+
+	rental, err := s.repo.GetOpenByCopy(bookCopy.ID)
+	if err != nil {
+		s.logger.Error("Failed to get open rental of book copy", zap.String("barcode", barcode), zap.Error(err))
+		return nil, nil, err
+	}
+
+	return s.Return(rental.ID)
 }
 
 // Extend extends the due date of a rental
@@ -227,16 +324,36 @@ func (s *RentalServiceImpl) Extend(id int64, days int) (*domain.Rental, error) {
 		return nil, domain.NewInvalidInputError("extension days must be positive")
 	}
 
-	if days > s.config.MaxRentalExtensionDays {
-		return nil, domain.NewInvalidInputError("extension days cannot exceed maximum allowed")
+	// Enforce the renewals allowed by the loan rule, the user's membership plan bounds how long each renewal is
+	rule, plan, err := s.loanRuleFor(rental)
+	if err != nil {
+		return nil, err
+	}
+
+	if rental.ExtensionCount >= rule.MaxRenewals {
+		return nil, domain.NewLimitReachedError(domain.ErrExtensionLimitReached,
+			fmt.Sprintf("the %s loan rule allows %d renewal(s) per rental", rule.Name, rule.MaxRenewals))
+	}
+
+	if days > plan.MaxExtensionDays {
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("the %s plan allows extensions of at most %d days", plan.Name, plan.MaxExtensionDays))
 	}
 
-	// Calculate new due date
+	// Calculate new due date, rolled forward to the next day the library is open
 	newDueDate := rental.DueDate.AddDate(0, 0, days)
+	calendar, err := s.libraryCalendar(rental.DueDate, newDueDate)
+	if err != nil {
+		return nil, err
+	}
+	newDueDate = calendar.NextOpenDay(newDueDate)
 
 	// Extend rental
-	extendedRental, err := s.repo.Extend(id, newDueDate)
+	extendedRental, err := s.repo.Extend(id, newDueDate, rule.MaxRenewals)
 	if err != nil {
+		if errors.Is(err, domain.ErrExtensionLimitReached) {
+			return nil, domain.NewLimitReachedError(domain.ErrExtensionLimitReached,
+				fmt.Sprintf("the %s loan rule allows %d renewal(s) per rental", rule.Name, rule.MaxRenewals))
+		}
 		s.logger.Error("Failed to extend rental", zap.Int64("id", id), zap.Error(err))
 		return nil, err
 	}
@@ -244,28 +361,35 @@ func (s *RentalServiceImpl) Extend(id int64, days int) (*domain.Rental, error) {
 	return extendedRental, nil
 }
 
-// CalculateLateFee calculates the late fee for a rental
+// CalculateLateFee calculates the late fee for a rental with the fine rate, cap and grace
+// period of the loan rule that applies to it. A book is late for each day the library is open
+// after its due date, up to and including the day it is returned.
 func (s *RentalServiceImpl) CalculateLateFee(rental *domain.Rental) (float64, error) {
-	// If rental is not overdue, no late fee
-	if !s.IsOverdue(rental) {
+	// If rental is returned, calculate late fee based on return date,
+	// otherwise based on current date
+	end := time.Now()
+	if rental.ReturnDate != nil {
+		end = *rental.ReturnDate
+	}
+
+	// If the book was not kept past its due date, no late fee
+	if !end.After(rental.DueDate) {
 		return 0, nil
 	}
 
-	// If rental is returned, calculate late fee based on return date
-	var lateDays int
-	if rental.ReturnDate != nil {
-		lateDays = int(rental.ReturnDate.Sub(rental.DueDate).Hours() / 24)
-	} else {
-		// If rental is not returned, calculate late fee based on current date
-		lateDays = int(time.Now().Sub(rental.DueDate).Hours() / 24)
+	// Books are late for each day the library was open after the due date, up to the day of return
+	calendar, err := s.libraryCalendar(rental.DueDate, end)
+	if err != nil {
+		return 0, err
 	}
+	lateDays := calendar.OpenDaysBetween(rental.DueDate, end)
 
-	// Ensure late days is at least 1
-	if lateDays < 1 {
-		lateDays = 1
+	rule, _, err := s.loanRuleFor(rental)
+	if err != nil {
+		return 0, err
 	}
 
-	return float64(lateDays) * s.config.LateFeePerDay, nil
+	return rule.LateFee(lateDays), nil
 }
 
 // IsOverdue checks if a rental is overdue
@@ -279,6 +403,22 @@ func (s *RentalServiceImpl) IsOverdue(rental *domain.Rental) bool {
 	return rental.DueDate.Before(time.Now())
 }
 
+// MarkOverdue marks every active rental past its due date as overdue and returns how many
+// were marked
+func (s *RentalServiceImpl) MarkOverdue() (int64, error) {
+	marked, err := s.repo.MarkOverdue()
+	if err != nil {
+		s.logger.Error("Failed to mark overdue rentals", zap.Error(err))
+		return 0, err
+	}
+
+	if marked > 0 {
+		s.logger.Info("Marked overdue rentals", zap.Int64("count", marked))
+	}
+
+	return marked, nil
+}
+
 // Helper methods
 
 // IsBookAvailable checks if a book is available for rental
@@ -290,17 +430,70 @@ func (s *RentalServiceImpl) IsBookAvailable(bookID int64) (bool, error) {
 	return book.AvailableCopies > 0, nil
 }
 
-// updateOverdueStatus updates the status of any overdue rentals in the given list
-func (s *RentalServiceImpl) updateOverdueStatus(rentals []*domain.Rental) {
-	for _, rental := range rentals {
-		if rental.Status == domain.RentalStatusActive && s.IsOverdue(rental) {
-			rental.Status = domain.RentalStatusOverdue
-			_, err := s.repo.UpdateStatus(rental.ID, domain.RentalStatusOverdue)
-			if err != nil {
-				s.logger.Error("Failed to update rental status to overdue", 
-					zap.Int64("id", rental.ID), zap.Error(err))
-				// Continue with the next rental
-			}
+// libraryCalendar loads the library calendar for the days from one time to the next open day
+// after another
+func (s *RentalServiceImpl) libraryCalendar(from, to time.Time) (*domain.LibraryCalendar, error) {
+	calendar, err := loadLibraryCalendar(s.calendarRepo, from, to)
+	if err != nil {
+		s.logger.Error("Failed to load library calendar", zap.Error(err))
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// loanRuleFor resolves the loan rule that applies to a rental, along with the membership plan
+// of its user. Only the due date is fixed at checkout; the rule is resolved again on each
+// renewal and return, so changes to rules and plans apply to rentals that are already out.
+func (s *RentalServiceImpl) loanRuleFor(rental *domain.Rental) (*domain.LoanRule, *domain.MembershipPlan, error) {
+	plan, err := s.planForUser(rental.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	book, err := s.bookRepo.GetByID(rental.BookID)
+	if err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", rental.BookID), zap.Error(err))
+		return nil, nil, err
+	}
+
+	rule, _, err := resolveLoanRule(s.ruleRepo, book, plan, s.config.LateFeePerDay)
+	if err != nil {
+		s.logger.Error("Failed to resolve loan rule", zap.Int64("rentalID", rental.ID), zap.Error(err))
+		return nil, nil, err
+	}
+	return rule, plan, nil
+}
+
+// planForUser resolves the membership plan whose limits apply to a user
+func (s *RentalServiceImpl) planForUser(userID int64) (*domain.MembershipPlan, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := resolveMembershipPlan(s.planRepo, user)
+	if err != nil {
+		s.logger.Error("Failed to resolve membership plan", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return plan, nil
+}
+
+// limitedHousehold returns the household a user belongs to when it limits the rentals of its
+// members, or nil when it does not or the user has no household
+func (s *RentalServiceImpl) limitedHousehold(userID int64) (*domain.Household, error) {
+	household, err := s.householdRepo.GetByMember(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrHouseholdNotFound) {
+			return nil, nil
 		}
+		s.logger.Error("Failed to get household for user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
 	}
+
+	if household.MaxConcurrentRentals == nil {
+		return nil, nil
+	}
+
+	return household, nil
 }
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestRentalServiceCalculateLateFee(t *testing.T) {
+	// Wednesday 2025-12-10 10:00
+	due := time.Date(2025, 12, 10, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		returned time.Time
+		want     float64
+	}{
+		{"returned on time", due.Add(-time.Hour), 0},
+		{"returned later on the due date", due.Add(5 * time.Hour), 0},
+		{"returned the next morning", time.Date(2025, 12, 11, 9, 0, 0, 0, time.UTC), 0.50},
+		{"returned over the weekend", time.Date(2025, 12, 14, 9, 0, 0, 0, time.UTC), 1.00},
+		{"returned on the Monday after the weekend", time.Date(2025, 12, 15, 9, 0, 0, 0, time.UTC), 1.50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().GetByID(int64(1)).Return(&domain.User{ID: 1}, nil).AnyTimes()
+
+			planRepo := mocks.NewMockMembershipPlanRepository(ctrl)
+			planRepo.EXPECT().GetDefault().Return(&domain.MembershipPlan{ID: 1, Name: "basic", LoanPeriodDays: 14}, nil).AnyTimes()
+
+			bookRepo := mocks.NewMockBookRepository(ctrl)
+			bookRepo.EXPECT().GetByID(int64(2)).Return(&domain.Book{ID: 2, CategoryID: 3}, nil).AnyTimes()
+
+			ruleRepo := mocks.NewMockLoanRuleRepository(ctrl)
+			ruleRepo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(nil, domain.ErrLoanRuleNotFound).AnyTimes()
+
+			// Closed on weekends
+			calendarRepo := mocks.NewMockCalendarRepository(ctrl)
+			calendarRepo.EXPECT().ListOpeningHours().Return([]*domain.OpeningHours{
+				{Weekday: time.Saturday, Closed: true},
+				{Weekday: time.Sunday, Closed: true},
+			}, nil).AnyTimes()
+			calendarRepo.EXPECT().ListClosures(gomock.Any(), gomock.Any()).Return([]*domain.Closure{}, nil).AnyTimes()
+
+			s := NewRentalService(nil, bookRepo, nil, userRepo, planRepo, nil, nil, ruleRepo, calendarRepo,
+				config.RentalConfig{LateFeePerDay: 0.50}, false, &logger.Logger{Logger: zap.NewNop()})
+
+			returned := tt.returned
+			fee, err := s.CalculateLateFee(&domain.Rental{
+				ID:         5,
+				UserID:     1,
+				BookID:     2,
+				DueDate:    due,
+				ReturnDate: &returned,
+				Status:     domain.RentalStatusReturned,
+			})
+			if err != nil {
+				t.Fatalf("CalculateLateFee() error = %v", err)
+			}
+			if fee != tt.want {
+				t.Errorf("CalculateLateFee() = %.2f, want %.2f", fee, tt.want)
+			}
+		})
+	}
+}
+
+func TestRentalServiceCalculateLateFeeWithRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(int64(1)).Return(&domain.User{ID: 1}, nil)
+
+	planRepo := mocks.NewMockMembershipPlanRepository(ctrl)
+	planRepo.EXPECT().GetDefault().Return(&domain.MembershipPlan{ID: 1, Name: "basic", LoanPeriodDays: 14}, nil)
+
+	bookRepo := mocks.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().GetByID(int64(2)).Return(&domain.Book{ID: 2, CategoryID: 3}, nil)
+
+	// The rule that matches when the book is returned sets the fine, not the one at checkout
+	fineCap := 1.50
+	ruleRepo := mocks.NewMockLoanRuleRepository(ctrl)
+	ruleRepo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(&domain.LoanRule{
+		ID:              7,
+		Name:            "Short loans",
+		FinePerDay:      1.00,
+		FineCap:         &fineCap,
+		GracePeriodDays: 1,
+	}, nil)
+
+	calendarRepo := mocks.NewMockCalendarRepository(ctrl)
+	calendarRepo.EXPECT().ListOpeningHours().Return([]*domain.OpeningHours{}, nil)
+	calendarRepo.EXPECT().ListClosures(gomock.Any(), gomock.Any()).Return([]*domain.Closure{}, nil)
+
+	s := NewRentalService(nil, bookRepo, nil, userRepo, planRepo, nil, nil, ruleRepo, calendarRepo,
+		config.RentalConfig{LateFeePerDay: 0.50}, false, &logger.Logger{Logger: zap.NewNop()})
+
+	// Four days late, one of them within the grace period, is capped at 1.50
+	due := time.Date(2025, 12, 10, 10, 0, 0, 0, time.UTC)
+	returned := due.AddDate(0, 0, 4)
+	fee, err := s.CalculateLateFee(&domain.Rental{
+		ID:         5,
+		UserID:     1,
+		BookID:     2,
+		DueDate:    due,
+		ReturnDate: &returned,
+		Status:     domain.RentalStatusReturned,
+	})
+	if err != nil {
+		t.Fatalf("CalculateLateFee() error = %v", err)
+	}
+	if fee != 1.50 {
+		t.Errorf("CalculateLateFee() = %.2f, want 1.50", fee)
+	}
+}
+
+// closedOverChristmas returns a calendar repository for a library that is closed on weekends
+// and from Wednesday 2025-12-24 to Friday 2025-12-26
+func closedOverChristmas(ctrl *gomock.Controller) *mocks.MockCalendarRepository {
+	calendarRepo := mocks.NewMockCalendarRepository(ctrl)
+	calendarRepo.EXPECT().ListOpeningHours().Return([]*domain.OpeningHours{
+		{Weekday: time.Saturday, Closed: true},
+		{Weekday: time.Sunday, Closed: true},
+	}, nil).AnyTimes()
+	calendarRepo.EXPECT().ListClosures(gomock.Any(), gomock.Any()).Return([]*domain.Closure{
+		{Date: time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2025, 12, 26, 0, 0, 0, 0, time.UTC)},
+	}, nil).AnyTimes()
+	return calendarRepo
+}
+
+func TestRentalServiceCreateRollsDueDatePastClosures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(int64(1)).Return(&domain.User{ID: 1}, nil).AnyTimes()
+
+	planRepo := mocks.NewMockMembershipPlanRepository(ctrl)
+	planRepo.EXPECT().GetDefault().Return(&domain.MembershipPlan{ID: 1, Name: "basic", LoanPeriodDays: 14, MaxConcurrentRentals: 5}, nil)
+
+	householdRepo := mocks.NewMockHouseholdRepository(ctrl)
+	householdRepo.EXPECT().GetByMember(int64(1)).Return(nil, domain.ErrHouseholdNotFound)
+
+	fineRepo := mocks.NewMockFineRepository(ctrl)
+	fineRepo.EXPECT().SumOutstandingByUser(int64(1)).Return(0.0, nil)
+
+	bookRepo := mocks.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().GetByID(int64(2)).Return(&domain.Book{ID: 2, CategoryID: 3}, nil)
+
+	ruleRepo := mocks.NewMockLoanRuleRepository(ctrl)
+	ruleRepo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(nil, domain.ErrLoanRuleNotFound)
+
+	// Rented on Wednesday 2025-12-10, the loan period ends on the closed Wednesday 2025-12-24
+	// and rolls past the holidays and the weekend to Monday 2025-12-29
+	rentalDate := time.Date(2025, 12, 10, 10, 0, 0, 0, time.UTC)
+	wantDueDate := time.Date(2025, 12, 29, 10, 0, 0, 0, time.UTC)
+
+	repo := mocks.NewMockRentalRepository(ctrl)
+	repo.EXPECT().CountOverdueByUser(int64(1)).Return(0, nil)
+	repo.EXPECT().ListLateByUser(int64(1)).Return([]*domain.Rental{}, nil)
+	repo.EXPECT().Create(gomock.Any(), domain.RentalLimits{MaxActiveRentals: 5}).DoAndReturn(
+		func(rental *domain.Rental, limits domain.RentalLimits) (*domain.Rental, error) {
+			return rental, nil
+		})
+
+	s := NewRentalService(repo, bookRepo, nil, userRepo, planRepo, householdRepo, fineRepo, ruleRepo, closedOverChristmas(ctrl),
+		config.RentalConfig{LateFeePerDay: 0.50}, false, &logger.Logger{Logger: zap.NewNop()})
+
+	rental, err := s.Create(&domain.Rental{UserID: 1, BookID: 2, RentalDate: rentalDate})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !rental.DueDate.Equal(wantDueDate) {
+		t.Errorf("Create() due date = %v, want %v", rental.DueDate, wantDueDate)
+	}
+}
+
+func TestRentalServiceExtendRollsDueDatePastClosures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(int64(1)).Return(&domain.User{ID: 1}, nil)
+
+	planRepo := mocks.NewMockMembershipPlanRepository(ctrl)
+	planRepo.EXPECT().GetDefault().Return(&domain.MembershipPlan{ID: 1, Name: "basic", LoanPeriodDays: 14, MaxExtensions: 2, MaxExtensionDays: 14}, nil)
+
+	bookRepo := mocks.NewMockBookRepository(ctrl)
+	bookRepo.EXPECT().GetByID(int64(2)).Return(&domain.Book{ID: 2, CategoryID: 3}, nil)
+
+	ruleRepo := mocks.NewMockLoanRuleRepository(ctrl)
+	ruleRepo.EXPECT().Match(int64(2), int64(3), int64(1)).Return(nil, domain.ErrLoanRuleNotFound)
+
+	// Due on Wednesday 2025-12-10, two more weeks end on the closed Wednesday 2025-12-24 and
+	// roll past the holidays and the weekend to Monday 2025-12-29
+	dueDate := time.Date(2025, 12, 10, 10, 0, 0, 0, time.UTC)
+	wantDueDate := time.Date(2025, 12, 29, 10, 0, 0, 0, time.UTC)
+
+	repo := mocks.NewMockRentalRepository(ctrl)
+	repo.EXPECT().GetByID(int64(5)).Return(&domain.Rental{ID: 5, UserID: 1, BookID: 2, DueDate: dueDate, Status: domain.RentalStatusActive}, nil)
+	repo.EXPECT().Extend(int64(5), wantDueDate, 2).Return(&domain.Rental{ID: 5, UserID: 1, BookID: 2, DueDate: wantDueDate, ExtensionCount: 1, Status: domain.RentalStatusActive}, nil)
+
+	s := NewRentalService(repo, bookRepo, nil, userRepo, planRepo, nil, nil, ruleRepo, closedOverChristmas(ctrl),
+		config.RentalConfig{LateFeePerDay: 0.50}, false, &logger.Logger{Logger: zap.NewNop()})
+
+	rental, err := s.Extend(5, 14)
+	if err != nil {
+		t.Fatalf("Extend() error = %v", err)
+	}
+	if !rental.DueDate.Equal(wantDueDate) {
+		t.Errorf("Extend() due date = %v, want %v", rental.DueDate, wantDueDate)
+	}
+}
@@ -125,47 +125,12 @@ func (s *ReportServiceImpl) GetRevenueReport(startDate, endDate string) ([]*doma
 
 // GetOverdueBooks retrieves a list of overdue rentals
 func (s *ReportServiceImpl) GetOverdueBooks(limit, offset int32) ([]*domain.Rental, error) {
-	// This leverages the existing overdue rentals functionality
 	rentals, err := s.rentalRepo.ListOverdue(limit, offset)
 	if err != nil {
 		s.logger.Error("Failed to list overdue rentals", zap.Error(err))
 		return nil, err
 	}
-	
-	// Check active rentals to find any additional overdue rentals
-	// that haven't been updated in the database yet
-	activeRentals, err := s.rentalRepo.ListActive(100, 0)
-	if err != nil {
-		s.logger.Error("Failed to list active rentals", zap.Error(err))
-		// Continue with just the known overdue rentals
-	} else {
-		now := time.Now()
-		var newOverdueRentals []*domain.Rental
-		
-		for _, rental := range activeRentals {
-			if rental.DueDate.Before(now) {
-				// Mark as overdue in the database
-				rental.Status = domain.RentalStatusOverdue
-				_, updateErr := s.rentalRepo.UpdateStatus(rental.ID, domain.RentalStatusOverdue)
-				if updateErr != nil {
-					s.logger.Error("Failed to update rental status", 
-						zap.Int64("id", rental.ID), zap.Error(updateErr))
-					// Continue anyway, we want to include this rental in the report
-				}
-				newOverdueRentals = append(newOverdueRentals, rental)
-			}
-		}
-		
-		// Add newly discovered overdue rentals to the result
-		if len(newOverdueRentals) > 0 {
-			rentals = append(rentals, newOverdueRentals...)
-			// Apply pagination if needed
-			if int32(len(rentals)) > limit {
-				rentals = rentals[:limit]
-			}
-		}
-	}
-	
+
 	return rentals, nil
 }
 
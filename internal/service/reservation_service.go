@@ -0,0 +1,161 @@
+package service
+
+import (
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ReservationServiceImpl implements domain.ReservationService
+type ReservationServiceImpl struct {
+	repo          domain.ReservationRepository
+	bookRepo      domain.BookRepository
+	rentalRepo    domain.RentalRepository
+	rentalService domain.RentalService
+	config        config.RentalConfig
+	logger        *logger.Logger
+}
+
+// NewReservationService creates a new ReservationService
+func NewReservationService(repo domain.ReservationRepository, bookRepo domain.BookRepository, rentalRepo domain.RentalRepository, rentalService domain.RentalService, config config.RentalConfig, logger *logger.Logger) domain.ReservationService {
+	return &ReservationServiceImpl{
+		repo:          repo,
+		bookRepo:      bookRepo,
+		rentalRepo:    rentalRepo,
+		rentalService: rentalService,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// GetByID retrieves a reservation by ID
+func (s *ReservationServiceImpl) GetByID(id int64) (*domain.Reservation, error) {
+	reservation, err := s.repo.GetByID(id)
+	if err != nil {
+		s.logger.Error("Failed to get reservation by ID", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ListByUser retrieves the reservations of a user
+func (s *ReservationServiceImpl) ListByUser(userID int64) ([]*domain.Reservation, error) {
+	reservations, err := s.repo.ListByUser(userID)
+	if err != nil {
+		s.logger.Error("Failed to list reservations by user", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// ListOpenByBook retrieves the reservation queue of a book
+func (s *ReservationServiceImpl) ListOpenByBook(bookID int64) ([]*domain.Reservation, error) {
+	// Check if book exists
+	if _, err := s.bookRepo.GetByID(bookID); err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+
+	reservations, err := s.repo.ListOpenByBook(bookID)
+	if err != nil {
+		s.logger.Error("Failed to list reservations by book", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// Create puts a user in the queue for a book. Books with a copy on the shelf are rented
+// directly instead, and users who already have the book on loan cannot queue for it. Users
+// who may not rent books cannot queue either, so copies are not held for them.
+func (s *ReservationServiceImpl) Create(userID, bookID int64) (*domain.Reservation, error) {
+	eligibility, err := s.rentalService.CheckEligibility(userID)
+	if err != nil {
+		s.logger.Error("Failed to check rental eligibility", zap.Int64("userID", userID), zap.Error(err))
+		return nil, err
+	}
+
+	if !eligibility.Eligible {
+		s.logger.Info("Reservation refused, borrowing is blocked",
+			zap.String("event", "borrowing_blocked"),
+			zap.Int64("userID", userID),
+			zap.Int("overdueRentals", eligibility.OverdueRentals),
+			zap.Float64("unpaidFees", eligibility.UnpaidFees))
+		return nil, &domain.BorrowingBlockedError{Reasons: eligibility.Reasons}
+	}
+
+	book, err := s.bookRepo.GetByID(bookID)
+	if err != nil {
+		s.logger.Error("Failed to get book by ID", zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+
+	if book.AvailableCopies > 0 {
+		return nil, domain.NewInvalidInputError("the book has copies available, rent one instead of reserving it")
+	}
+
+	// A returned copy should not be held for someone who already has the book
+	borrowed, err := s.rentalRepo.CountActiveByUserAndBook(userID, bookID)
+	if err != nil {
+		s.logger.Error("Failed to count active rentals by user and book", zap.Int64("userID", userID), zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+	if borrowed > 0 {
+		return nil, domain.ErrAlreadyBorrowed
+	}
+
+	reservation, err := s.repo.Create(&domain.Reservation{UserID: userID, BookID: bookID})
+	if err != nil {
+		s.logger.Error("Failed to create reservation", zap.Int64("userID", userID), zap.Int64("bookID", bookID), zap.Error(err))
+		return nil, err
+	}
+
+	s.logger.Info("Reservation placed",
+		zap.String("event", "reservation_placed"),
+		zap.Int64("reservationID", reservation.ID),
+		zap.Int64("userID", userID),
+		zap.Int64("bookID", bookID),
+		zap.Int("queuePosition", reservation.QueuePosition))
+
+	return reservation, nil
+}
+
+// Cancel cancels a reservation. A copy on hold for it passes to the next in line.
+func (s *ReservationServiceImpl) Cancel(id int64) (*domain.Reservation, error) {
+	reservation, err := s.repo.Cancel(id, s.holdUntil())
+	if err != nil {
+		s.logger.Error("Failed to cancel reservation", zap.Int64("id", id), zap.Error(err))
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// ProcessHolds expires the holds whose pickup window has passed, passing their copies to
+// the next in line, and holds copies on the shelf for members who are waiting. It returns
+// the number of expired holds and of copies newly put on hold from the shelf.
+func (s *ReservationServiceImpl) ProcessHolds() (int64, int64, error) {
+	expired, err := s.repo.ExpireHolds(s.holdUntil())
+	if err != nil {
+		s.logger.Error("Failed to expire holds", zap.Error(err))
+		return 0, 0, err
+	}
+
+	held, err := s.repo.HoldAvailableCopies(s.holdUntil())
+	if err != nil {
+		s.logger.Error("Failed to hold available copies", zap.Error(err))
+		return expired, 0, err
+	}
+
+	if expired > 0 || held > 0 {
+		s.logger.Info("Processed reservation holds", zap.Int64("expired", expired), zap.Int64("held", held))
+	}
+
+	return expired, held, nil
+}
+
+// holdUntil returns when a copy put on hold now stops being held
+func (s *ReservationServiceImpl) holdUntil() time.Time {
+	return time.Now().Add(s.config.HoldPickupWindow)
+}
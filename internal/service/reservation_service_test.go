@@ -0,0 +1,61 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestReservationServiceCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		eligible bool
+		borrowed int
+		wantErr  error
+	}{
+		{"book not on loan to the user", true, 0, nil},
+		{"book already on loan to the user", true, 1, domain.ErrAlreadyBorrowed},
+		{"user blocked from borrowing", false, 0, domain.ErrBorrowingBlocked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			eligibility := &domain.Eligibility{UserID: 1, Eligible: tt.eligible}
+			if !tt.eligible {
+				eligibility.OverdueRentals = 2
+				eligibility.Reasons = []domain.BorrowingBlockReason{{Code: domain.BorrowingBlockOverdueRentals, Message: "2 overdue rental(s), at most 0 allowed"}}
+			}
+			rentalService := mocks.NewMockRentalService(ctrl)
+			rentalService.EXPECT().CheckEligibility(int64(1)).Return(eligibility, nil)
+
+			bookRepo := mocks.NewMockBookRepository(ctrl)
+			rentalRepo := mocks.NewMockRentalRepository(ctrl)
+			repo := mocks.NewMockReservationRepository(ctrl)
+			if tt.eligible {
+				bookRepo.EXPECT().GetByID(int64(2)).Return(&domain.Book{ID: 2, AvailableCopies: 0}, nil)
+				rentalRepo.EXPECT().CountActiveByUserAndBook(int64(1), int64(2)).Return(tt.borrowed, nil)
+			}
+			if tt.wantErr == nil {
+				repo.EXPECT().Create(gomock.Any()).Return(&domain.Reservation{ID: 3, UserID: 1, BookID: 2, QueuePosition: 1}, nil)
+			}
+
+			s := NewReservationService(repo, bookRepo, rentalRepo, rentalService, config.RentalConfig{}, &logger.Logger{Logger: zap.NewNop()})
+
+			reservation, err := s.Create(1, 2)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && reservation.ID != 3 {
+				t.Errorf("Create() reservation ID = %d, want 3", reservation.ID)
+			}
+		})
+	}
+}
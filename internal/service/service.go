@@ -1,55 +1,140 @@
 package service
 
 import (
+	"time"
+
 	"github.com/SimpleBookRental/backend/internal/domain"
 	"github.com/SimpleBookRental/backend/internal/repository"
 	"github.com/SimpleBookRental/backend/pkg/auth"
 	"github.com/SimpleBookRental/backend/pkg/config"
 	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/SimpleBookRental/backend/pkg/mailer"
 )
 
 // Service is a factory for all services
 type Service struct {
-	User     domain.UserService
-	Auth     AuthService
-	Category domain.CategoryService
-	Book     domain.BookService
-	Rental   domain.RentalService
-	Payment  domain.PaymentService
-	Report   ReportService
-	Logger   *logger.Logger
+	User           domain.UserService
+	Auth           AuthService
+	Category       domain.CategoryService
+	Book           domain.BookService
+	BookCopy       domain.BookCopyService
+	Rental         domain.RentalService
+	Reservation    domain.ReservationService
+	Payment        domain.PaymentService
+	Fine           domain.FineService
+	Report         ReportService
+	APIKey         domain.APIKeyService
+	Authz          domain.AuthorizationService
+	MembershipPlan domain.MembershipPlanService
+	LoanRule       domain.LoanRuleService
+	Calendar       domain.CalendarService
+	DataExport     domain.DataExportService
+	Household      domain.HouseholdService
+	UserImport     domain.UserImportService
+	Logger         *logger.Logger
 }
 
 // NewService creates a new service factory
-func NewService(repo *repository.Repository, cfg *config.Config, jwtService *auth.JWTService, logger *logger.Logger) *Service {
+func NewService(repo *repository.Repository, cfg *config.Config, jwtService *auth.JWTService, logger *logger.Logger) (*Service, error) {
 	serviceLogger := logger.Named("service")
+	mail := mailer.New(cfg.Mail, serviceLogger.Named("mailer"))
+
+	passwordPolicy, err := NewPasswordPolicy(repo.PasswordHistory, cfg.Password, serviceLogger.Named("password_policy"))
+	if err != nil {
+		return nil, err
+	}
 
-	userService := NewUserService(repo.User, serviceLogger.Named("user"))
-	authService := NewAuthService(repo.User, jwtService, serviceLogger.Named("auth"))
+	emailVerifier := NewEmailVerifier(repo.EmailVerification, mail, cfg.Auth, serviceLogger.Named("email_verifier"))
+
+	userService := NewUserService(repo.User, repo.Session, passwordPolicy, emailVerifier, cfg.Auth.DeletedAccountRetention, serviceLogger.Named("user"))
+	authService := NewAuthService(repo.User, repo.RevokedToken, repo.Session, repo.PasswordReset, repo.EmailVerification, repo.LoginAttempt, repo.MFA, repo.UserIdentity, repo.OIDCAuthRequest, passwordPolicy, emailVerifier, jwtService, mail, cfg.Auth, cfg.OIDC, serviceLogger.Named("auth"))
 	categoryService := NewCategoryService(repo.Category, serviceLogger.Named("category"))
-	bookService := NewBookService(repo.Book, repo.Category, serviceLogger.Named("book"))
-	rentalService := NewRentalService(repo.Rental, repo.Book, cfg.Rental, serviceLogger.Named("rental"))
-	paymentService := NewPaymentService(repo.Payment, repo.Rental, serviceLogger.Named("payment"))
+	bookService := NewBookService(repo.Book, repo.Category, repo.BookCopy, serviceLogger.Named("book"))
+	bookCopyService := NewBookCopyService(repo.BookCopy, repo.Book, serviceLogger.Named("book_copy"))
+	rentalService := NewRentalService(repo.Rental, repo.Book, repo.BookCopy, repo.User, repo.MembershipPlan, repo.Household, repo.Fine, repo.LoanRule, repo.Calendar, cfg.Rental, cfg.Auth.RequireVerifiedEmail, serviceLogger.Named("rental"))
+	reservationService := NewReservationService(repo.Reservation, repo.Book, repo.Rental, rentalService, cfg.Rental, serviceLogger.Named("reservation"))
+	paymentService := NewPaymentService(repo.Payment, repo.Rental, repo.Fine, repo.User, cfg.Auth.RequireVerifiedEmail, serviceLogger.Named("payment"))
+	fineService := NewFineService(repo.Fine, repo.Rental, serviceLogger.Named("fine"))
 	reportService := NewReportService(repo.Book, repo.Rental, repo.Payment, serviceLogger.Named("report"))
+	apiKeyService := NewAPIKeyService(repo.APIKey, repo.User, cfg.Auth.MFARequiredForStaff, serviceLogger.Named("api_key"))
+	authzService := NewAuthorizationService(repo.Permission, repo.Household, serviceLogger.Named("authz"))
+	membershipPlanService := NewMembershipPlanService(repo.MembershipPlan, repo.User, serviceLogger.Named("membership_plan"))
+	calendarService := NewCalendarService(repo.Calendar, serviceLogger.Named("calendar"))
+	loanRuleService := NewLoanRuleService(repo.LoanRule, repo.Book, repo.Category, repo.MembershipPlan, repo.User, cfg.Rental, serviceLogger.Named("loan_rule"))
+	householdService := NewHouseholdService(repo.Household, repo.User, serviceLogger.Named("household"))
+	userImportService := NewUserImportService(repo.UserImport, repo.User, passwordPolicy, emailVerifier, mail, serviceLogger.Named("user_import"))
+	dataExportService := NewDataExportService(repo.DataExport, repo.User, repo.Rental, repo.Payment, repo.Session, repo.LoginAttempt, mail, cfg.Export, serviceLogger.Named("data_export"))
 
 	return &Service{
-		User:     userService,
-		Auth:     authService,
-		Category: categoryService,
-		Book:     bookService,
-		Rental:   rentalService,
-		Payment:  paymentService,
-		Report:   reportService,
-		Logger:   serviceLogger,
-	}
+		User:           userService,
+		Auth:           authService,
+		Category:       categoryService,
+		Book:           bookService,
+		BookCopy:       bookCopyService,
+		Rental:         rentalService,
+		Reservation:    reservationService,
+		Payment:        paymentService,
+		Fine:           fineService,
+		Report:         reportService,
+		APIKey:         apiKeyService,
+		Authz:          authzService,
+		MembershipPlan: membershipPlanService,
+		LoanRule:       loanRuleService,
+		Calendar:       calendarService,
+		DataExport:     dataExportService,
+		Household:      householdService,
+		UserImport:     userImportService,
+		Logger:         serviceLogger,
+	}, nil
 }
 
 // AuthService defines the interface for authentication service
 type AuthService interface {
 	Register(user *domain.User, password string) (*domain.User, error)
-	Login(username, password string) (string, string, error)
+	Login(username, password, userAgent, ipAddress string) (*LoginResult, error)
+	VerifyMFA(mfaToken, code, userAgent, ipAddress string) (*LoginResult, error)
+	OIDCProviders() []string
+	OIDCAuthorize(provider string) (authURL string, state string, err error)
+	OIDCCallback(provider, code, state, userAgent, ipAddress string) (*LoginResult, error)
+	EnrollMFA(userID int64) (*MFAEnrollment, error)
+	ConfirmMFA(userID int64, code string) ([]string, error)
+	MFARequired(role domain.UserRole) bool
 	RefreshToken(refreshToken string) (string, string, error)
-	Logout(token string) error
+	Impersonate(actorID, actorSessionID, targetID int64) (*ImpersonationResult, error)
+	Logout(accessToken, refreshToken string) error
+	IsRevoked(claims *auth.Claims) (bool, error)
+	PurgeRevokedTokens() (int64, error)
+	PurgeLoginAttempts() (int64, error)
+	PurgeOIDCAuthRequests() (int64, error)
+	ListSessions(userID int64) ([]*domain.Session, error)
+	RevokeSession(userID, sessionID int64) error
+	ForgotPassword(email string) error
+	ResetPassword(token, newPassword string) error
+	VerifyEmail(token string) error
+	ResendVerificationEmail(userID int64) error
+}
+
+// LoginResult is the outcome of a login. When MFARequired is set only MFAToken is filled in
+// and the login has to be completed with a second factor.
+type LoginResult struct {
+	AccessToken            string
+	RefreshToken           string
+	MFARequired            bool
+	MFAToken               string
+	PasswordChangeRequired bool // The account still has a generated password
+}
+
+// ImpersonationResult holds an access token that acts as another user on behalf of an admin
+type ImpersonationResult struct {
+	AccessToken string
+	ExpiresAt   time.Time
+	User        *domain.User
+}
+
+// MFAEnrollment holds the TOTP secret of a pending two-factor enrollment
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
 }
 
 // ReportService defines the interface for report service
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"github.com/SimpleBookRental/backend/pkg/mailer"
+	"go.uber.org/zap"
+)
+
+// maxImportRows caps the number of accounts a single import file may create
+const maxImportRows = 5000
+
+// temporaryPasswordLength is the length of the generated passwords of imported accounts
+const temporaryPasswordLength = 16
+
+// staleImportAge is how long an import can stay pending before it is taken to have been
+// interrupted, e.g. by a restart, and is marked as failed
+const staleImportAge = time.Hour
+
+// UserImportServiceImpl implements domain.UserImportService
+type UserImportServiceImpl struct {
+	repo           domain.UserImportRepository
+	userRepo       domain.UserRepository
+	passwordPolicy domain.PasswordPolicy
+	verifier       domain.EmailVerifier
+	mailer         mailer.Mailer
+	logger         *logger.Logger
+}
+
+// NewUserImportService creates a new UserImportService
+func NewUserImportService(repo domain.UserImportRepository, userRepo domain.UserRepository, passwordPolicy domain.PasswordPolicy, verifier domain.EmailVerifier, mailer mailer.Mailer, logger *logger.Logger) domain.UserImportService {
+	return &UserImportServiceImpl{
+		repo:           repo,
+		userRepo:       userRepo,
+		passwordPolicy: passwordPolicy,
+		verifier:       verifier,
+		mailer:         mailer,
+		logger:         logger,
+	}
+}
+
+// Validate checks every row of an import file without creating accounts
+func (s *UserImportServiceImpl) Validate(file io.Reader) (*domain.UserImportValidation, error) {
+	rows, rowErrors, err := s.check(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserImportValidation{
+		TotalRows: len(rows),
+		Valid:     len(rowErrors) == 0,
+		Errors:    rowErrors,
+	}, nil
+}
+
+// Import checks an import file and, if every row is valid, creates its accounts in the background.
+// Accounts get a random temporary password, which is only ever sent in the invite email, so
+// invites cannot be turned off. Users have to change the temporary password.
+func (s *UserImportServiceImpl) Import(file io.Reader, sendInvites bool, importedBy int64) (*domain.UserImportJob, error) {
+	if !sendInvites {
+		return nil, domain.NewInvalidInputError("send_invites is required, the temporary passwords of imported accounts are only sent in the invites")
+	}
+
+	rows, rowErrors, err := s.check(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(rowErrors) > 0 {
+		return nil, &domain.UserImportError{Errors: rowErrors}
+	}
+
+	job, err := s.repo.Create(&domain.UserImportJob{
+		Status:      domain.UserImportStatusPending,
+		SendInvites: sendInvites,
+		TotalRows:   len(rows),
+		CreatedBy:   importedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("User import started",
+		zap.String("event", "user_import_started"),
+		zap.Int64("jobID", job.ID),
+		zap.Int("rows", len(rows)),
+		zap.Int64("importedBy", importedBy))
+
+	go func() {
+		// An import that panics is failed rather than left pending
+		defer func() {
+			if r := recover(); r != nil {
+				s.failJob(job.ID, "the import stopped unexpectedly", fmt.Errorf("panic: %v", r))
+			}
+		}()
+		s.run(job.ID, rows, sendInvites)
+	}()
+
+	return job, nil
+}
+
+// GetByID retrieves a user import job by ID
+func (s *UserImportServiceImpl) GetByID(id int64) (*domain.UserImportJob, error) {
+	job, err := s.repo.GetByID(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserImportNotFound) {
+			s.logger.Error("Failed to get user import job", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// FailStale marks imports that have been pending for too long as failed. Their goroutine was
+// lost, e.g. to a restart, and the transaction creating the accounts rolled back with it.
+func (s *UserImportServiceImpl) FailStale() (int64, error) {
+	failed, err := s.repo.FailPendingBefore(time.Now().Add(-staleImportAge), "the import was interrupted; no accounts were created")
+	if err != nil {
+		s.logger.Error("Failed to fail stale user imports", zap.Error(err))
+		return 0, err
+	}
+
+	if failed > 0 {
+		s.logger.Warn("Stale user imports failed", zap.String("event", "user_imports_failed"), zap.Int64("count", failed))
+	}
+	return failed, nil
+}
+
+// run creates the accounts of an import in a single transaction and sends the invites
+func (s *UserImportServiceImpl) run(jobID int64, rows []*domain.UserImportRow, sendInvites bool) {
+	users := make([]*domain.User, len(rows))
+	passwords := make([]string, len(rows))
+	for i, row := range rows {
+		password, err := s.temporaryPassword()
+		if err != nil {
+			s.failJob(jobID, "temporary passwords could not be generated; no accounts were created", err)
+			return
+		}
+
+		hashedPassword, err := hashPassword(password)
+		if err != nil {
+			s.failJob(jobID, "temporary passwords could not be generated; no accounts were created", err)
+			return
+		}
+
+		passwords[i] = password
+		users[i] = &domain.User{
+			Username:           row.Username,
+			Email:              row.Email,
+			PasswordHash:       hashedPassword,
+			FirstName:          row.FirstName,
+			LastName:           row.LastName,
+			Role:               row.Role,
+			MustChangePassword: true,
+		}
+	}
+
+	createdUsers, err := s.userRepo.CreateBatch(users)
+	if err != nil {
+		reason := "the accounts could not be created; no accounts were created"
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			reason = "a username or email was taken while the import ran; no accounts were created"
+		}
+		s.failJob(jobID, reason, err)
+		return
+	}
+
+	for _, user := range createdUsers {
+		if err := s.passwordPolicy.Remember(user.ID, user.PasswordHash); err != nil {
+			s.logger.Error("Failed to record password history", zap.Int64("userID", user.ID), zap.Error(err))
+		}
+	}
+
+	invitesSent := 0
+	if sendInvites {
+		for i, user := range createdUsers {
+			if err := s.sendInvite(user, passwords[i]); err != nil {
+				s.logger.Error("Failed to send invite", zap.Int64("userID", user.ID), zap.Error(err))
+				continue
+			}
+			invitesSent++
+
+			// Receiving the invite does not prove the address belongs to the user
+			if err := s.verifier.Send(user); err != nil {
+				s.logger.Error("Failed to send verification email", zap.Int64("userID", user.ID), zap.Error(err))
+			}
+		}
+	}
+
+	if err := s.repo.Complete(jobID, len(createdUsers), invitesSent); err != nil {
+		s.logger.Error("Failed to complete user import job", zap.Int64("jobID", jobID), zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Users imported",
+		zap.String("event", "users_imported"),
+		zap.Int64("jobID", jobID),
+		zap.Int("created", len(createdUsers)),
+		zap.Int("invitesSent", invitesSent))
+}
+
+// failJob records why an import created no accounts
+func (s *UserImportServiceImpl) failJob(jobID int64, reason string, cause error) {
+	s.logger.Error("User import failed", zap.Int64("jobID", jobID), zap.Error(cause))
+	if err := s.repo.Fail(jobID, reason); err != nil {
+		s.logger.Error("Failed to mark user import job as failed", zap.Int64("jobID", jobID), zap.Error(err))
+	}
+}
+
+// sendInvite emails an imported user their username and temporary password
+func (s *UserImportServiceImpl) sendInvite(user *domain.User, password string) error {
+	return s.mailer.Send(&mailer.Message{
+		To:      user.Email,
+		Subject: "Your library account",
+		Body: fmt.Sprintf("Hello %s,\n\n"+
+			"An account has been created for you at the library. Sign in with:\n\n"+
+			"Username: %s\n"+
+			"Temporary password: %s\n\n"+
+			"Please change your password after signing in for the first time.\n",
+			user.Username, user.Username, password),
+	})
+}
+
+// temporaryPassword generates a random password that satisfies the password policy
+func (s *UserImportServiceImpl) temporaryPassword() (string, error) {
+	classes := []string{
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"abcdefghijkmnopqrstuvwxyz",
+		"23456789",
+		"!#$%&*+-=?@_",
+	}
+	all := strings.Join(classes, "")
+
+	// One character of every class, the rest from all of them, then shuffled
+	password := make([]byte, temporaryPasswordLength)
+	for i := range password {
+		set := all
+		if i < len(classes) {
+			set = classes[i]
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = set[n.Int64()]
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		j := n.Int64()
+		password[i], password[j] = password[j], password[i]
+	}
+
+	if err := s.passwordPolicy.Validate(string(password), nil); err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// check parses an import file and validates every row, also against existing accounts
+func (s *UserImportServiceImpl) check(file io.Reader) ([]*domain.UserImportRow, []domain.UserImportRowError, error) {
+	rows, err := parseUserImport(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rowErrors []domain.UserImportRowError
+	fail := func(row int, field, message string) {
+		rowErrors = append(rowErrors, domain.UserImportRowError{Row: row, Field: field, Message: message})
+	}
+
+	usernames := make(map[string]int)
+	emails := make(map[string]int)
+	for _, row := range rows {
+		if n := utf8.RuneCountInString(row.Username); n < 3 || n > 50 {
+			fail(row.Row, "username", "username must be between 3 and 50 characters long")
+		} else if first, ok := usernames[strings.ToLower(row.Username)]; ok {
+			fail(row.Row, "username", fmt.Sprintf("username is already used on row %d", first))
+		} else {
+			usernames[strings.ToLower(row.Username)] = row.Row
+			if taken, err := s.exists(s.userRepo.GetByUsername(row.Username)); err != nil {
+				return nil, nil, err
+			} else if taken {
+				fail(row.Row, "username", "username is already taken")
+			}
+		}
+
+		if address, err := mail.ParseAddress(row.Email); err != nil || address.Address != row.Email {
+			fail(row.Row, "email", "email is not a valid email address")
+		} else if first, ok := emails[strings.ToLower(row.Email)]; ok {
+			fail(row.Row, "email", fmt.Sprintf("email is already used on row %d", first))
+		} else {
+			emails[strings.ToLower(row.Email)] = row.Row
+			if taken, err := s.exists(s.userRepo.GetByEmail(row.Email)); err != nil {
+				return nil, nil, err
+			} else if taken {
+				fail(row.Row, "email", "email is already in use")
+			}
+		}
+
+		if utf8.RuneCountInString(row.FirstName) > 100 {
+			fail(row.Row, "first_name", "first name must be at most 100 characters long")
+		}
+		if utf8.RuneCountInString(row.LastName) > 100 {
+			fail(row.Row, "last_name", "last name must be at most 100 characters long")
+		}
+
+		switch row.Role {
+		case domain.RoleMember, domain.RoleLibrarian, domain.RoleAdmin:
+		default:
+			fail(row.Row, "role", "role must be member, librarian or admin")
+		}
+	}
+
+	return rows, rowErrors, nil
+}
+
+// exists reports whether a user lookup found an account
+func (s *UserImportServiceImpl) exists(user *domain.User, err error) (bool, error) {
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return false, nil
+		}
+		s.logger.Error("Failed to look up existing user", zap.Error(err))
+		return false, err
+	}
+	return true, nil
+}
+
+// parseUserImport reads the rows of a CSV import file. The header row names the columns:
+// username and email are required, first_name, last_name and role are optional.
+func parseUserImport(file io.Reader) ([]*domain.UserImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, domain.NewInvalidInputError("the import file is empty")
+		}
+		return nil, domain.NewInvalidInputError(fmt.Sprintf("the import file is not valid CSV: %v", err))
+	}
+
+	// Spreadsheet programs often start the file with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"username", "email"} {
+		if _, ok := columns[required]; !ok {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("the import file has no %s column", required))
+		}
+	}
+
+	var rows []*domain.UserImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("the import file is not valid CSV: %v", err))
+		}
+
+		if len(rows) == maxImportRows {
+			return nil, domain.NewInvalidInputError(fmt.Sprintf("the import file has more than %d rows", maxImportRows))
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &domain.UserImportRow{
+			Row:       line,
+			Username:  field("username"),
+			Email:     field("email"),
+			FirstName: field("first_name"),
+			LastName:  field("last_name"),
+			Role:      domain.UserRole(strings.ToLower(field("role"))),
+		}
+		if row.Role == "" {
+			row.Role = domain.RoleMember
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, domain.NewInvalidInputError("the import file has no rows")
+	}
+
+	return rows, nil
+}
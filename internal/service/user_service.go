@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"time"
 
 	"github.com/SimpleBookRental/backend/internal/domain"
 	"github.com/SimpleBookRental/backend/pkg/logger"
@@ -11,15 +12,26 @@ import (
 
 // UserService implements domain.UserService
 type UserService struct {
-	repo   domain.UserRepository
-	logger *logger.Logger
+	repo                    domain.UserRepository
+	sessionRepo             domain.SessionRepository
+	passwordPolicy          domain.PasswordPolicy
+	verifier                domain.EmailVerifier
+	deletedAccountRetention time.Duration
+	logger                  *logger.Logger
 }
 
+// anonymizeBatchSize caps how many deleted accounts one anonymization run processes
+const anonymizeBatchSize = 100
+
 // NewUserService creates a new UserService
-func NewUserService(repo domain.UserRepository, logger *logger.Logger) domain.UserService {
+func NewUserService(repo domain.UserRepository, sessionRepo domain.SessionRepository, passwordPolicy domain.PasswordPolicy, verifier domain.EmailVerifier, deletedAccountRetention time.Duration, logger *logger.Logger) domain.UserService {
 	return &UserService{
-		repo:   repo,
-		logger: logger,
+		repo:                    repo,
+		sessionRepo:             sessionRepo,
+		passwordPolicy:          passwordPolicy,
+		verifier:                verifier,
+		deletedAccountRetention: deletedAccountRetention,
+		logger:                  logger,
 	}
 }
 
@@ -53,14 +65,14 @@ func (s *UserService) GetByEmail(email string) (*domain.User, error) {
 	return user, nil
 }
 
-// List retrieves a list of users with pagination
-func (s *UserService) List(limit, offset int32) ([]*domain.User, error) {
-	users, err := s.repo.List(limit, offset)
+// List searches and filters users, returning one page and the total number of matches
+func (s *UserService) List(params domain.UserListParams) ([]*domain.User, int64, error) {
+	users, total, err := s.repo.List(params)
 	if err != nil {
 		s.logger.Error("Failed to list users", zap.Error(err))
-		return nil, err
+		return nil, 0, err
 	}
-	return users, nil
+	return users, total, nil
 }
 
 // Create creates a new user
@@ -85,6 +97,10 @@ func (s *UserService) Create(user *domain.User, password string) (*domain.User,
 		return nil, err
 	}
 
+	if err := s.passwordPolicy.Validate(password, nil); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := hashPassword(password)
 	if err != nil {
@@ -105,6 +121,15 @@ func (s *UserService) Create(user *domain.User, password string) (*domain.User,
 		return nil, err
 	}
 
+	if err := s.passwordPolicy.Remember(createdUser.ID, hashedPassword); err != nil {
+		s.logger.Error("Failed to record password history", zap.Int64("userID", createdUser.ID), zap.Error(err))
+	}
+
+	// The account exists either way; the user can ask for a new link once mail is working again
+	if err := s.verifier.Send(createdUser); err != nil {
+		s.logger.Error("Failed to send verification email", zap.Int64("userID", createdUser.ID), zap.Error(err))
+	}
+
 	return createdUser, nil
 }
 
@@ -144,13 +169,19 @@ func (s *UserService) Update(user *domain.User) (*domain.User, error) {
 	// Preserve password hash
 	user.PasswordHash = existingUser.PasswordHash
 
-	// Update user
+	// Update user, a new email address is no longer verified
 	updatedUser, err := s.repo.Update(user)
 	if err != nil {
 		s.logger.Error("Failed to update user", zap.Int64("id", user.ID), zap.Error(err))
 		return nil, err
 	}
 
+	if updatedUser.Email != existingUser.Email {
+		if err := s.verifier.Send(updatedUser); err != nil {
+			s.logger.Error("Failed to send verification email", zap.Int64("userID", updatedUser.ID), zap.Error(err))
+		}
+	}
+
 	return updatedUser, nil
 }
 
@@ -168,6 +199,10 @@ func (s *UserService) ChangePassword(id int64, currentPassword, newPassword stri
 		return domain.ErrInvalidPassword
 	}
 
+	if err := s.passwordPolicy.Validate(newPassword, user); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashedPassword, err := hashPassword(newPassword)
 	if err != nil {
@@ -182,16 +217,36 @@ func (s *UserService) ChangePassword(id int64, currentPassword, newPassword stri
 		return err
 	}
 
+	if err := s.passwordPolicy.Remember(id, hashedPassword); err != nil {
+		s.logger.Error("Failed to record password history", zap.Int64("userID", id), zap.Error(err))
+	}
+
 	return nil
 }
 
-// Delete deletes a user
-func (s *UserService) Delete(id int64) error {
+// Delete soft-deletes a user and ends their sessions. Rentals and payments are kept.
+func (s *UserService) Delete(id, deletedBy int64) error {
+	if id == deletedBy {
+		return domain.NewInvalidInputError("you cannot delete your own account")
+	}
+	if err := s.checkCanManageStaff(id, deletedBy, "delete"); err != nil {
+		return err
+	}
+
 	err := s.repo.Delete(id)
 	if err != nil {
-		s.logger.Error("Failed to delete user", zap.Int64("id", id), zap.Error(err))
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Error("Failed to delete user", zap.Int64("id", id), zap.Error(err))
+		}
 		return err
 	}
+
+	s.revokeSessions(id, "account_deleted")
+
+	s.logger.Warn("Account deleted",
+		zap.String("event", "account_deleted"),
+		zap.Int64("userID", id),
+		zap.Int64("deletedBy", deletedBy))
 	return nil
 }
 
@@ -212,11 +267,159 @@ func (s *UserService) ValidateCredentials(username, password string) (*domain.Us
 		return nil, domain.ErrInvalidCredentials
 	}
 
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
+// Unlock clears a login lockout on a user account
+func (s *UserService) Unlock(id, unlockedBy int64) error {
+	err := s.repo.ResetFailedLogins(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Error("Failed to unlock user", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+
+	s.logger.Warn("Account unlocked by administrator",
+		zap.String("event", "account_unlocked"),
+		zap.Int64("userID", id),
+		zap.Int64("unlockedBy", unlockedBy))
+	return nil
+}
+
+// Suspend blocks a user from signing in and ends their sessions
+func (s *UserService) Suspend(id, suspendedBy int64) error {
+	if id == suspendedBy {
+		return domain.NewInvalidInputError("you cannot suspend your own account")
+	}
+	if err := s.checkCanManageStaff(id, suspendedBy, "suspend"); err != nil {
+		return err
+	}
+
+	err := s.repo.UpdateStatus(id, domain.UserStatusSuspended)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Error("Failed to suspend user", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+
+	s.revokeSessions(id, "account_suspended")
+
+	s.logger.Warn("Account suspended",
+		zap.String("event", "account_suspended"),
+		zap.Int64("userID", id),
+		zap.Int64("suspendedBy", suspendedBy))
+	return nil
+}
+
+// checkCanManageStaff refuses the action when the target is a staff account and the acting user
+// is not an admin, so librarians cannot suspend or delete each other or an admin
+func (s *UserService) checkCanManageStaff(id, actingID int64, action string) error {
+	target, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if target.Role != domain.RoleLibrarian && target.Role != domain.RoleAdmin {
+		return nil
+	}
+
+	actor, err := s.repo.GetByID(actingID)
+	if err != nil {
+		return err
+	}
+	if actor.Role != domain.RoleAdmin {
+		s.logger.Warn("Refused to manage staff account",
+			zap.String("event", "staff_"+action+"_denied"), zap.Int64("actorID", actingID), zap.Int64("userID", id))
+		return domain.NewForbiddenError("only admins can " + action + " staff accounts")
+	}
+	return nil
+}
+
+// Reactivate lifts a suspension
+func (s *UserService) Reactivate(id, reactivatedBy int64) error {
+	err := s.repo.UpdateStatus(id, domain.UserStatusActive)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			s.logger.Error("Failed to reactivate user", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+
+	s.logger.Warn("Account reactivated",
+		zap.String("event", "account_reactivated"),
+		zap.Int64("userID", id),
+		zap.Int64("reactivatedBy", reactivatedBy))
+	return nil
+}
+
+// Anonymize scrubs the personal data of a deleted user right away instead of waiting for the retention period
+func (s *UserService) Anonymize(id, anonymizedBy int64) error {
+	err := s.repo.Anonymize(id)
+	if err != nil {
+		if !errors.Is(err, domain.ErrUserNotFound) && !errors.Is(err, domain.ErrUserNotDeleted) {
+			s.logger.Error("Failed to anonymize user", zap.Int64("id", id), zap.Error(err))
+		}
+		return err
+	}
+
+	s.logger.Warn("Account anonymized",
+		zap.String("event", "account_anonymized"),
+		zap.Int64("userID", id),
+		zap.Int64("anonymizedBy", anonymizedBy))
+	return nil
+}
+
+// AnonymizeDeletedUsers scrubs the personal data of users deleted longer ago than the retention period
+func (s *UserService) AnonymizeDeletedUsers() (int64, error) {
+	ids, err := s.repo.ListDeletedBefore(time.Now().Add(-s.deletedAccountRetention), anonymizeBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to list deleted users", zap.Error(err))
+		return 0, err
+	}
+
+	var anonymized int64
+	for _, id := range ids {
+		if err := s.repo.Anonymize(id); err != nil {
+			s.logger.Error("Failed to anonymize user", zap.Int64("id", id), zap.Error(err))
+			continue
+		}
+		anonymized++
+	}
+
+	if anonymized > 0 {
+		s.logger.Info("Anonymized deleted accounts",
+			zap.String("event", "accounts_anonymized"),
+			zap.Int64("count", anonymized))
+	}
+	return anonymized, nil
+}
+
+// revokeSessions ends every session of a user, so their access tokens stop working
+func (s *UserService) revokeSessions(userID int64, reason string) {
+	if err := s.sessionRepo.RevokeAllByUser(userID, reason); err != nil {
+		s.logger.Error("Failed to revoke sessions", zap.Int64("userID", userID), zap.Error(err))
+	}
+}
+
 // Helper functions
 
+// checkAccountActive refuses accounts that may not sign in. Deleted accounts look like
+// unknown accounts so that sign-in attempts do not reveal them.
+func checkAccountActive(user *domain.User) error {
+	switch user.Status {
+	case domain.UserStatusSuspended:
+		return domain.ErrAccountSuspended
+	case domain.UserStatusDeleted:
+		return domain.ErrInvalidCredentials
+	}
+	return nil
+}
+
 // hashPassword hashes a password
 func hashPassword(password string) (string, error) {
 	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -231,3 +434,15 @@ func verifyPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// checkEmailVerified returns ErrEmailNotVerified if the user has not verified their email address
+func checkEmailVerified(userRepo domain.UserRepository, userID int64) error {
+	user, err := userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
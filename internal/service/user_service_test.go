@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/internal/mocks"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestUserServiceSuspend(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorRole  domain.UserRole
+		targetRole domain.UserRole
+		wantErr    error
+	}{
+		{"librarian suspends member", domain.RoleLibrarian, domain.RoleMember, nil},
+		{"librarian suspends librarian", domain.RoleLibrarian, domain.RoleLibrarian, domain.ErrForbidden},
+		{"librarian suspends admin", domain.RoleLibrarian, domain.RoleAdmin, domain.ErrForbidden},
+		{"admin suspends librarian", domain.RoleAdmin, domain.RoleLibrarian, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockUserRepository(ctrl)
+			repo.EXPECT().GetByID(int64(1)).Return(&domain.User{ID: 1, Role: tt.actorRole}, nil).AnyTimes()
+			repo.EXPECT().GetByID(int64(2)).Return(&domain.User{ID: 2, Role: tt.targetRole}, nil)
+
+			sessionRepo := mocks.NewMockSessionRepository(ctrl)
+			if tt.wantErr == nil {
+				repo.EXPECT().UpdateStatus(int64(2), domain.UserStatusSuspended).Return(nil)
+				sessionRepo.EXPECT().RevokeAllByUser(int64(2), "account_suspended").Return(nil)
+			}
+
+			s := NewUserService(repo, sessionRepo, nil, nil, 0, &logger.Logger{Logger: zap.NewNop()})
+
+			if err := s.Suspend(2, 1); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Suspend() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
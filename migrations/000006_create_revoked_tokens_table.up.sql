@@ -0,0 +1,9 @@
+CREATE TABLE revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create index on expiry to keep the purge of expired entries cheap
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
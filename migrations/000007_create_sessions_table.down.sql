@@ -0,0 +1,6 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_sessions_refresh_token_id;
+DROP INDEX IF EXISTS idx_sessions_user_id;
+
+-- Drop the sessions table
+DROP TABLE IF EXISTS sessions;
@@ -0,0 +1,5 @@
+-- Drop index first
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+
+-- Drop the password_reset_tokens table
+DROP TABLE IF EXISTS password_reset_tokens;
@@ -0,0 +1,7 @@
+-- Drop index first
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id;
+
+-- Drop the email_verification_tokens table
+DROP TABLE IF EXISTS email_verification_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
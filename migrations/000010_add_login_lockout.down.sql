@@ -0,0 +1,9 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_login_attempts_created_at;
+DROP INDEX IF EXISTS idx_login_attempts_ip_address_created_at;
+
+-- Drop the login_attempts table
+DROP TABLE IF EXISTS login_attempts;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locked_until;
+ALTER TABLE users DROP COLUMN IF EXISTS failed_login_attempts;
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN failed_login_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN locked_until TIMESTAMP;
+
+CREATE TABLE login_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    username VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for per-IP throttling and purging old attempts
+CREATE INDEX idx_login_attempts_ip_address_created_at ON login_attempts(ip_address, created_at);
+CREATE INDEX idx_login_attempts_created_at ON login_attempts(created_at);
@@ -0,0 +1,8 @@
+ALTER TABLE sessions DROP COLUMN IF EXISTS mfa_verified;
+
+-- Drop index first
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user_id;
+
+-- Drop the MFA tables
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
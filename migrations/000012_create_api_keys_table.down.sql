@@ -0,0 +1,5 @@
+-- Drop index first
+DROP INDEX IF EXISTS idx_api_keys_user_id;
+
+-- Drop the api_keys table
+DROP TABLE IF EXISTS api_keys;
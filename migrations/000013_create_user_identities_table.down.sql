@@ -0,0 +1,7 @@
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_oidc_auth_requests_expires_at;
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+
+-- Drop the tables
+DROP TABLE IF EXISTS oidc_auth_requests;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,36 @@
+CREATE TABLE role_permissions (
+    role VARCHAR(20) NOT NULL,
+    permission VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (role, permission)
+);
+
+-- Members act on their own profile, rentals and payments
+INSERT INTO role_permissions (role, permission)
+SELECT 'member', unnest(ARRAY[
+    'users:read:own', 'users:update:own',
+    'rentals:read:own', 'rentals:create', 'rentals:return:own', 'rentals:extend:own',
+    'payments:read:own', 'payments:create'
+]);
+
+-- Librarians additionally run the desk, the catalog and the operational reports
+INSERT INTO role_permissions (role, permission)
+SELECT 'librarian', unnest(ARRAY[
+    'users:read:own', 'users:update:own',
+    'rentals:read:own', 'rentals:read:any', 'rentals:create',
+    'rentals:return:own', 'rentals:return:any', 'rentals:extend:own', 'rentals:extend:any',
+    'payments:read:own', 'payments:read:any', 'payments:create', 'payments:refund',
+    'categories:manage', 'books:manage', 'reports:read'
+]);
+
+-- Admins can do everything
+INSERT INTO role_permissions (role, permission)
+SELECT 'admin', unnest(ARRAY[
+    'users:read:own', 'users:read:any', 'users:update:own', 'users:update:any',
+    'users:delete', 'users:unlock', 'users:update_role',
+    'rentals:read:own', 'rentals:read:any', 'rentals:create',
+    'rentals:return:own', 'rentals:return:any', 'rentals:extend:own', 'rentals:extend:any',
+    'payments:read:own', 'payments:read:any', 'payments:create', 'payments:list', 'payments:refund',
+    'categories:manage', 'books:manage', 'reports:read', 'reports:revenue',
+    'api_keys:manage', 'permissions:manage'
+]);
@@ -0,0 +1,2 @@
+-- Remove the impersonation permission from every role
+DELETE FROM role_permissions WHERE permission = 'users:impersonate';
@@ -0,0 +1,4 @@
+-- Admins can impersonate non-admin users for support
+INSERT INTO role_permissions (role, permission)
+VALUES ('admin', 'users:impersonate')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- Drop index first
+DROP INDEX IF EXISTS idx_password_history_user_id;
+
+-- Drop the password_history table
+DROP TABLE IF EXISTS password_history;
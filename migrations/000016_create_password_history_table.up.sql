@@ -0,0 +1,13 @@
+CREATE TABLE password_history (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create index for reading a user's most recent passwords
+CREATE INDEX idx_password_history_user_id ON password_history(user_id, created_at DESC);
+
+-- Seed the history with the passwords currently in use
+INSERT INTO password_history (user_id, password_hash)
+SELECT id, password_hash FROM users;
@@ -0,0 +1,13 @@
+-- Remove the membership plan permissions from every role
+DELETE FROM role_permissions WHERE permission IN ('membership_plans:manage', 'users:assign_plan');
+
+ALTER TABLE rentals DROP COLUMN IF EXISTS extension_count;
+
+-- Drop index first
+DROP INDEX IF EXISTS idx_users_membership_plan_id;
+ALTER TABLE users DROP COLUMN IF EXISTS membership_plan_id;
+
+DROP INDEX IF EXISTS idx_membership_plans_default;
+
+-- Drop the membership_plans table
+DROP TABLE IF EXISTS membership_plans;
@@ -0,0 +1,41 @@
+CREATE TABLE membership_plans (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT,
+    max_concurrent_rentals INT NOT NULL,
+    loan_period_days INT NOT NULL,
+    max_extensions INT NOT NULL DEFAULT 0,
+    max_extension_days INT NOT NULL DEFAULT 0,
+    monthly_fee DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_membership_plan_limits CHECK (
+        max_concurrent_rentals > 0 AND loan_period_days > 0 AND
+        max_extensions >= 0 AND max_extension_days >= 0 AND monthly_fee >= 0
+    )
+);
+
+-- Only one plan can be the default for members without an assigned plan
+CREATE UNIQUE INDEX idx_membership_plans_default ON membership_plans(is_default) WHERE is_default;
+
+-- Users without a plan fall back to the default plan
+ALTER TABLE users ADD COLUMN membership_plan_id INT REFERENCES membership_plans(id) ON DELETE SET NULL;
+CREATE INDEX idx_users_membership_plan_id ON users(membership_plan_id);
+
+-- Track how often a rental has been extended
+ALTER TABLE rentals ADD COLUMN extension_count INT NOT NULL DEFAULT 0;
+
+INSERT INTO membership_plans (name, description, max_concurrent_rentals, loan_period_days, max_extensions, max_extension_days, monthly_fee, is_default)
+VALUES
+    ('basic', 'Free membership', 3, 14, 1, 7, 0, TRUE),
+    ('premium', 'More books for longer', 10, 28, 3, 14, 9.99, FALSE);
+
+-- Admins manage the plans, staff assign them to members
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('admin', 'membership_plans:manage'),
+    ('admin', 'users:assign_plan'),
+    ('librarian', 'users:assign_plan')
+ON CONFLICT DO NOTHING;
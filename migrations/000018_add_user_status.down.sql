@@ -0,0 +1,11 @@
+-- Remove the account status permissions from every role
+DELETE FROM role_permissions WHERE permission IN ('users:suspend', 'users:anonymize');
+
+-- Drop index first
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_status;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_user_status;
+ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS status;
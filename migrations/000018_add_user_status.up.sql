@@ -0,0 +1,18 @@
+ALTER TABLE users ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN anonymized_at TIMESTAMP;
+
+-- Add constraint to ensure status is one of the allowed values
+ALTER TABLE users ADD CONSTRAINT chk_user_status CHECK (status IN ('active', 'suspended', 'deleted'));
+
+-- Create indexes for filtering by status and finding deleted users due for anonymization
+CREATE INDEX idx_users_status ON users(status);
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE anonymized_at IS NULL;
+
+-- Staff can suspend accounts, only admins can scrub personal data
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('admin', 'users:suspend'),
+    ('admin', 'users:anonymize'),
+    ('librarian', 'users:suspend')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+CREATE TABLE data_exports (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    archive BYTEA,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error TEXT,
+    expires_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_data_export_status CHECK (status IN ('pending', 'ready', 'failed'))
+);
+
+-- Create index on user for listing a user's exports
+CREATE INDEX idx_data_exports_user_id ON data_exports(user_id);
+
+-- Create index on expiry for purging expired exports
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at);
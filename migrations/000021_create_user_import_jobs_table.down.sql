@@ -0,0 +1,5 @@
+-- Remove the import permission from every role
+DELETE FROM role_permissions WHERE permission = 'users:import';
+
+-- Drop the user_import_jobs table
+DROP TABLE IF EXISTS user_import_jobs;
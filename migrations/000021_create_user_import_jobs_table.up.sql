@@ -0,0 +1,19 @@
+CREATE TABLE user_import_jobs (
+    id SERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    send_invites BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INT NOT NULL,
+    created_count INT NOT NULL DEFAULT 0,
+    invites_sent INT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_by INT NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+
+    CONSTRAINT chk_user_import_status CHECK (status IN ('pending', 'completed', 'failed'))
+);
+
+-- Only admins can onboard users in bulk
+INSERT INTO role_permissions (role, permission)
+VALUES ('admin', 'users:import')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,7 @@
+-- Remove the household permission from every role
+DELETE FROM role_permissions WHERE permission = 'households:manage';
+
+DROP TABLE IF EXISTS household_dependents;
+
+-- Drop the households table
+DROP TABLE IF EXISTS households;
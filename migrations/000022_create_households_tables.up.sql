@@ -0,0 +1,26 @@
+CREATE TABLE households (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    guardian_id INT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    max_concurrent_rentals INT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_household_limits CHECK (max_concurrent_rentals IS NULL OR max_concurrent_rentals > 0)
+);
+
+-- A user can be the dependent of only one household
+CREATE TABLE household_dependents (
+    household_id INT NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+    user_id INT NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (household_id, user_id)
+);
+
+-- Staff link guardians to their dependents
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('admin', 'households:manage'),
+    ('librarian', 'households:manage')
+ON CONFLICT DO NOTHING;
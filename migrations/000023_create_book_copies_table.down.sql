@@ -0,0 +1,20 @@
+-- Remove the checkout permission from every role
+DELETE FROM role_permissions WHERE permission = 'rentals:checkout';
+
+-- Bring back the copy counters
+ALTER TABLE books ADD COLUMN total_copies INT NOT NULL DEFAULT 1;
+ALTER TABLE books ADD COLUMN available_copies INT NOT NULL DEFAULT 1;
+
+UPDATE books b
+SET total_copies = (SELECT COUNT(*) FROM book_copies bc WHERE bc.book_id = b.id AND bc.status NOT IN ('lost', 'withdrawn')),
+    available_copies = (SELECT COUNT(*) FROM book_copies bc WHERE bc.book_id = b.id AND bc.status = 'available');
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_rentals_open_copy;
+DROP INDEX IF EXISTS idx_rentals_copy_id;
+DROP INDEX IF EXISTS idx_book_copies_book_id_status;
+
+ALTER TABLE rentals DROP COLUMN IF EXISTS copy_id;
+
+-- Drop the book copies table
+DROP TABLE IF EXISTS book_copies;
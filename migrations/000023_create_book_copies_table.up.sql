@@ -0,0 +1,57 @@
+CREATE TABLE book_copies (
+    id SERIAL PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    barcode VARCHAR(50) UNIQUE NOT NULL,
+    condition VARCHAR(20) NOT NULL DEFAULT 'good',
+    shelf_location VARCHAR(100),
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_book_copy_condition CHECK (condition IN ('new', 'good', 'fair', 'poor', 'damaged')),
+    CONSTRAINT chk_book_copy_status CHECK (status IN ('available', 'checked_out', 'maintenance', 'lost', 'withdrawn'))
+);
+
+-- Availability counts are derived per book and status
+CREATE INDEX idx_book_copies_book_id_status ON book_copies(book_id, status);
+
+-- A rental holds one specific copy, and a copy is held by at most one open rental
+ALTER TABLE rentals ADD COLUMN copy_id INT REFERENCES book_copies(id);
+CREATE INDEX idx_rentals_copy_id ON rentals(copy_id);
+CREATE UNIQUE INDEX idx_rentals_open_copy ON rentals(copy_id) WHERE status IN ('active', 'overdue');
+
+-- Turn the copy counters into individual copies with generated barcodes
+INSERT INTO book_copies (book_id, barcode)
+SELECT b.id, 'BK' || LPAD(b.id::TEXT, 6, '0') || '-' || LPAD(n::TEXT, 3, '0')
+FROM books b
+CROSS JOIN LATERAL generate_series(1, GREATEST(b.total_copies, 0)) AS n;
+
+-- Hand the copies out to the rentals that are still open
+WITH open_rentals AS (
+    SELECT id, book_id, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY id) AS n
+    FROM rentals
+    WHERE status IN ('active', 'overdue')
+),
+numbered_copies AS (
+    SELECT id, book_id, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY id) AS n
+    FROM book_copies
+)
+UPDATE rentals r
+SET copy_id = c.id
+FROM open_rentals o
+JOIN numbered_copies c ON c.book_id = o.book_id AND c.n = o.n
+WHERE r.id = o.id;
+
+UPDATE book_copies
+SET status = 'checked_out'
+WHERE id IN (SELECT copy_id FROM rentals WHERE copy_id IS NOT NULL AND status IN ('active', 'overdue'));
+
+ALTER TABLE books DROP COLUMN total_copies;
+ALTER TABLE books DROP COLUMN available_copies;
+
+-- Circulation desk staff check copies out and in by barcode
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('admin', 'rentals:checkout'),
+    ('librarian', 'rentals:checkout')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,18 @@
+-- Remove the reservation permissions from every role
+DELETE FROM role_permissions WHERE permission IN ('reservations:manage:own', 'reservations:manage:any');
+
+-- Put held copies back on the shelf
+UPDATE book_copies SET status = 'available', updated_at = NOW() WHERE status = 'on_hold';
+
+ALTER TABLE book_copies DROP CONSTRAINT chk_book_copy_status;
+ALTER TABLE book_copies ADD CONSTRAINT chk_book_copy_status
+    CHECK (status IN ('available', 'checked_out', 'maintenance', 'lost', 'withdrawn'));
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_reservations_hold_expires_at;
+DROP INDEX IF EXISTS idx_reservations_book_id_status;
+DROP INDEX IF EXISTS idx_reservations_held_copy;
+DROP INDEX IF EXISTS idx_reservations_open;
+
+-- Drop the reservations table
+DROP TABLE IF EXISTS reservations;
@@ -0,0 +1,36 @@
+CREATE TABLE reservations (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    copy_id INT REFERENCES book_copies(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    hold_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_reservation_status CHECK (status IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired')),
+    CONSTRAINT chk_reservation_hold CHECK (status <> 'ready' OR (copy_id IS NOT NULL AND hold_expires_at IS NOT NULL))
+);
+
+-- A member holds at most one open reservation per book, and a copy is held for one reservation
+CREATE UNIQUE INDEX idx_reservations_open ON reservations(user_id, book_id) WHERE status IN ('waiting', 'ready');
+CREATE UNIQUE INDEX idx_reservations_held_copy ON reservations(copy_id) WHERE status = 'ready';
+
+-- Create indexes for walking the queue of a book and expiring holds
+CREATE INDEX idx_reservations_book_id_status ON reservations(book_id, status, id);
+CREATE INDEX idx_reservations_hold_expires_at ON reservations(hold_expires_at) WHERE status = 'ready';
+
+-- Copies can be kept aside for the member who reserved them
+ALTER TABLE book_copies DROP CONSTRAINT chk_book_copy_status;
+ALTER TABLE book_copies ADD CONSTRAINT chk_book_copy_status
+    CHECK (status IN ('available', 'checked_out', 'on_hold', 'maintenance', 'lost', 'withdrawn'));
+
+-- Members reserve for themselves, staff manage every queue
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('member', 'reservations:manage:own'),
+    ('librarian', 'reservations:manage:own'),
+    ('librarian', 'reservations:manage:any'),
+    ('admin', 'reservations:manage:own'),
+    ('admin', 'reservations:manage:any')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- Remove the fine permission from every role
+DELETE FROM role_permissions WHERE permission = 'fines:waive';
+
+-- Drop the link from payments first
+DROP INDEX IF EXISTS idx_payments_fine_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS fine_id;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_fines_status;
+DROP INDEX IF EXISTS idx_fines_user_id_status;
+
+-- Drop the fines table
+DROP TABLE IF EXISTS fines;
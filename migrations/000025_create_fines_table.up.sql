@@ -0,0 +1,32 @@
+CREATE TABLE fines (
+    id SERIAL PRIMARY KEY,
+    rental_id INT NOT NULL UNIQUE REFERENCES rentals(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id),
+    amount DECIMAL(10, 2) NOT NULL,
+    amount_paid DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'outstanding',
+    waived_by INT REFERENCES users(id) ON DELETE SET NULL,
+    waiver_reason TEXT,
+    waived_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_fine_status CHECK (status IN ('outstanding', 'paid', 'waived')),
+    CONSTRAINT chk_fine_amount CHECK (amount > 0 AND amount_paid >= 0),
+    CONSTRAINT chk_fine_waiver CHECK (status <> 'waived' OR waiver_reason IS NOT NULL)
+);
+
+-- Create indexes for faster lookups
+CREATE INDEX idx_fines_user_id_status ON fines(user_id, status);
+CREATE INDEX idx_fines_status ON fines(status);
+
+-- Payments can settle a fine
+ALTER TABLE payments ADD COLUMN fine_id INT REFERENCES fines(id);
+CREATE INDEX idx_payments_fine_id ON payments(fine_id);
+
+-- Librarians and admins can waive fines
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('librarian', 'fines:waive'),
+    ('admin', 'fines:waive')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- Remove the loan rule permissions from every role
+DELETE FROM role_permissions WHERE permission IN ('loan_rules:manage', 'loan_rules:read');
+
+-- Drop indexes first
+DROP INDEX IF EXISTS idx_loan_rules_criteria;
+
+-- Drop the loan_rules table
+DROP TABLE IF EXISTS loan_rules;
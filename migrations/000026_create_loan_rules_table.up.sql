@@ -0,0 +1,32 @@
+CREATE TABLE loan_rules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+    membership_plan_id INT REFERENCES membership_plans(id) ON DELETE CASCADE,
+    book_id INT REFERENCES books(id) ON DELETE CASCADE,
+    loan_period_days INT NOT NULL,
+    max_renewals INT NOT NULL DEFAULT 0,
+    fine_per_day DECIMAL(10, 2) NOT NULL,
+    fine_cap DECIMAL(10, 2),
+    grace_period_days INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_loan_rule_terms CHECK (
+        loan_period_days > 0 AND max_renewals >= 0 AND fine_per_day >= 0 AND
+        (fine_cap IS NULL OR fine_cap >= 0) AND grace_period_days >= 0
+    )
+);
+
+-- At most one rule per combination of criteria, an empty criterion matches anything
+CREATE UNIQUE INDEX idx_loan_rules_criteria ON loan_rules(
+    COALESCE(category_id, 0), COALESCE(membership_plan_id, 0), COALESCE(book_id, 0)
+);
+
+-- Admins manage the rules, staff look up which rule applies
+INSERT INTO role_permissions (role, permission)
+VALUES
+    ('admin', 'loan_rules:manage'),
+    ('admin', 'loan_rules:read'),
+    ('librarian', 'loan_rules:read')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- Remove the calendar permission from every role
+DELETE FROM role_permissions WHERE permission = 'calendar:manage';
+
+-- Drop the library calendar tables
+DROP TABLE IF EXISTS library_closures;
+DROP TABLE IF EXISTS opening_hours;
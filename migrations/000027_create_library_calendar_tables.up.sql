@@ -0,0 +1,33 @@
+CREATE TABLE opening_hours (
+    weekday SMALLINT PRIMARY KEY, -- 0 is Sunday
+    opens_at TIME,
+    closes_at TIME,
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_opening_hours_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_opening_hours_times CHECK (
+        closed OR (opens_at IS NOT NULL AND closes_at IS NOT NULL AND opens_at < closes_at)
+    )
+);
+
+-- The library starts out open every day, so existing due dates and fines are unchanged
+INSERT INTO opening_hours (weekday, opens_at, closes_at, closed)
+SELECT weekday, '09:00', '18:00', FALSE
+FROM generate_series(0, 6) AS weekday;
+
+CREATE TABLE library_closures (
+    id SERIAL PRIMARY KEY,
+    closure_date DATE NOT NULL UNIQUE,
+    reason VARCHAR(255) NOT NULL,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual',
+    created_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_library_closure_source CHECK (source IN ('manual', 'ical'))
+);
+
+-- Admins manage the calendar
+INSERT INTO role_permissions (role, permission)
+VALUES ('admin', 'calendar:manage')
+ON CONFLICT DO NOTHING;
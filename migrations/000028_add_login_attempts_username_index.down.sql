@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_login_attempts_username_created_at;
@@ -0,0 +1,2 @@
+-- Failed logins for usernames that match no account are looked up by username
+CREATE INDEX idx_login_attempts_username_created_at ON login_attempts(username, created_at) WHERE user_id IS NULL;
@@ -0,0 +1,2 @@
+-- Accounts created with a generated password have to choose their own
+ALTER TABLE users ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT FALSE;
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -18,6 +20,8 @@ const (
 	AccessToken TokenType = "access"
 	// RefreshToken represents a refresh token
 	RefreshToken TokenType = "refresh"
+	// MFAPendingToken represents a short-lived token proving the password step of a two-factor login
+	MFAPendingToken TokenType = "mfa_pending"
 )
 
 // Claims represents the JWT claims
@@ -26,39 +30,102 @@ type Claims struct {
 	Username string    `json:"username"`
 	Role     string    `json:"role"`
 	TokenType TokenType `json:"token_type"`
+	SessionID int64     `json:"sid,omitempty"`
+	MFA       bool      `json:"mfa,omitempty"` // Set when the session passed two-factor authentication
+	Actor     *Actor    `json:"actor,omitempty"` // Set when an admin is impersonating the user
 	jwt.RegisteredClaims
 }
 
-// JWTService provides JWT token generation and validation
+// Actor identifies the user who is really making the requests of an impersonation token
+type Actor struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// JWTService provides JWT token generation and validation.
+// Tokens are signed with HS256 and the shared secret unless asymmetric signing keys are configured.
 type JWTService struct {
 	config *config.JWTConfig
+	keys   *KeySet
 }
 
 // NewJWTService creates a new JWTService
-func NewJWTService(config *config.JWTConfig) *JWTService {
-	return &JWTService{
+func NewJWTService(config *config.JWTConfig) (*JWTService, error) {
+	service := &JWTService{
 		config: config,
 	}
+
+	if config.SigningKeys != "" {
+		keys, err := LoadKeySet(config.SigningKeys, config.ActiveKeyID)
+		if err != nil {
+			return nil, err
+		}
+		service.keys = keys
+	}
+
+	return service, nil
+}
+
+// GenerateAccessToken generates a new access token bound to a session
+func (s *JWTService) GenerateAccessToken(user *domain.User, sessionID int64, mfa bool) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+	return s.generateToken(user, AccessToken, s.config.ExpirationHours, sessionID, tokenID, mfa, nil)
+}
+
+// GenerateImpersonationToken generates a short-lived access token that acts as the target user
+// on behalf of the actor. It is bound to the actor's session so ending that session ends the impersonation.
+func (s *JWTService) GenerateImpersonationToken(target, actor *domain.User, actorSessionID int64, mfa bool) (string, time.Time, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token, err := s.generateToken(target, AccessToken, s.config.ImpersonationExpiration, actorSessionID, tokenID, mfa,
+		&Actor{UserID: actor.ID, Username: actor.Username})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, time.Now().Add(s.config.ImpersonationExpiration), nil
 }
 
-// GenerateAccessToken generates a new access token
-func (s *JWTService) GenerateAccessToken(user *domain.User) (string, error) {
-	return s.generateToken(user, AccessToken, s.config.ExpirationHours)
+// GenerateRefreshToken generates a new refresh token for a session.
+// The token ID is chosen by the caller so it can be recorded on the session first.
+func (s *JWTService) GenerateRefreshToken(user *domain.User, sessionID int64, tokenID string) (string, error) {
+	return s.generateToken(user, RefreshToken, s.config.RefreshExpiration, sessionID, tokenID, false, nil)
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (s *JWTService) GenerateRefreshToken(user *domain.User) (string, error) {
-	return s.generateToken(user, RefreshToken, s.config.RefreshExpiration)
+// GenerateMFAPendingToken generates a token that can only be exchanged for a session
+// together with a valid second factor
+func (s *JWTService) GenerateMFAPendingToken(user *domain.User) (string, error) {
+	tokenID, err := NewTokenID()
+	if err != nil {
+		return "", err
+	}
+	return s.generateToken(user, MFAPendingToken, s.config.MFAPendingExpiration, 0, tokenID, false, nil)
+}
+
+// RefreshTokenExpiry returns the expiry time of a refresh token issued now
+func (s *JWTService) RefreshTokenExpiry() time.Time {
+	return time.Now().Add(s.config.RefreshExpiration)
 }
 
 // ValidateToken validates a token and returns the claims
 func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	})
+	var token *jwt.Token
+	var err error
+
+	if s.keys == nil {
+		token, err = jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(s.config.Secret), nil
+		})
+	} else {
+		token, err = jwt.ParseWithClaims(tokenString, &Claims{}, s.lookupKey, jwt.WithValidMethods(s.keys.Methods()))
+	}
 
 	if err != nil {
 		return nil, err
@@ -72,13 +139,17 @@ func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 }
 
 // generateToken generates a new token
-func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, expiration time.Duration) (string, error) {
+func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, expiration time.Duration, sessionID int64, tokenID string, mfa bool, actor *Actor) (string, error) {
 	claims := &Claims{
 		UserID:   user.ID,
 		Username: user.Username,
 		Role:     string(user.Role),
 		TokenType: tokenType,
+		SessionID: sessionID,
+		MFA:       mfa,
+		Actor:     actor,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -87,8 +158,49 @@ func (s *JWTService) generateToken(user *domain.User, tokenType TokenType, expir
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.Secret))
+	if s.keys == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(s.config.Secret))
+	}
+
+	key := s.keys.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// lookupKey returns the public key named by the token's kid header
+func (s *JWTService) lookupKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys.Lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown or retired signing key: %q", kid)
+	}
+
+	// The algorithm is fixed by the key, never chosen by the token
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %v for key %q", token.Header["alg"], kid)
+	}
+
+	return key.PublicKey, nil
+}
+
+// JWKS returns the public keys that verify tokens, for services validating tokens offline.
+// The set is empty when tokens are signed with a shared secret.
+func (s *JWTService) JWKS() *JWKS {
+	if s.keys == nil {
+		return &JWKS{Keys: []JWK{}}
+	}
+	return s.keys.JWKS()
+}
+
+// NewTokenID generates a random token identifier (jti) used for revocation and rotation
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
 // GetUserIDFromToken extracts the user ID from a token
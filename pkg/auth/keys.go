@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is an asymmetric key used to sign or verify tokens
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+	// NotAfter ends the grace period of a retired key; tokens signed with it are rejected afterwards
+	NotAfter *time.Time
+}
+
+// usable checks if the key may still verify tokens
+func (k *SigningKey) usable(now time.Time) bool {
+	return k.NotAfter == nil || now.Before(*k.NotAfter)
+}
+
+// KeySet holds the key used to sign new tokens and every key still accepted for verification
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// LoadKeySet parses a key specification of the form "kid=path[@notAfter],kid=path[@notAfter]"
+// and selects the active signing key. Paths point to PEM encoded RSA (RS256) or Ed25519 (EdDSA)
+// private keys; notAfter is an RFC 3339 time after which a retired key is no longer accepted.
+func LoadKeySet(spec, activeID string) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*SigningKey)}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, err := parseKeyEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate signing key id %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q is not configured", activeID)
+	}
+	if active.NotAfter != nil {
+		return nil, fmt.Errorf("active signing key %q must not have an end of grace period", activeID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+// Active returns the key used to sign new tokens
+func (s *KeySet) Active() *SigningKey {
+	return s.active
+}
+
+// Lookup returns a key that may still verify tokens
+func (s *KeySet) Lookup(kid string) (*SigningKey, bool) {
+	key, ok := s.keys[kid]
+	if !ok || !key.usable(time.Now()) {
+		return nil, false
+	}
+	return key, true
+}
+
+// Methods returns the names of the signing algorithms in use
+func (s *KeySet) Methods() []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, key := range s.keys {
+		alg := key.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// JWKS returns the public keys that may still verify tokens as a JSON Web Key Set
+func (s *KeySet) JWKS() *JWKS {
+	now := time.Now()
+	jwks := &JWKS{Keys: []JWK{}}
+	for _, key := range s.keys {
+		if key.usable(now) {
+			jwks.Keys = append(jwks.Keys, newJWK(key))
+		}
+	}
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].Kid < jwks.Keys[j].Kid })
+	return jwks
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK is a public JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// newJWK converts the public part of a signing key into a JWK
+func newJWK(key *SigningKey) JWK {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+// parseKeyEntry parses a single "kid=path[@notAfter]" entry and loads the key file
+func parseKeyEntry(entry string) (*SigningKey, error) {
+	kid, rest, ok := strings.Cut(entry, "=")
+	if !ok || kid == "" || rest == "" {
+		return nil, fmt.Errorf("invalid signing key entry %q, expected kid=path", entry)
+	}
+
+	path, notAfterValue, hasNotAfter := strings.Cut(rest, "@")
+
+	key := &SigningKey{ID: kid}
+	if hasNotAfter {
+		notAfter, err := time.Parse(time.RFC3339, notAfterValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end of grace period for signing key %q: %w", kid, err)
+		}
+		key.NotAfter = &notAfter
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key %q: %w", kid, err)
+	}
+
+	privateKey, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %q: %w", kid, err)
+	}
+
+	switch pk := privateKey.(type) {
+	case *rsa.PrivateKey:
+		key.Method = jwt.SigningMethodRS256
+		key.PrivateKey = pk
+		key.PublicKey = &pk.PublicKey
+	case ed25519.PrivateKey:
+		key.Method = jwt.SigningMethodEdDSA
+		key.PrivateKey = pk
+		key.PublicKey = pk.Public()
+	default:
+		return nil, fmt.Errorf("signing key %q must be an RSA or Ed25519 key", kid)
+	}
+
+	return key, nil
+}
+
+// parsePrivateKey decodes a PEM encoded PKCS#8 or PKCS#1 private key
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SimpleBookRental/backend/internal/domain"
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeRSAKey writes a new PKCS#1 encoded RSA private key to the directory and returns its path
+func writeRSAKey(t *testing.T, dir, name string) (string, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	return writePEM(t, dir, name, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)), key
+}
+
+// writeEd25519Key writes a new PKCS#8 encoded Ed25519 private key to the directory and returns its path
+func writeEd25519Key(t *testing.T, dir, name string) (string, ed25519.PrivateKey) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to encode Ed25519 key: %v", err)
+	}
+	return writePEM(t, dir, name, "PRIVATE KEY", der), key
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name+".pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+	return path
+}
+
+func newTestJWTService(t *testing.T, signingKeys, activeKeyID string) *JWTService {
+	t.Helper()
+	service, err := NewJWTService(&config.JWTConfig{
+		Secret:          "test-secret",
+		ExpirationHours: time.Hour,
+		SigningKeys:     signingKeys,
+		ActiveKeyID:     activeKeyID,
+	})
+	if err != nil {
+		t.Fatalf("NewJWTService() error = %v", err)
+	}
+	return service
+}
+
+// tokenHeader returns a header field of a token without verifying it
+func tokenHeader(t *testing.T, tokenString, name string) string {
+	t.Helper()
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, &Claims{})
+	if err != nil {
+		t.Fatalf("Failed to parse token: %v", err)
+	}
+	value, _ := token.Header[name].(string)
+	return value
+}
+
+var testUser = &domain.User{ID: 7, Username: "reader", Role: domain.RoleMember}
+
+func TestJWTServiceAsymmetricSigning(t *testing.T) {
+	dir := t.TempDir()
+	rsaPath, _ := writeRSAKey(t, dir, "rsa")
+	edPath, _ := writeEd25519Key(t, dir, "ed")
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantAlg string
+	}{
+		{"RS256", "k1=" + rsaPath, "RS256"},
+		{"EdDSA", "k1=" + edPath, "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestJWTService(t, tt.spec, "k1")
+
+			token, err := service.GenerateAccessToken(testUser, 3, true)
+			if err != nil {
+				t.Fatalf("GenerateAccessToken() error = %v", err)
+			}
+			if alg := tokenHeader(t, token, "alg"); alg != tt.wantAlg {
+				t.Errorf("alg = %q, want %q", alg, tt.wantAlg)
+			}
+			if kid := tokenHeader(t, token, "kid"); kid != "k1" {
+				t.Errorf("kid = %q, want %q", kid, "k1")
+			}
+
+			claims, err := service.ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if claims.UserID != testUser.ID || claims.SessionID != 3 || !claims.MFA || claims.TokenType != AccessToken {
+				t.Errorf("ValidateToken() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestJWTServiceRejectsSharedSecretTokensWithSigningKeys(t *testing.T) {
+	rsaPath, _ := writeRSAKey(t, t.TempDir(), "rsa")
+
+	// A token signed with the shared secret must not be accepted once signing keys are configured
+	token, err := newTestJWTService(t, "", "").GenerateAccessToken(testUser, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	if _, err := newTestJWTService(t, "k1="+rsaPath, "k1").ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted an HS256 token")
+	}
+}
+
+func TestJWTServiceKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, _ := writeRSAKey(t, dir, "old")
+	newPath, _ := writeEd25519Key(t, dir, "new")
+
+	oldToken, err := newTestJWTService(t, "old="+oldPath, "old").GenerateAccessToken(testUser, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	// After rotation new tokens use the new key and tokens signed with the old key are still accepted
+	gracePeriodEnd := time.Now().Add(time.Hour).Format(time.RFC3339)
+	rotated := newTestJWTService(t, "old="+oldPath+"@"+gracePeriodEnd+",new="+newPath, "new")
+
+	newToken, err := rotated.GenerateAccessToken(testUser, 3, false)
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+	if kid := tokenHeader(t, newToken, "kid"); kid != "new" {
+		t.Errorf("kid = %q, want %q", kid, "new")
+	}
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := rotated.ValidateToken(token); err != nil {
+			t.Errorf("ValidateToken() of the token signed with the %s key error = %v", name, err)
+		}
+	}
+
+	// Once the grace period has ended the old key is no longer accepted
+	gracePeriodEnd = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	retired := newTestJWTService(t, "old="+oldPath+"@"+gracePeriodEnd+",new="+newPath, "new")
+	if _, err := retired.ValidateToken(oldToken); err == nil {
+		t.Error("ValidateToken() accepted a token signed with a retired key")
+	}
+	if _, err := retired.ValidateToken(newToken); err != nil {
+		t.Errorf("ValidateToken() error = %v", err)
+	}
+
+	// A key that was removed from the set is unknown
+	if _, err := newTestJWTService(t, "new="+newPath, "new").ValidateToken(oldToken); err == nil {
+		t.Error("ValidateToken() accepted a token signed with an unknown key")
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	dir := t.TempDir()
+	rsaPath, rsaKey := writeRSAKey(t, dir, "rsa")
+	edPath, edKey := writeEd25519Key(t, dir, "ed")
+	retiredPath, _ := writeRSAKey(t, dir, "retired")
+
+	spec := strings.Join([]string{
+		"b-rsa=" + rsaPath,
+		"a-ed=" + edPath,
+		"c-retired=" + retiredPath + "@" + time.Now().Add(-time.Minute).Format(time.RFC3339),
+	}, ",")
+	keys, err := LoadKeySet(spec, "b-rsa")
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+
+	// Retired keys are left out and the keys are sorted by kid
+	jwks := keys.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("JWKS() returned %d keys, want 2", len(jwks.Keys))
+	}
+
+	ed := jwks.Keys[0]
+	wantX := base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey))
+	if ed.Kid != "a-ed" || ed.Kty != "OKP" || ed.Crv != "Ed25519" || ed.Alg != "EdDSA" || ed.Use != "sig" || ed.X != wantX {
+		t.Errorf("JWKS() Ed25519 key = %+v", ed)
+	}
+
+	rsaJWK := jwks.Keys[1]
+	if rsaJWK.Kid != "b-rsa" || rsaJWK.Kty != "RSA" || rsaJWK.Alg != "RS256" || rsaJWK.Use != "sig" {
+		t.Errorf("JWKS() RSA key = %+v", rsaJWK)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	if err != nil || new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 {
+		t.Errorf("JWKS() RSA modulus does not match the key")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(rsaJWK.E)
+	if err != nil || new(big.Int).SetBytes(e).Int64() != int64(rsaKey.E) {
+		t.Errorf("JWKS() RSA exponent = %q, want %d", rsaJWK.E, rsaKey.E)
+	}
+}
+
+func TestJWTServiceJWKSWithSharedSecret(t *testing.T) {
+	if jwks := newTestJWTService(t, "", "").JWKS(); len(jwks.Keys) != 0 {
+		t.Errorf("JWKS() returned %d keys, want none", len(jwks.Keys))
+	}
+}
+
+func TestLoadKeySetErrors(t *testing.T) {
+	dir := t.TempDir()
+	keyPath, _ := writeEd25519Key(t, dir, "key")
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name     string
+		spec     string
+		activeID string
+	}{
+		{"active key not configured", "k1=" + keyPath, "k2"},
+		{"active key is retiring", "k1=" + keyPath + "@" + future, "k1"},
+		{"duplicate key id", "k1=" + keyPath + ",k1=" + keyPath, "k1"},
+		{"missing path", "k1=", "k1"},
+		{"invalid end of grace period", "k1=" + keyPath + "@tomorrow", "k1"},
+		{"missing key file", "k1=" + filepath.Join(dir, "missing.pem"), "k1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadKeySet(tt.spec, tt.activeID); err == nil {
+				t.Error("LoadKeySet() error = nil, want an error")
+			}
+		})
+	}
+}
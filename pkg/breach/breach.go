@@ -0,0 +1,104 @@
+// Package breach checks passwords against lists of breached passwords using the k-anonymity
+// range model: candidates are looked up by the first five hex characters of the password's SHA-1
+// hash, so a list source never needs the full hash of the password being checked.
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// prefixLength is the number of hash characters used for a range query
+const prefixLength = 5
+
+// RangeSource returns the hash suffixes, with their breach counts, of the breached passwords
+// whose upper-case hex SHA-1 hash starts with prefix
+type RangeSource interface {
+	Range(prefix string) (map[string]int, error)
+}
+
+// FileSource is a RangeSource backed by a local list of breached password hashes
+type FileSource struct {
+	ranges map[string]map[string]int
+}
+
+// LoadFile reads a breached password list. Each line holds an SHA-1 hash in hex, optionally
+// followed by ":count" as in the downloadable Pwned Passwords lists. Blank lines and lines
+// starting with "#" are ignored.
+func LoadFile(path string) (*FileSource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	source := &FileSource{ranges: make(map[string]map[string]int)}
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		hash, countText, hasCount := strings.Cut(text, ":")
+		hash = strings.ToUpper(hash)
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("breached password list line %d: invalid hash", line)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("breached password list line %d: invalid hash", line)
+		}
+
+		count := 1
+		if hasCount {
+			count, err = strconv.Atoi(countText)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("breached password list line %d: invalid count", line)
+			}
+		}
+
+		prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+		if source.ranges[prefix] == nil {
+			source.ranges[prefix] = make(map[string]int)
+		}
+		source.ranges[prefix][suffix] += count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password list: %w", err)
+	}
+
+	return source, nil
+}
+
+// Range returns the suffixes listed under a hash prefix
+func (f *FileSource) Range(prefix string) (map[string]int, error) {
+	return f.ranges[strings.ToUpper(prefix)], nil
+}
+
+// Checker looks passwords up in a RangeSource
+type Checker struct {
+	source RangeSource
+}
+
+// NewChecker creates a new Checker
+func NewChecker(source RangeSource) *Checker {
+	return &Checker{source: source}
+}
+
+// Count returns how often the password appears in breaches, or 0 if it is not listed
+func (c *Checker) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := c.source.Range(hash[:prefixLength])
+	if err != nil {
+		return 0, err
+	}
+	return suffixes[hash[prefixLength:]], nil
+}
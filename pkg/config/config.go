@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -15,6 +16,12 @@ type Config struct {
 	Logger    LoggingConfig
 	Rental    RentalConfig
 	RateLimit RateLimitConfig
+	Auth      AuthConfig
+	Mail      MailConfig
+	OIDC      OIDCConfig
+	Password  PasswordPolicyConfig
+	Export    DataExportConfig
+	Jobs      JobsConfig
 }
 
 // ServerConfig holds server configuration
@@ -40,9 +47,14 @@ type DatabaseConfig struct {
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret            string
-	ExpirationHours   time.Duration
-	RefreshExpiration time.Duration
+	Secret                  string
+	ExpirationHours         time.Duration
+	RefreshExpiration       time.Duration
+	RevocationPurgeInterval time.Duration
+	MFAPendingExpiration    time.Duration
+	ImpersonationExpiration time.Duration
+	SigningKeys             string
+	ActiveKeyID             string
 }
 
 // LoggingConfig holds logging configuration
@@ -53,9 +65,17 @@ type LoggingConfig struct {
 
 // RentalConfig holds rental configuration
 type RentalConfig struct {
-	DefaultRentalDays      int
-	MaxRentalExtensionDays int
-	LateFeePerDay          float64
+	LateFeePerDay     float64
+	MaxOverdueRentals int           // Members with more overdue rentals may not borrow
+	MaxUnpaidFees     float64       // Members owing more late fees may not borrow
+	HoldPickupWindow  time.Duration // How long a copy stays on hold for the member who reserved it
+}
+
+// JobsConfig holds background job configuration. An interval of zero disables its job.
+type JobsConfig struct {
+	Enabled                  bool // Replicas that only serve requests can turn the scheduler off
+	MarkOverdueInterval      time.Duration
+	ReservationHoldsInterval time.Duration
 }
 
 // RateLimitConfig holds rate limiting configuration
@@ -64,6 +84,68 @@ type RateLimitConfig struct {
 	Duration time.Duration
 }
 
+// AuthConfig holds account security configuration
+type AuthConfig struct {
+	PasswordResetExpiration     time.Duration
+	PasswordResetURL            string
+	EmailVerificationExpiration time.Duration
+	EmailVerificationURL        string
+	RequireVerifiedEmail        bool
+	MaxFailedLogins             int
+	LockoutDuration             time.Duration
+	LoginThrottleBaseDelay      time.Duration
+	MaxFailedLoginsPerIP        int
+	LoginIPWindow               time.Duration
+	LoginAttemptRetention       time.Duration
+	MFARequiredForStaff         bool
+	MFAIssuer                   string
+	DeletedAccountRetention     time.Duration // How long deleted accounts keep their personal data before anonymization
+}
+
+// PasswordPolicyConfig holds the rules new passwords must satisfy
+type PasswordPolicyConfig struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	HistorySize      int    // Number of previous passwords that cannot be reused, 0 to allow reuse
+	BreachedListFile string // SHA-1 hash list of breached passwords, empty to skip the check
+}
+
+// DataExportConfig holds configuration for exports of a user's personal data
+type DataExportConfig struct {
+	SyncMaxRecords int           // Exports with more records are generated in the background
+	LinkExpiration time.Duration // How long the download link of a background export works
+	DownloadURL    string
+}
+
+// OIDCConfig holds OpenID Connect login configuration
+type OIDCConfig struct {
+	Providers       map[string]OIDCProviderConfig
+	StateExpiration time.Duration
+}
+
+// OIDCProviderConfig holds the client registration at an external identity provider
+type OIDCProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// MailConfig holds outgoing email configuration
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	OutputFile   string
+}
+
 // Load loads configuration from environment variables and .env file
 func Load() (*Config, error) {
 	viper.SetConfigName(".env")
@@ -122,28 +204,110 @@ func Load() (*Config, error) {
 			RunMigrations: viper.GetBool("DB_RUN_MIGRATIONS"),
 		},
 		JWT: JWTConfig{
-			Secret:            viper.GetString("JWT_SECRET"),
-			ExpirationHours:   viper.GetDuration("JWT_EXPIRATION"),
-			RefreshExpiration: viper.GetDuration("JWT_REFRESH_EXPIRATION"),
+			Secret:                  viper.GetString("JWT_SECRET"),
+			ExpirationHours:         viper.GetDuration("JWT_EXPIRATION"),
+			RefreshExpiration:       viper.GetDuration("JWT_REFRESH_EXPIRATION"),
+			RevocationPurgeInterval: viper.GetDuration("JWT_REVOCATION_PURGE_INTERVAL"),
+			MFAPendingExpiration:    viper.GetDuration("JWT_MFA_PENDING_EXPIRATION"),
+			ImpersonationExpiration: viper.GetDuration("JWT_IMPERSONATION_EXPIRATION"),
+			SigningKeys:             viper.GetString("JWT_SIGNING_KEYS"),
+			ActiveKeyID:             viper.GetString("JWT_ACTIVE_KEY_ID"),
 		},
 		Logger: LoggingConfig{
 			Level:  viper.GetString("LOG_LEVEL"),
 			Format: viper.GetString("LOG_FORMAT"),
 		},
 		Rental: RentalConfig{
-			DefaultRentalDays:      viper.GetInt("DEFAULT_RENTAL_DAYS"),
-			MaxRentalExtensionDays: viper.GetInt("MAX_RENTAL_EXTENSION_DAYS"),
-			LateFeePerDay:          viper.GetFloat64("LATE_FEE_PER_DAY"),
+			LateFeePerDay:     viper.GetFloat64("LATE_FEE_PER_DAY"),
+			MaxOverdueRentals: viper.GetInt("MAX_OVERDUE_RENTALS"),
+			MaxUnpaidFees:     viper.GetFloat64("MAX_UNPAID_FEES"),
+			HoldPickupWindow:  viper.GetDuration("HOLD_PICKUP_WINDOW"),
 		},
 		RateLimit: RateLimitConfig{
 			Requests: viper.GetInt("RATE_LIMIT_REQUESTS"),
 			Duration: viper.GetDuration("RATE_LIMIT_DURATION"),
 		},
+		Auth: AuthConfig{
+			PasswordResetExpiration:     viper.GetDuration("PASSWORD_RESET_EXPIRATION"),
+			PasswordResetURL:            viper.GetString("PASSWORD_RESET_URL"),
+			EmailVerificationExpiration: viper.GetDuration("EMAIL_VERIFICATION_EXPIRATION"),
+			EmailVerificationURL:        viper.GetString("EMAIL_VERIFICATION_URL"),
+			RequireVerifiedEmail:        viper.GetBool("REQUIRE_VERIFIED_EMAIL"),
+			MaxFailedLogins:             viper.GetInt("LOGIN_MAX_FAILED_ATTEMPTS"),
+			LockoutDuration:             viper.GetDuration("LOGIN_LOCKOUT_DURATION"),
+			LoginThrottleBaseDelay:      viper.GetDuration("LOGIN_THROTTLE_BASE_DELAY"),
+			MaxFailedLoginsPerIP:        viper.GetInt("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP"),
+			LoginIPWindow:               viper.GetDuration("LOGIN_IP_WINDOW"),
+			LoginAttemptRetention:       viper.GetDuration("LOGIN_ATTEMPT_RETENTION"),
+			MFARequiredForStaff:         viper.GetBool("MFA_REQUIRED_FOR_STAFF"),
+			MFAIssuer:                   viper.GetString("MFA_ISSUER"),
+			DeletedAccountRetention:     viper.GetDuration("DELETED_ACCOUNT_RETENTION"),
+		},
+		Mail: MailConfig{
+			Driver:       viper.GetString("MAIL_DRIVER"),
+			From:         viper.GetString("MAIL_FROM"),
+			SMTPHost:     viper.GetString("SMTP_HOST"),
+			SMTPPort:     viper.GetInt("SMTP_PORT"),
+			SMTPUsername: viper.GetString("SMTP_USERNAME"),
+			SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+			OutputFile:   viper.GetString("MAIL_OUTPUT_FILE"),
+		},
+		OIDC: OIDCConfig{
+			Providers:       loadOIDCProviders(),
+			StateExpiration: viper.GetDuration("OIDC_STATE_EXPIRATION"),
+		},
+		Password: PasswordPolicyConfig{
+			MinLength:        viper.GetInt("PASSWORD_MIN_LENGTH"),
+			RequireUppercase: viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+			RequireLowercase: viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+			RequireDigit:     viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+			RequireSymbol:    viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+			HistorySize:      viper.GetInt("PASSWORD_HISTORY_SIZE"),
+			BreachedListFile: viper.GetString("PASSWORD_BREACHED_LIST_FILE"),
+		},
+		Export: DataExportConfig{
+			SyncMaxRecords: viper.GetInt("DATA_EXPORT_SYNC_MAX_RECORDS"),
+			LinkExpiration: viper.GetDuration("DATA_EXPORT_LINK_EXPIRATION"),
+			DownloadURL:    viper.GetString("DATA_EXPORT_DOWNLOAD_URL"),
+		},
+		Jobs: JobsConfig{
+			Enabled:                  viper.GetBool("JOBS_ENABLED"),
+			MarkOverdueInterval:      viper.GetDuration("JOB_MARK_OVERDUE_INTERVAL"),
+			ReservationHoldsInterval: viper.GetDuration("JOB_RESERVATION_HOLDS_INTERVAL"),
+		},
 	}
 
 	return config, nil
 }
 
+// loadOIDCProviders reads the providers listed in OIDC_PROVIDERS. Each provider named
+// "campus" is configured with OIDC_CAMPUS_ISSUER, OIDC_CAMPUS_CLIENT_ID,
+// OIDC_CAMPUS_CLIENT_SECRET, OIDC_CAMPUS_REDIRECT_URL and optionally OIDC_CAMPUS_SCOPES.
+func loadOIDCProviders() map[string]OIDCProviderConfig {
+	providers := make(map[string]OIDCProviderConfig)
+	for _, name := range strings.Split(viper.GetString("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		scopes := strings.Fields(strings.ReplaceAll(viper.GetString(prefix+"SCOPES"), ",", " "))
+		if len(scopes) == 0 {
+			scopes = []string{"openid", "email", "profile"}
+		}
+
+		providers[name] = OIDCProviderConfig{
+			Issuer:       viper.GetString(prefix + "ISSUER"),
+			ClientID:     viper.GetString(prefix + "CLIENT_ID"),
+			ClientSecret: viper.GetString(prefix + "CLIENT_SECRET"),
+			RedirectURL:  viper.GetString(prefix + "REDIRECT_URL"),
+			Scopes:       scopes,
+		}
+	}
+	return providers
+}
+
 // setDefaults sets default values for configuration
 func setDefaults() {
 	// Server defaults
@@ -167,19 +331,71 @@ func setDefaults() {
 	viper.SetDefault("JWT_SECRET", "your_jwt_secret_key_here")
 	viper.SetDefault("JWT_EXPIRATION", "24h")
 	viper.SetDefault("JWT_REFRESH_EXPIRATION", "168h")
+	viper.SetDefault("JWT_REVOCATION_PURGE_INTERVAL", "1h")
+	viper.SetDefault("JWT_MFA_PENDING_EXPIRATION", "5m")
+	viper.SetDefault("JWT_IMPERSONATION_EXPIRATION", "15m")
+	viper.SetDefault("JWT_SIGNING_KEYS", "")
+	viper.SetDefault("JWT_ACTIVE_KEY_ID", "")
 
 	// Logging defaults
 	viper.SetDefault("LOG_LEVEL", "debug")
 	viper.SetDefault("LOG_FORMAT", "json")
 
 	// Rental defaults
-	viper.SetDefault("DEFAULT_RENTAL_DAYS", 14)
-	viper.SetDefault("MAX_RENTAL_EXTENSION_DAYS", 7)
 	viper.SetDefault("LATE_FEE_PER_DAY", 1.00)
+	viper.SetDefault("MAX_OVERDUE_RENTALS", 0)
+	viper.SetDefault("MAX_UNPAID_FEES", 10.00)
+	viper.SetDefault("HOLD_PICKUP_WINDOW", "72h")
 
 	// Rate limiting defaults
 	viper.SetDefault("RATE_LIMIT_REQUESTS", 100)
 	viper.SetDefault("RATE_LIMIT_DURATION", "1m")
+
+	// Account security defaults
+	viper.SetDefault("PASSWORD_RESET_EXPIRATION", "1h")
+	viper.SetDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	viper.SetDefault("EMAIL_VERIFICATION_EXPIRATION", "48h")
+	viper.SetDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/api/v1/auth/verify")
+	viper.SetDefault("REQUIRE_VERIFIED_EMAIL", false)
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS", 5)
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", "15m")
+	viper.SetDefault("LOGIN_THROTTLE_BASE_DELAY", "1s")
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20)
+	viper.SetDefault("LOGIN_IP_WINDOW", "15m")
+	viper.SetDefault("LOGIN_ATTEMPT_RETENTION", "720h")
+	viper.SetDefault("MFA_REQUIRED_FOR_STAFF", false)
+	viper.SetDefault("MFA_ISSUER", "SimpleBookRental")
+	viper.SetDefault("DELETED_ACCOUNT_RETENTION", "720h")
+
+	// Mail defaults
+	viper.SetDefault("MAIL_DRIVER", "log")
+	viper.SetDefault("MAIL_FROM", "no-reply@simplebookrental.com")
+	viper.SetDefault("SMTP_HOST", "localhost")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("MAIL_OUTPUT_FILE", "")
+
+	// OpenID Connect defaults
+	viper.SetDefault("OIDC_PROVIDERS", "")
+	viper.SetDefault("OIDC_STATE_EXPIRATION", "10m")
+
+	// Password policy defaults
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+	viper.SetDefault("PASSWORD_REQUIRE_SYMBOL", false)
+	viper.SetDefault("PASSWORD_HISTORY_SIZE", 5)
+	viper.SetDefault("PASSWORD_BREACHED_LIST_FILE", "")
+
+	// Data export defaults
+	viper.SetDefault("DATA_EXPORT_SYNC_MAX_RECORDS", 1000)
+	viper.SetDefault("DATA_EXPORT_LINK_EXPIRATION", "24h")
+	viper.SetDefault("DATA_EXPORT_DOWNLOAD_URL", "http://localhost:3000/api/v1/exports/download")
+
+	// Background job defaults
+	viper.SetDefault("JOBS_ENABLED", true)
+	viper.SetDefault("JOB_MARK_OVERDUE_INTERVAL", "5m")
+	viper.SetDefault("JOB_RESERVATION_HOLDS_INTERVAL", "5m")
 }
 
 // GetDSN returns the database connection string
@@ -0,0 +1,59 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LogMailer does not send email. It writes messages to the log and, if an
+// output file is configured, appends them to that file. Intended for local
+// development and tests.
+type LogMailer struct {
+	mu         sync.Mutex
+	from       string
+	outputFile string
+	logger     *logger.Logger
+}
+
+// NewLogMailer creates a new LogMailer
+func NewLogMailer(cfg config.MailConfig, logger *logger.Logger) *LogMailer {
+	return &LogMailer{
+		from:       cfg.From,
+		outputFile: cfg.OutputFile,
+		logger:     logger,
+	}
+}
+
+// Send records a message
+func (m *LogMailer) Send(msg *Message) error {
+	m.logger.Info("Email sent",
+		zap.String("to", msg.To),
+		zap.String("subject", msg.Subject),
+	)
+
+	if m.outputFile == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.outputFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mail output file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\r\n%s\r\n\r\n", time.Now().Format(time.RFC1123Z), buildMessage(m.from, msg))
+	if err != nil {
+		return fmt.Errorf("failed to write mail output file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package mailer
+
+import (
+	"github.com/SimpleBookRental/backend/pkg/config"
+	"github.com/SimpleBookRental/backend/pkg/logger"
+)
+
+// Message represents an email message
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// New creates the Mailer selected by the configuration.
+// Unknown drivers fall back to the log mailer so nothing is sent by accident.
+func New(cfg config.MailConfig, logger *logger.Logger) Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg)
+	default:
+		return NewLogMailer(cfg, logger)
+	}
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestAPIKeyManagement tests the API key endpoints
+func TestAPIKeyManagement(t *testing.T) {
+	createURL := fmt.Sprintf("%s/api/v1/api-keys", baseURL)
+	keyData := map[string]interface{}{
+		"name":    "Catalog sync",
+		"user_id": 1,
+		"scopes":  []string{"books:read", "books:write"},
+	}
+	
+	// Members cannot create API keys
+	resp, err := makeAuthenticatedRequest("POST", createURL, keyData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create API key request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Unknown scopes are rejected
+	invalidScopeData := map[string]interface{}{
+		"name":    "Bad scopes",
+		"user_id": 1,
+		"scopes":  []string{"everything:all"},
+	}
+	resp, err = makeAuthenticatedRequest("POST", createURL, invalidScopeData, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create API key request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+}
+
+// TestAPIKeyAuthentication tests authenticating with the X-API-Key header
+func TestAPIKeyAuthentication(t *testing.T) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/v1/reports/overdue", baseURL), nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", "sbr_not-a-real-key")
+	
+	resp, err := testClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusUnauthorized)
+	
+	// A key scoped to reading reports acts as its owner on report routes
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/api-keys", baseURL), map[string]interface{}{
+		"name":    "Reporting",
+		"user_id": 1,
+		"scopes":  []string{"reports:read"},
+	}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create API key request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	rawKey, _ := decodeData(t, resp)["key"].(string)
+	
+	resp = makeAPIKeyRequest(t, "GET", "/api/v1/reports/overdue", rawKey)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Routes outside the key's scopes are forbidden, even though its owner may use them
+	resp = makeAPIKeyRequest(t, "GET", "/api/v1/payments", rawKey)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	resp = makeAPIKeyRequest(t, "POST", "/api/v1/books", rawKey)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+}
+
+// makeAPIKeyRequest makes a request authenticated with an API key instead of a token
+func makeAPIKeyRequest(t *testing.T, method, path, apiKey string) *http.Response {
+	req, err := http.NewRequest(method, baseURL+path, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", apiKey)
+	
+	resp, err := testClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to make request: %v", err)
+	}
+	return resp
+}
@@ -1,7 +1,10 @@
 package integration
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +72,46 @@ func TestAuthRegister(t *testing.T) {
 	checkStatusCode(t, resp, http.StatusBadRequest)
 }
 
+// TestAuthPasswordPolicy tests that registration reports every failed password rule
+func TestAuthPasswordPolicy(t *testing.T) {
+	registerURL := baseURL + "/api/v1/auth/register"
+	registerData := map[string]interface{}{
+		"username": "policy_user",
+		"email":    "policy_user@example.com",
+		"password": "short",
+	}
+	
+	resp, err := makeAuthenticatedRequest("POST", registerURL, registerData, "")
+	if err != nil {
+		t.Fatalf("Failed to make register request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	
+	details, ok := result["details"].([]interface{})
+	if !ok {
+		t.Fatalf("Expected password policy details in response, got %v", result)
+	}
+	
+	failed := make(map[string]bool)
+	for _, d := range details {
+		violation, _ := d.(map[string]interface{})
+		rule, _ := violation["rule"].(string)
+		failed[rule] = true
+	}
+	for _, rule := range []string{"min_length", "uppercase", "digit"} {
+		if !failed[rule] {
+			t.Errorf("Expected rule %s to be reported, got %v", rule, details)
+		}
+	}
+}
+
 // TestAuthLogin tests the login endpoint
 func TestAuthLogin(t *testing.T) {
 	// Test successful login
@@ -117,10 +160,16 @@ func TestAuthLogin(t *testing.T) {
 
 // TestAuthLogout tests the logout endpoint
 func TestAuthLogout(t *testing.T) {
+	// Log in separately so revoking the token does not affect other tests
+	token, err := loginAndGetToken("member@example.com", "Member123!")
+	if err != nil {
+		t.Fatalf("Failed to log in before logout: %v", err)
+	}
+	
 	// Test successful logout
 	logoutURL := baseURL + "/api/v1/auth/logout"
 	
-	resp, err := makeAuthenticatedRequest("POST", logoutURL, nil, memberToken)
+	resp, err := makeAuthenticatedRequest("POST", logoutURL, nil, token)
 	if err != nil {
 		t.Fatalf("Failed to make logout request: %v", err)
 	}
@@ -128,6 +177,15 @@ func TestAuthLogout(t *testing.T) {
 	
 	checkStatusCode(t, resp, http.StatusOK)
 	
+	// Test that the revoked token is rejected
+	resp, err = makeAuthenticatedRequest("GET", baseURL+"/api/v1/users/profile", nil, token)
+	if err != nil {
+		t.Fatalf("Failed to make request with revoked token: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusUnauthorized)
+	
 	// Test unauthenticated logout
 	resp, err = makeAuthenticatedRequest("POST", logoutURL, nil, "")
 	if err != nil {
@@ -192,3 +250,221 @@ func TestAuthMe(t *testing.T) {
 	
 	checkStatusCode(t, resp, http.StatusUnauthorized)
 }
+
+// TestAuthSessions tests the session management endpoints
+func TestAuthSessions(t *testing.T) {
+	sessionsURL := baseURL + "/api/v1/auth/sessions"
+	
+	// Test listing own sessions
+	resp, err := makeAuthenticatedRequest("GET", sessionsURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list sessions request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Test revoking a session that does not exist
+	resp, err = makeAuthenticatedRequest("DELETE", sessionsURL+"/999999", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make revoke session request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Test unauthenticated session listing
+	resp, err = makeAuthenticatedRequest("GET", sessionsURL, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make unauthenticated list sessions request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusUnauthorized)
+}
+
+// TestAuthPasswordReset tests the password reset endpoints
+func TestAuthPasswordReset(t *testing.T) {
+	forgotURL := baseURL + "/api/v1/auth/password/forgot"
+	resetURL := baseURL + "/api/v1/auth/password/reset"
+	
+	// Test requesting a reset for a registered email
+	resp, err := makeAuthenticatedRequest("POST", forgotURL, map[string]interface{}{
+		"email": "member@example.com",
+	}, "")
+	if err != nil {
+		t.Fatalf("Failed to make forgot password request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Test that unknown emails get the same response
+	resp, err = makeAuthenticatedRequest("POST", forgotURL, map[string]interface{}{
+		"email": "nobody@example.com",
+	}, "")
+	if err != nil {
+		t.Fatalf("Failed to make forgot password request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Test resetting with an invalid token
+	resp, err = makeAuthenticatedRequest("POST", resetURL, map[string]interface{}{
+		"token":        "not-a-real-token",
+		"new_password": "NewMember123!",
+	}, "")
+	if err != nil {
+		t.Fatalf("Failed to make reset password request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+}
+
+// TestAuthVerifyEmail tests the email verification endpoint
+func TestAuthVerifyEmail(t *testing.T) {
+	verifyURL := baseURL + "/api/v1/auth/verify"
+	
+	// Test verifying without a token
+	resp, err := makeAuthenticatedRequest("GET", verifyURL, nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make verify request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Test verifying with an invalid token
+	resp, err = makeAuthenticatedRequest("GET", verifyURL+"?token=not-a-real-token", nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make verify request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	t.Run("Resend", testAuthResendVerification)
+}
+
+// testAuthResendVerification tests requesting a new verification link, and that changing the
+// email address requires verifying it again
+func testAuthResendVerification(t *testing.T) {
+	resendURL := baseURL + "/api/v1/auth/verify/resend"
+	userID := createMember(t, "resend.verification@example.com", "Resend123!")
+	token, err := loginAndGetToken("resend.verification@example.com", "Resend123!")
+	if err != nil {
+		t.Fatalf("Failed to log in: %v", err)
+	}
+	
+	// Unverified users can ask for a new link
+	resp, err := makeAuthenticatedRequest("POST", resendURL, nil, token)
+	if err != nil {
+		t.Fatalf("Failed to make resend request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Verified users cannot
+	if _, err := testDB.DB.Exec("UPDATE users SET email_verified_at = NOW() WHERE id = $1", userID); err != nil {
+		t.Fatalf("Failed to verify email: %v", err)
+	}
+	
+	resp, err = makeAuthenticatedRequest("POST", resendURL, nil, token)
+	if err != nil {
+		t.Fatalf("Failed to make resend request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+	
+	// A new email address has to be verified again
+	userURL := fmt.Sprintf("%s/api/v1/users/%d", baseURL, userID)
+	resp, err = makeAuthenticatedRequest("PUT", userURL, map[string]interface{}{"email": "resend.changed@example.com"}, token)
+	if err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	if verifiedAt := decodeData(t, resp)["email_verified_at"]; verifiedAt != nil {
+		t.Errorf("Expected the new email address to be unverified; verified at %v", verifiedAt)
+	}
+	
+	resp, err = makeAuthenticatedRequest("POST", resendURL, nil, token)
+	if err != nil {
+		t.Fatalf("Failed to make resend request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+}
+
+// TestAuthTwoFactor tests the two-factor enrollment and verification endpoints
+func TestAuthTwoFactor(t *testing.T) {
+	enrollURL := baseURL + "/api/v1/auth/2fa/enroll"
+	confirmURL := baseURL + "/api/v1/auth/2fa/confirm"
+	verifyURL := baseURL + "/api/v1/auth/2fa/verify"
+	
+	// Test starting enrollment
+	resp, err := makeAuthenticatedRequest("POST", enrollURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make enroll request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var enrollResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&enrollResp); err != nil {
+		t.Fatalf("Failed to decode enroll response: %v", err)
+	}
+	data, _ := enrollResp["data"].(map[string]interface{})
+	uri, _ := data["provisioning_uri"].(string)
+	if !strings.HasPrefix(uri, "otpauth://totp/") {
+		t.Errorf("Expected otpauth provisioning URI, got %q", uri)
+	}
+	
+	// Test confirming with a wrong code
+	resp, err = makeAuthenticatedRequest("POST", confirmURL, map[string]interface{}{
+		"code": "abcdef",
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make confirm request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusUnauthorized)
+	
+	// Test verifying with an invalid MFA token
+	resp, err = makeAuthenticatedRequest("POST", verifyURL, map[string]interface{}{
+		"mfa_token": "invalid-token",
+		"code":      "123456",
+	}, "")
+	if err != nil {
+		t.Fatalf("Failed to make verify request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusUnauthorized)
+}
+
+// TestAuthJWKS tests the public signing key set endpoint
+func TestAuthJWKS(t *testing.T) {
+	resp, err := makeAuthenticatedRequest("GET", baseURL+"/.well-known/jwks.json", nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make JWKS request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var jwks map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&jwks)
+	
+	if _, ok := jwks["keys"].([]interface{}); !ok {
+		t.Errorf("Expected a keys array in JWKS response; got %v", jwks)
+	}
+}
@@ -311,3 +311,150 @@ func TestBookAvailability(t *testing.T) {
 		t.Errorf("Expected 3 available books, got %d", int(availableCount))
 	}
 }
+
+// TestBookCopies tests the book copy and barcode circulation endpoints
+func TestBookCopies(t *testing.T) {
+	copyData := map[string]interface{}{
+		"barcode":        "TEST-COPY-001",
+		"condition":      "good",
+		"shelf_location": "A1-01",
+	}
+	
+	// Members cannot add copies
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%d/copies", baseURL, 1), copyData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create book copy request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Copies can only be added to existing books
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%d/copies", baseURL, 999999), copyData, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create book copy request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Unknown barcodes are not found
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/copies/%s", baseURL, "NO-SUCH-BARCODE"), nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make get book copy request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Members cannot check out copies at the desk
+	checkoutData := map[string]interface{}{
+		"barcode": "TEST-COPY-001",
+		"user_id": 999999,
+	}
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals/checkout", baseURL), checkoutData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make checkout request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Returning an unknown barcode fails
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals/return", baseURL), map[string]interface{}{"barcode": "NO-SUCH-BARCODE"}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make return request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	t.Run("checkout and return by barcode", testBookCopyCirculation)
+}
+
+// testBookCopyCirculation tests lending and returning a copy at the desk by its barcode and
+// that the book's available copies follow the copy
+func testBookCopyCirculation(t *testing.T) {
+	bookID := createBookWithCopy(t, "Barcode Circulation Book", "9780000000200", "DESK-0001")
+	
+	// Create the borrower
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/users", baseURL), map[string]interface{}{
+		"email":     "desk.borrower@example.com",
+		"password":  "TestPassword123!",
+		"firstName": "Desk",
+		"lastName":  "Borrower",
+		"role":      "member",
+	}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	userID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract user ID from response")
+	}
+	
+	availableCopies := func() float64 {
+		resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/books/%d", baseURL, bookID), nil, librianToken)
+		if err != nil {
+			t.Fatalf("Failed to get book: %v", err)
+		}
+		defer resp.Body.Close()
+		
+		available, _ := decodeData(t, resp)["available_copies"].(float64)
+		return available
+	}
+	
+	if available := availableCopies(); available != 1 {
+		t.Fatalf("Expected 1 available copy before checkout; got %.0f", available)
+	}
+	
+	// Check the copy out by its barcode
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals/checkout", baseURL), map[string]interface{}{
+		"barcode": "DESK-0001",
+		"user_id": userID,
+	}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make checkout request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	rental := decodeData(t, resp)
+	if borrower, _ := rental["user_id"].(float64); borrower != userID {
+		t.Errorf("Expected the rental to be for user %.0f; got %.0f", userID, borrower)
+	}
+	
+	if available := availableCopies(); available != 0 {
+		t.Errorf("Expected no available copies while on loan; got %.0f", available)
+	}
+	
+	// The copy on loan cannot be checked out again
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals/checkout", baseURL), map[string]interface{}{
+		"barcode": "DESK-0001",
+		"user_id": userID,
+	}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make checkout request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+	
+	// Return it by its barcode
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals/return", baseURL), map[string]interface{}{"barcode": "DESK-0001"}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make return request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	if available := availableCopies(); available != 1 {
+		t.Errorf("Expected 1 available copy after the return; got %.0f", available)
+	}
+}
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestLibraryCalendar tests the library calendar endpoints
+func TestLibraryCalendar(t *testing.T) {
+	calendarURL := fmt.Sprintf("%s/api/v1/calendar", baseURL)
+	
+	// Anyone can see the opening hours
+	resp, err := makeAuthenticatedRequest("GET", calendarURL+"/hours", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make get opening hours request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Anyone can see the closures over a range of dates
+	resp, err = makeAuthenticatedRequest("GET", calendarURL+"/closures?from=2025-12-01&to=2025-12-31", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list closures request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Dates must be YYYY-MM-DD
+	resp, err = makeAuthenticatedRequest("GET", calendarURL+"/closures?from=December", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list closures request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Members cannot close the library
+	resp, err = makeAuthenticatedRequest("POST", calendarURL+"/closures", map[string]interface{}{"date": "2025-12-25", "reason": "Christmas Day"}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create closure request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians cannot change the opening hours
+	resp, err = makeAuthenticatedRequest("PUT", calendarURL+"/hours", map[string]interface{}{"hours": []map[string]interface{}{{"weekday": 0, "closed": true}}}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make update opening hours request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// The library must open before it closes
+	resp, err = makeAuthenticatedRequest("PUT", calendarURL+"/hours", map[string]interface{}{"hours": []map[string]interface{}{{"weekday": 0, "opens_at": "18:00", "closes_at": "09:00"}}}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make update opening hours request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Closures need a valid date
+	resp, err = makeAuthenticatedRequest("POST", calendarURL+"/closures", map[string]interface{}{"date": "25/12/2025", "reason": "Christmas Day"}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create closure request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Imports need an iCalendar file
+	resp, err = makeAuthenticatedRequest("POST", calendarURL+"/closures/import", nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make import closures request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Unknown closures cannot be deleted
+	resp, err = makeAuthenticatedRequest("DELETE", fmt.Sprintf("%s/closures/%d", calendarURL, 999999), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make delete closure request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,217 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestFines tests the fine endpoints
+func TestFines(t *testing.T) {
+	finesURL := fmt.Sprintf("%s/api/v1/fines", baseURL)
+	
+	// Members cannot list every fine
+	resp, err := makeAuthenticatedRequest("GET", finesURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list fines request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can, filtered by status
+	resp, err = makeAuthenticatedRequest("GET", finesURL+"?status=outstanding", nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make list fines request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Unknown statuses are rejected
+	resp, err = makeAuthenticatedRequest("GET", finesURL+"?status=forgiven", nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make list fines request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Members cannot list other users' fines
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/user/%d", finesURL, 999999), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list user fines request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Members cannot waive fines
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/%d/waive", finesURL, 999999), map[string]interface{}{"reason": "Returned on time"}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make waive fine request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// A reason is required to waive a fine
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/%d/waive", finesURL, 999999), map[string]interface{}{}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make waive fine request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Unknown fines cannot be waived
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/%d/waive", finesURL, 999999), map[string]interface{}{"reason": "Library was closed"}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make waive fine request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Payments can only go towards existing fines
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/payments/process", baseURL), map[string]interface{}{
+		"fine_id":        999999,
+		"amount":         1.00,
+		"payment_method": "credit_card",
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make process payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	t.Run("late return is fined, paid and waived", testFineLifecycle)
+}
+
+// testFineLifecycle tests that a late return is fined, payments are credited to the fine
+// and a librarian can waive the rest
+func testFineLifecycle(t *testing.T) {
+	bookID := createBookWithCopy(t, "Fine Lifecycle Book", "9780000000231", "FINE-0001")
+	
+	// Rent the book with a due date that has already passed
+	rentalData := map[string]interface{}{
+		"book_id":     bookID,
+		"rental_date": time.Now().AddDate(0, 0, -20),
+		"due_date":    time.Now().AddDate(0, 0, -10),
+	}
+	
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), rentalData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to create rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	rentalID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract rental ID from response")
+	}
+	
+	// Returning it late records a fine and returns its amount as the late fee
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/api/v1/rentals/%.0f/return", baseURL, rentalID), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to return rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	returned := decodeData(t, resp)
+	lateFee, _ := returned["late_fee"].(float64)
+	if lateFee <= 1.00 {
+		t.Fatalf("Expected a late fee over 1.00; got %.2f", lateFee)
+	}
+	
+	fine, ok := returned["fine"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Failed to extract fine from return response")
+	}
+	
+	fineID, _ := fine["id"].(float64)
+	if amount, _ := fine["amount"].(float64); amount != lateFee {
+		t.Errorf("Expected fine amount %.2f; got %.2f", lateFee, amount)
+	}
+	
+	fineURL := fmt.Sprintf("%s/api/v1/fines/%.0f", baseURL, fineID)
+	processURL := fmt.Sprintf("%s/api/v1/payments/process", baseURL)
+	
+	// A completed payment is credited to the fine
+	resp, err = makeAuthenticatedRequest("POST", processURL, map[string]interface{}{
+		"fine_id":        fineID,
+		"amount":         1.00,
+		"payment_method": "credit_card",
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make process payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	resp, err = makeAuthenticatedRequest("GET", fineURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to get fine: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	fine = decodeData(t, resp)
+	if paid, _ := fine["amount_paid"].(float64); paid != 1.00 {
+		t.Errorf("Expected 1.00 paid towards the fine; got %.2f", paid)
+	}
+	if status, _ := fine["status"].(string); status != "outstanding" {
+		t.Errorf("Expected the fine to still be outstanding; got %s", status)
+	}
+	
+	// Paying more than the balance is refused
+	resp, err = makeAuthenticatedRequest("POST", processURL, map[string]interface{}{
+		"fine_id":        fineID,
+		"amount":         lateFee,
+		"payment_method": "credit_card",
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make process payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// A librarian waives the rest with a reason
+	resp, err = makeAuthenticatedRequest("PUT", fineURL+"/waive", map[string]interface{}{"reason": "Book returned late because the library was closed"}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make waive fine request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	fine = decodeData(t, resp)
+	if status, _ := fine["status"].(string); status != "waived" {
+		t.Errorf("Expected the fine to be waived; got %s", status)
+	}
+	if reason, _ := fine["waiver_reason"].(string); reason == "" {
+		t.Error("Expected the waiver reason to be recorded")
+	}
+	
+	// A waived fine takes no more payments
+	resp, err = makeAuthenticatedRequest("POST", processURL, map[string]interface{}{
+		"fine_id":        fineID,
+		"amount":         1.00,
+		"payment_method": "credit_card",
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make process payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+}
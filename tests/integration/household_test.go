@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestHouseholds tests the household endpoints and acting for dependents
+func TestHouseholds(t *testing.T) {
+	listURL := fmt.Sprintf("%s/api/v1/households", baseURL)
+	
+	// Members cannot manage households
+	resp, err := makeAuthenticatedRequest("GET", listURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list households request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can list households
+	resp, err = makeAuthenticatedRequest("GET", listURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make list households request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// The guardian has to exist
+	householdData := map[string]interface{}{
+		"name":                   "The Smiths",
+		"guardian_id":            999999,
+		"max_concurrent_rentals": 5,
+	}
+	resp, err = makeAuthenticatedRequest("POST", listURL, householdData, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create household request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Dependents can only be added to existing households
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/999999/dependents", listURL), map[string]interface{}{"user_id": 999999}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make add dependent request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Members cannot see other users' households
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/users/%d/household", baseURL, 999999), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make get user household request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Members who are not a guardian cannot rent for someone else
+	rentalData := map[string]interface{}{
+		"user_id": 999999,
+		"book_id": 1,
+	}
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), rentalData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create rental request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Nor pay for someone else
+	paymentData := map[string]interface{}{
+		"user_id":        999999,
+		"amount":         5.00,
+		"payment_method": "credit_card",
+	}
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/payments", baseURL), paymentData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	t.Run("guardian acts for a dependent", testHouseholdGuardian)
+}
+
+// testHouseholdGuardian tests that a guardian can rent, pay and view for their dependent and
+// that the members of a household share its rental limit
+func testHouseholdGuardian(t *testing.T) {
+	guardianID := createMember(t, "household.guardian@example.com", "Guardian123!")
+	dependentID := createMember(t, "household.dependent@example.com", "Dependent123!")
+	
+	// Create a household that may borrow one book at a time
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/households", baseURL), map[string]interface{}{
+		"name":                   "The Guardians",
+		"guardian_id":            guardianID,
+		"max_concurrent_rentals": 1,
+	}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create household request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	householdID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract household ID from response")
+	}
+	
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/households/%.0f/dependents", baseURL, householdID), map[string]interface{}{"user_id": dependentID}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make add dependent request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	guardianToken, err := loginAndGetToken("household.guardian@example.com", "Guardian123!")
+	if err != nil {
+		t.Fatalf("Failed to log in as the guardian: %v", err)
+	}
+	
+	// The guardian rents a book for the dependent
+	bookID := createBookWithCopy(t, "Household Book", "9780000000190", "HOUSE-0001")
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), map[string]interface{}{
+		"user_id": dependentID,
+		"book_id": bookID,
+	}, guardianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create rental request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	if borrower, _ := decodeData(t, resp)["user_id"].(float64); int64(borrower) != dependentID {
+		t.Errorf("Expected the rental to be for the dependent %d; got %.0f", dependentID, borrower)
+	}
+	
+	// The guardian pays for the dependent
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/payments", baseURL), map[string]interface{}{
+		"user_id":        dependentID,
+		"amount":         2.50,
+		"payment_method": "credit_card",
+	}, guardianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create payment request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	// The guardian sees the dependent's rentals and payments
+	for _, path := range []string{"rentals", "payments"} {
+		resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/%s/user/%d", baseURL, path, dependentID), nil, guardianToken)
+		if err != nil {
+			t.Fatalf("Failed to list the dependent's %s: %v", path, err)
+		}
+		resp.Body.Close()
+		
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status %d listing the dependent's %s; got %d", http.StatusOK, path, resp.StatusCode)
+		}
+	}
+	
+	// The dependent's rental uses up the household limit, so the guardian cannot borrow
+	otherBookID := createBookWithCopy(t, "Household Limit Book", "9780000000191", "HOUSE-0002")
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), map[string]interface{}{
+		"book_id": otherBookID,
+	}, guardianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create rental request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	var errResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("Failed to decode error response: %v", err)
+	}
+	if message, _ := errResp["error"].(string); !strings.Contains(message, "household") {
+		t.Errorf("Expected the household limit to be reached; got %q", message)
+	}
+}
@@ -0,0 +1,206 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestLoanRules tests the loan rule endpoints
+func TestLoanRules(t *testing.T) {
+	rulesURL := fmt.Sprintf("%s/api/v1/loan-rules", baseURL)
+	rule := map[string]interface{}{
+		"name":             "Reference books",
+		"category_id":      999999,
+		"loan_period_days": 7,
+		"max_renewals":     0,
+		"fine_per_day":     0.50,
+		"fine_cap":         10.00,
+	}
+	
+	// Members cannot list loan rules
+	resp, err := makeAuthenticatedRequest("GET", rulesURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list loan rules request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can
+	resp, err = makeAuthenticatedRequest("GET", rulesURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make list loan rules request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Both a user and a book are needed to preview a rule
+	resp, err = makeAuthenticatedRequest("GET", rulesURL+"/preview?user_id=1", nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make preview loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Unknown books have no rule to preview
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/preview?user_id=1&book_id=%d", rulesURL, 999999), nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make preview loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Librarians cannot manage loan rules
+	resp, err = makeAuthenticatedRequest("POST", rulesURL, rule, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make create loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Loan periods must be positive
+	resp, err = makeAuthenticatedRequest("POST", rulesURL, map[string]interface{}{"name": "Reference books", "loan_period_days": 0}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Rules must match existing categories
+	resp, err = makeAuthenticatedRequest("POST", rulesURL, rule, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Unknown rules cannot be deleted
+	resp, err = makeAuthenticatedRequest("DELETE", fmt.Sprintf("%s/%d", rulesURL, 999999), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make delete loan rule request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	t.Run("Precedence", testLoanRulePrecedence)
+}
+
+// testLoanRulePrecedence tests that a rule for a book wins over a rule for its category and
+// sets the due date of a rental
+func testLoanRulePrecedence(t *testing.T) {
+	rulesURL := fmt.Sprintf("%s/api/v1/loan-rules", baseURL)
+	
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/categories", baseURL), map[string]interface{}{
+		"name":        "Loan Rule Category",
+		"description": "Category for loan rule tests",
+	}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	categoryID := decodeData(t, resp)["id"].(float64)
+	
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books", baseURL), map[string]interface{}{
+		"title":       "Loan Rule Book",
+		"author":      "Test Author",
+		"isbn":        "9780000000240",
+		"category_id": categoryID,
+	}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to create book: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	bookID := decodeData(t, resp)["id"].(float64)
+	
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%.0f/copies", baseURL, bookID), map[string]interface{}{"barcode": "RULE-0001"}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to create copy: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	memberID := createMember(t, "loan.rule.member@example.com", "LoanRule123!")
+	borrowerToken, err := loginAndGetToken("loan.rule.member@example.com", "LoanRule123!")
+	if err != nil {
+		t.Fatalf("Failed to log in as the member: %v", err)
+	}
+	
+	createRule := func(rule map[string]interface{}) float64 {
+		resp, err := makeAuthenticatedRequest("POST", rulesURL, rule, adminToken)
+		if err != nil {
+			t.Fatalf("Failed to create loan rule: %v", err)
+		}
+		defer resp.Body.Close()
+		
+		checkStatusCode(t, resp, http.StatusCreated)
+		return decodeData(t, resp)["id"].(float64)
+	}
+	previewRule := func() string {
+		resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/preview?user_id=%d&book_id=%.0f", rulesURL, memberID, bookID), nil, librianToken)
+		if err != nil {
+			t.Fatalf("Failed to preview loan rule: %v", err)
+		}
+		defer resp.Body.Close()
+		
+		checkStatusCode(t, resp, http.StatusOK)
+		rule, _ := decodeData(t, resp)["rule"].(map[string]interface{})
+		name, _ := rule["name"].(string)
+		return name
+	}
+	
+	categoryRuleID := createRule(map[string]interface{}{
+		"name":             "Category loans",
+		"category_id":      categoryID,
+		"loan_period_days": 10,
+	})
+	defer makeAuthenticatedRequest("DELETE", fmt.Sprintf("%s/%.0f", rulesURL, categoryRuleID), nil, adminToken)
+	
+	if name := previewRule(); name != "Category loans" {
+		t.Errorf("Expected the category rule to apply; got %q", name)
+	}
+	
+	// A rule for the book is more specific than the rule for its category
+	bookRuleID := createRule(map[string]interface{}{
+		"name":             "Book loans",
+		"book_id":          bookID,
+		"loan_period_days": 3,
+	})
+	defer makeAuthenticatedRequest("DELETE", fmt.Sprintf("%s/%.0f", rulesURL, bookRuleID), nil, adminToken)
+	
+	if name := previewRule(); name != "Book loans" {
+		t.Errorf("Expected the book rule to apply; got %q", name)
+	}
+	
+	// Renting the book uses the book rule's loan period, rolled forward past closed days
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), map[string]interface{}{
+		"book_id": bookID,
+	}, borrowerToken)
+	if err != nil {
+		t.Fatalf("Failed to create rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	dueDate, err := time.Parse(time.RFC3339, decodeData(t, resp)["due_date"].(string))
+	if err != nil {
+		t.Fatalf("Failed to parse due date: %v", err)
+	}
+	if days := time.Until(dueDate).Hours() / 24; days < 2.9 || days >= 10 {
+		t.Errorf("Expected the rental to be due in 3 days or on the next open day after; due in %.1f days", days)
+	}
+}
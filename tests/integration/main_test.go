@@ -25,6 +25,7 @@ var (
 	testServer     *httptest.Server
 	testClient     *http.Client
 	testDB         *repository.DBConn
+	testServices   *service.Service
 	adminToken     string
 	librianToken   string
 	memberToken    string
@@ -34,6 +35,7 @@ var (
 	testCategoryID string
 	testRentalID   string
 	testPaymentID  string
+	oidcStub       *stubOIDCServer
 )
 
 // TestMain sets up the test environment
@@ -69,20 +71,47 @@ func setup() {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
 	
+	// Reserve the test server address so redirect URLs can point at it
+	testServer = httptest.NewUnstartedServer(nil)
+	baseURL = "http://" + testServer.Listener.Addr().String()
+	
+	// Start the stub identity provider used by the OIDC tests
+	oidcStub = newStubOIDCServer()
+	cfg.OIDC.Providers = map[string]config.OIDCProviderConfig{
+		"stub": {
+			Issuer:       oidcStub.URL,
+			ClientID:     stubOIDCClientID,
+			ClientSecret: stubOIDCClientSecret,
+			RedirectURL:  baseURL + "/api/v1/auth/oidc/stub/callback",
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+	}
+	
 	// Initialize JWT service
-	jwtService := auth.NewJWTService(&cfg.JWT)
+	jwtService, err := auth.NewJWTService(&cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to initialize JWT service: %v", err)
+	}
 	
 	// Initialize repositories
 	repos := repository.NewRepository(testDB)
+	if testDB.DB == nil {
+		// Keep token revocation working without a database
+		repos.RevokedToken = repository.NewMemoryRevokedTokenRepository()
+	}
 	
 	// Initialize services
-	services := service.NewService(repos, cfg, jwtService, appLogger)
+	services, err := service.NewService(repos, cfg, jwtService, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to initialize services: %v", err)
+	}
+	testServices = services
 	
 	// Initialize handlers
 	handlers := api.NewHandler(services, cfg, jwtService, appLogger)
 	
 	// Initialize middleware
-	middleware := api.NewMiddleware(jwtService, appLogger)
+	middleware := api.NewMiddleware(jwtService, services.Auth, services.APIKey, services.Authz, cfg.RateLimit, appLogger)
 	
 	// Initialize router
 	router := gin.New()
@@ -100,9 +129,9 @@ func setup() {
 	// Register routes
 	handlers.RegisterRoutes(router, middleware)
 	
-	// Create test server
-	testServer = httptest.NewServer(router)
-	baseURL = testServer.URL
+	// Start test server
+	testServer.Config.Handler = router
+	testServer.Start()
 	
 	testClient = &http.Client{
 		Timeout: time.Second * 10,
@@ -115,6 +144,7 @@ func setup() {
 // teardown cleans up the test environment
 func teardown() {
 	testServer.Close()
+	oidcStub.Close()
 	cleanupTestDatabase()
 }
 
@@ -234,6 +264,31 @@ func createUserAndGetToken(email, password, role string) string {
 	return token
 }
 
+// loginAndGetToken logs in an existing user and returns a fresh access token
+func loginAndGetToken(email, password string) (string, error) {
+	loginURL := fmt.Sprintf("%s/api/v1/auth/login", baseURL)
+	loginData := map[string]string{
+		"email":    email,
+		"password": password,
+	}
+	
+	resp, err := makeAuthenticatedRequest("POST", loginURL, loginData, "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	
+	var loginResult map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&loginResult)
+	
+	token, ok := loginResult["token"].(string)
+	if !ok {
+		return "", fmt.Errorf("no token in login response (status %d)", resp.StatusCode)
+	}
+	
+	return token, nil
+}
+
 // TestPing tests the ping endpoint to verify the server is running
 func TestPing(t *testing.T) {
 	pingURL := fmt.Sprintf("%s/ping", baseURL)
@@ -278,6 +333,90 @@ func makeAuthenticatedRequest(method, url string, body interface{}, token string
 	return testClient.Do(req)
 }
 
+// decodeData decodes a response envelope and returns its data object
+func decodeData(t *testing.T, resp *http.Response) map[string]interface{} {
+	t.Helper()
+	
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	
+	data, ok := body["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Failed to extract data from response")
+	}
+	
+	return data
+}
+
+// createBookWithCopy creates a book with one copy on the shelf and returns the book ID
+func createBookWithCopy(t *testing.T, title, isbn, barcode string) int64 {
+	t.Helper()
+	
+	bookData := map[string]interface{}{
+		"title":  title,
+		"author": "Test Author",
+		"isbn":   isbn,
+	}
+	
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books", baseURL), bookData, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to create test book: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status %d creating the test book; got %d", http.StatusCreated, resp.StatusCode)
+	}
+	
+	bookID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract book ID from response")
+	}
+	
+	copyURL := fmt.Sprintf("%s/api/v1/books/%.0f/copies", baseURL, bookID)
+	resp, err = makeAuthenticatedRequest("POST", copyURL, map[string]interface{}{"barcode": barcode}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to create test copy: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status %d creating the test copy; got %d", http.StatusCreated, resp.StatusCode)
+	}
+	
+	return int64(bookID)
+}
+
+// createMember creates a member as an admin and returns the user ID
+func createMember(t *testing.T, email, password string) int64 {
+	t.Helper()
+	
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/users", baseURL), map[string]interface{}{
+		"email":     email,
+		"password":  password,
+		"firstName": "Test",
+		"lastName":  "Member",
+		"role":      "member",
+	}, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected status %d creating %s; got %d", http.StatusCreated, email, resp.StatusCode)
+	}
+	
+	userID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract user ID from response")
+	}
+	
+	return int64(userID)
+}
+
 // Helper function to check if status code matches expected
 func checkStatusCode(t *testing.T, resp *http.Response, expected int) {
 	if resp.StatusCode != expected {
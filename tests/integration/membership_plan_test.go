@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestMembershipPlans tests the membership plan endpoints
+func TestMembershipPlans(t *testing.T) {
+	listURL := fmt.Sprintf("%s/api/v1/membership-plans", baseURL)
+	
+	// Members can see the available plans
+	resp, err := makeAuthenticatedRequest("GET", listURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list membership plans request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Members cannot create plans
+	planData := map[string]interface{}{
+		"name":                   "student",
+		"max_concurrent_rentals": 5,
+		"loan_period_days":       21,
+		"max_extensions":         2,
+		"max_extension_days":     7,
+		"monthly_fee":            2.50,
+	}
+	resp, err = makeAuthenticatedRequest("POST", listURL, planData, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create membership plan request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Admins can create plans
+	resp, err = makeAuthenticatedRequest("POST", listURL, planData, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create membership plan request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	// Plans must allow at least one rental
+	planData["name"] = "nothing"
+	planData["max_concurrent_rentals"] = 0
+	resp, err = makeAuthenticatedRequest("POST", listURL, planData, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make create membership plan request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Members cannot assign themselves a plan
+	assignURL := fmt.Sprintf("%s/api/v1/users/%d/membership-plan", baseURL, 999999)
+	resp, err = makeAuthenticatedRequest("PUT", assignURL, map[string]interface{}{"plan_id": 2}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make assign membership plan request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can assign plans, but only to existing users
+	resp, err = makeAuthenticatedRequest("PUT", assignURL, map[string]interface{}{"plan_id": 2}, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make assign membership plan request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
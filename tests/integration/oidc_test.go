@@ -0,0 +1,296 @@
+package integration
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	stubOIDCClientID     = "sbr-integration"
+	stubOIDCClientSecret = "sbr-integration-secret"
+	stubOIDCKeyID        = "stub-key"
+)
+
+// stubOIDCServer is a minimal OpenID Connect provider that signs in whichever
+// identity is configured on it without prompting
+type stubOIDCServer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu            sync.Mutex
+	codes         map[string]stubAuthorization
+	subject       string
+	email         string
+	emailVerified bool
+}
+
+// stubAuthorization is an authorization code issued by the stub provider
+type stubAuthorization struct {
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	subject       string
+	email         string
+	emailVerified bool
+}
+
+// newStubOIDCServer starts a stub OpenID Connect provider
+func newStubOIDCServer() *stubOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	
+	stub := &stubOIDCServer{
+		key:   key,
+		codes: make(map[string]stubAuthorization),
+	}
+	
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", stub.discovery)
+	mux.HandleFunc("/authorize", stub.authorize)
+	mux.HandleFunc("/token", stub.token)
+	mux.HandleFunc("/jwks", stub.jwks)
+	stub.Server = httptest.NewServer(mux)
+	
+	return stub
+}
+
+// SetIdentity sets the identity signed in by the next authorization
+func (s *stubOIDCServer) SetIdentity(subject, email string, emailVerified bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subject, s.email, s.emailVerified = subject, email, emailVerified
+}
+
+func (s *stubOIDCServer) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 s.URL,
+		"authorization_endpoint": s.URL + "/authorize",
+		"token_endpoint":         s.URL + "/token",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *stubOIDCServer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != stubOIDCClientID || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	
+	codeBytes := make([]byte, 16)
+	rand.Read(codeBytes)
+	code := hex.EncodeToString(codeBytes)
+	
+	s.mu.Lock()
+	s.codes[code] = stubAuthorization{
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		subject:       s.subject,
+		email:         s.email,
+		emailVerified: s.emailVerified,
+	}
+	s.mu.Unlock()
+	
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirect.RawQuery = params.Encode()
+	
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *stubOIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok || clientID != stubOIDCClientID || clientSecret != stubOIDCClientSecret {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	
+	code := r.PostFormValue("code")
+	s.mu.Lock()
+	authorization, exists := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+	
+	verifierHash := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !exists ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != authorization.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(verifierHash[:]) != authorization.codeChallenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+	
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.URL,
+		"aud":            stubOIDCClientID,
+		"sub":            authorization.subject,
+		"email":          authorization.email,
+		"email_verified": authorization.emailVerified,
+		"given_name":     "Campus",
+		"family_name":    "Student",
+		"nonce":          authorization.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	})
+	idToken.Header["kid"] = stubOIDCKeyID
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"id_token":     signed,
+		"expires_in":   300,
+	})
+}
+
+func (s *stubOIDCServer) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": stubOIDCKeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}},
+	})
+}
+
+// oidcAuthorize runs the browser side of a login through the stub provider up to the redirect
+// back to the API, and returns the callback URL and the cookies set when the login started
+func oidcAuthorize(t *testing.T) (string, []*http.Cookie) {
+	noRedirectClient := &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	
+	// Start the login; the API redirects to the provider
+	resp, err := noRedirectClient.Get(fmt.Sprintf("%s/api/v1/auth/oidc/stub", baseURL))
+	if err != nil {
+		t.Fatalf("Failed to start OIDC login: %v", err)
+	}
+	resp.Body.Close()
+	checkStatusCode(t, resp, http.StatusFound)
+	cookies := resp.Cookies()
+	
+	// The provider signs the user in and redirects back to the callback
+	resp, err = noRedirectClient.Get(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("Failed to authorize with stub provider: %v", err)
+	}
+	resp.Body.Close()
+	checkStatusCode(t, resp, http.StatusFound)
+	
+	return resp.Header.Get("Location"), cookies
+}
+
+// oidcSignIn runs the browser side of a login through the stub provider and returns the callback response
+func oidcSignIn(t *testing.T) *http.Response {
+	callbackURL, cookies := oidcAuthorize(t)
+	
+	req, err := http.NewRequest("GET", callbackURL, nil)
+	if err != nil {
+		t.Fatalf("Failed to create OIDC callback request: %v", err)
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	
+	resp, err := testClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to call OIDC callback: %v", err)
+	}
+	
+	return resp
+}
+
+// TestOIDCLogin tests signing in through an external identity provider
+func TestOIDCLogin(t *testing.T) {
+	email := fmt.Sprintf("campus-%d@example.edu", time.Now().UnixNano())
+	oidcStub.SetIdentity("campus|"+email, email, true)
+	
+	// First sign-in provisions an account
+	resp := oidcSignIn(t)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	
+	data, _ := result["data"].(map[string]interface{})
+	if token, _ := data["access_token"].(string); token == "" {
+		t.Errorf("Expected access token in OIDC login response; got %v", result)
+	}
+	
+	// Signing in again uses the linked identity
+	resp = oidcSignIn(t)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+}
+
+// TestOIDCLoginErrors tests rejected OpenID Connect logins
+func TestOIDCLoginErrors(t *testing.T) {
+	// Unknown providers are not found
+	resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/auth/oidc/unknown", baseURL), nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make OIDC login request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// A callback with a state that was never issued is rejected
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/auth/oidc/stub/callback?code=abc&state=forged", baseURL), nil, "")
+	if err != nil {
+		t.Fatalf("Failed to make OIDC callback request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// A callback from another browser than the one that started the login is rejected
+	callbackURL, _ := oidcAuthorize(t)
+	resp, err = testClient.Get(callbackURL)
+	if err != nil {
+		t.Fatalf("Failed to call OIDC callback: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// An unverified email cannot take over an existing account
+	oidcStub.SetIdentity("campus|member", "member@example.com", false)
+	resp = oidcSignIn(t)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+}
@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestPermissionManagement tests the permission endpoints
+func TestPermissionManagement(t *testing.T) {
+	listURL := fmt.Sprintf("%s/api/v1/permissions", baseURL)
+	
+	// Members cannot view the permission policy
+	resp, err := makeAuthenticatedRequest("GET", listURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list permissions request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Admins can view the permission policy
+	resp, err = makeAuthenticatedRequest("GET", listURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make list permissions request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Unknown permissions cannot be granted
+	grantURL := fmt.Sprintf("%s/api/v1/permissions/roles/librarian", baseURL)
+	grantData := map[string]interface{}{
+		"permission": "everything:all",
+	}
+	resp, err = makeAuthenticatedRequest("POST", grantURL, grantData, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make grant permission request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Admins always keep the permission to manage permissions
+	revokeURL := fmt.Sprintf("%s/api/v1/permissions/roles/admin/permissions:manage", baseURL)
+	resp, err = makeAuthenticatedRequest("DELETE", revokeURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make revoke permission request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+}
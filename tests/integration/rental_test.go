@@ -180,6 +180,68 @@ func TestRentalCRUD(t *testing.T) {
 	if !ok || status != "returned" {
 		t.Errorf("Expected status 'returned', got %v", status)
 	}
+	
+	// Returning the same rental again is rejected
+	resp, err = makeAuthenticatedRequest("PUT", returnURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to return rental again: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+}
+
+// TestRentalReturnTwice tests that only one of two simultaneous returns of a rental succeeds
+func TestRentalReturnTwice(t *testing.T) {
+	bookID := createBookWithCopy(t, "Double Return Test Book", "3333222211110", "RETURN-0001")
+	
+	// Rent it
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), map[string]interface{}{
+		"book_id": bookID,
+	}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to create rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	rentalID, ok := decodeData(t, resp)["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract rental ID from response")
+	}
+	
+		// Return it twice at the same time, exactly one return succeeds
+	returnURL := fmt.Sprintf("%s/api/v1/rentals/%.0f/return", baseURL, rentalID)
+	statuses := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			resp, err := makeAuthenticatedRequest("PUT", returnURL, nil, memberToken)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	
+	counts := map[int]int{}
+	for i := 0; i < 2; i++ {
+		counts[<-statuses]++
+	}
+	if counts[http.StatusOK] != 1 || counts[http.StatusConflict] != 1 {
+		t.Errorf("Expected one return to succeed and one to conflict, got %v", counts)
+	}
+	
+	// Later returns are rejected too
+	resp, err = makeAuthenticatedRequest("PUT", returnURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to return rental again: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
 }
 
 // TestRentalOverdue tests the overdue rental functionality
@@ -470,3 +532,26 @@ func TestRentalPermissions(t *testing.T) {
 	
 	checkStatusCode(t, resp, http.StatusForbidden)
 }
+
+// TestRentalEligibility tests the borrowing eligibility endpoint
+func TestRentalEligibility(t *testing.T) {
+	eligibilityURL := fmt.Sprintf("%s/api/v1/users/%d/eligibility", baseURL, 999999)
+	
+	// Members cannot check other users
+	resp, err := makeAuthenticatedRequest("GET", eligibilityURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make eligibility request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can check any user
+	resp, err = makeAuthenticatedRequest("GET", eligibilityURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make eligibility request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestReservations tests the reservation queue endpoints
+func TestReservations(t *testing.T) {
+	reservationsURL := fmt.Sprintf("%s/api/v1/reservations", baseURL)
+	
+	// Only existing books can be reserved
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%d/reservations", baseURL, 999999), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create reservation request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Members cannot reserve for someone else
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%d/reservations", baseURL, 1), map[string]interface{}{"user_id": 999999}, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make create reservation request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Members cannot see the queue of a book
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/books/%d/reservations", baseURL, 1), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list book reservations request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians can
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/books/%d/reservations", baseURL, 1), nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make list book reservations request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Members cannot list other users' reservations
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/user/%d", reservationsURL, 999999), nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make list user reservations request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Unknown reservations cannot be cancelled
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/%d/cancel", reservationsURL, 999999), nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make cancel reservation request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	t.Run("Queue", testReservationQueue)
+}
+
+// testReservationQueue tests that a returned copy is held for the first member in line and
+// passes to the next one when the hold expires
+func testReservationQueue(t *testing.T) {
+	bookID := createBookWithCopy(t, "Reserved Book", "9780000000210", "RESV-0001")
+	
+	createMember(t, "queue.borrower@example.com", "Borrower123!")
+	createMember(t, "queue.first@example.com", "QueueFirst123!")
+	createMember(t, "queue.second@example.com", "QueueSecond123!")
+	
+	tokens := make(map[string]string)
+	for email, password := range map[string]string{
+		"queue.borrower@example.com": "Borrower123!",
+		"queue.first@example.com":    "QueueFirst123!",
+		"queue.second@example.com":   "QueueSecond123!",
+	} {
+		token, err := loginAndGetToken(email, password)
+		if err != nil {
+			t.Fatalf("Failed to log in as %s: %v", email, err)
+		}
+		tokens[email] = token
+	}
+	
+	// The only copy is rented out
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/rentals", baseURL), map[string]interface{}{
+		"book_id": bookID,
+	}, tokens["queue.borrower@example.com"])
+	if err != nil {
+		t.Fatalf("Failed to create rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	rentalID := decodeData(t, resp)["id"].(float64)
+	
+	// Two members queue for it
+	reserve := func(token string) float64 {
+		resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/books/%d/reservations", baseURL, bookID), nil, token)
+		if err != nil {
+			t.Fatalf("Failed to make create reservation request: %v", err)
+		}
+		defer resp.Body.Close()
+		
+		checkStatusCode(t, resp, http.StatusCreated)
+		return decodeData(t, resp)["id"].(float64)
+	}
+	firstID := reserve(tokens["queue.first@example.com"])
+	secondID := reserve(tokens["queue.second@example.com"])
+	
+	reservationStatus := func(id float64, token string) string {
+		resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/reservations/%.0f", baseURL, id), nil, token)
+		if err != nil {
+			t.Fatalf("Failed to get reservation: %v", err)
+		}
+		defer resp.Body.Close()
+		
+		checkStatusCode(t, resp, http.StatusOK)
+		status, _ := decodeData(t, resp)["status"].(string)
+		return status
+	}
+	
+	// Returning the copy holds it for the first in line
+	resp, err = makeAuthenticatedRequest("PUT", fmt.Sprintf("%s/api/v1/rentals/%.0f/return", baseURL, rentalID), nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to return rental: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	if status := reservationStatus(firstID, tokens["queue.first@example.com"]); status != "ready" {
+		t.Errorf("Expected the first reservation to be ready; got %q", status)
+	}
+	if status := reservationStatus(secondID, tokens["queue.second@example.com"]); status != "waiting" {
+		t.Errorf("Expected the second reservation to be waiting; got %q", status)
+	}
+	
+	// Once the hold expires the copy passes to the next in line
+	if _, err := testDB.DB.Exec("UPDATE reservations SET hold_expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", int64(firstID)); err != nil {
+		t.Fatalf("Failed to expire hold: %v", err)
+	}
+	if _, _, err := testServices.Reservation.ProcessHolds(); err != nil {
+		t.Fatalf("Failed to process holds: %v", err)
+	}
+	
+	if status := reservationStatus(firstID, tokens["queue.first@example.com"]); status != "expired" {
+		t.Errorf("Expected the first reservation to have expired; got %q", status)
+	}
+	if status := reservationStatus(secondID, tokens["queue.second@example.com"]); status != "ready" {
+		t.Errorf("Expected the second reservation to be ready; got %q", status)
+	}
+}
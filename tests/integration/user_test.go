@@ -1,8 +1,10 @@
 package integration
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"testing"
 )
@@ -279,6 +281,385 @@ func TestUserPermissions(t *testing.T) {
 	resp.Body.Close()
 }
 
+// TestUserUnlock tests clearing a login lockout
+func TestUserUnlock(t *testing.T) {
+	unlockURL := fmt.Sprintf("%s/api/v1/users/%d/unlock", baseURL, 999999)
+	
+	// Members cannot unlock accounts
+	resp, err := makeAuthenticatedRequest("POST", unlockURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make unlock request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Unlocking an unknown user returns not found
+	resp, err = makeAuthenticatedRequest("POST", unlockURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make unlock request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
+
+// TestUserSuspension tests suspending, reactivating and anonymizing accounts
+func TestUserSuspension(t *testing.T) {
+	suspendURL := fmt.Sprintf("%s/api/v1/users/%d/suspend", baseURL, 999999)
+	anonymizeURL := fmt.Sprintf("%s/api/v1/users/%d/anonymize", baseURL, 999999)
+	
+	// Members cannot suspend accounts
+	resp, err := makeAuthenticatedRequest("POST", suspendURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make suspend request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Suspending an unknown user returns not found
+	resp, err = makeAuthenticatedRequest("POST", suspendURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make suspend request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Librarians cannot anonymize accounts
+	resp, err = makeAuthenticatedRequest("POST", anonymizeURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make anonymize request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Anonymizing an unknown user returns not found
+	resp, err = makeAuthenticatedRequest("POST", anonymizeURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make anonymize request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
+
+// TestUserDataExport tests exporting the personal data of the current user
+func TestUserDataExport(t *testing.T) {
+	exportURL := fmt.Sprintf("%s/api/v1/users/me/export", baseURL)
+	
+	// Small exports are returned right away as a ZIP archive
+	resp, err := makeAuthenticatedRequest("GET", exportURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to export data: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/zip" {
+		t.Errorf("Expected a ZIP archive; got %s", contentType)
+	}
+	
+	// Background exports return the export and its download link
+	resp, err = makeAuthenticatedRequest("GET", exportURL+"?async=true", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to start background export: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusAccepted)
+	
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected data in response")
+	}
+	if data["download_url"] == nil {
+		t.Errorf("Expected a download link")
+	}
+	
+	// Another background export is refused while this one is pending or can be downloaded
+	resp, err = makeAuthenticatedRequest("GET", exportURL+"?async=true", nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to start background export: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusConflict)
+	
+	// The owner can check on the export
+	statusURL := fmt.Sprintf("%s/%d", exportURL, int64(data["id"].(float64)))
+	resp, err = makeAuthenticatedRequest("GET", statusURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to get export status: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	// Other users cannot see it
+	resp, err = makeAuthenticatedRequest("GET", statusURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to get export status: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+	
+	// Unknown download tokens are rejected
+	resp, err = http.Get(fmt.Sprintf("%s/api/v1/exports/download?token=invalid", baseURL))
+	if err != nil {
+		t.Fatalf("Failed to download export: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
+
+// TestUserSearch tests searching, filtering and sorting the user list
+func TestUserSearch(t *testing.T) {
+	listURL := fmt.Sprintf("%s/api/v1/users?search=example.com&role=member&sort=created_at&order=desc", baseURL)
+	
+	resp, err := makeAuthenticatedRequest("GET", listURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to search users: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	
+	users, _ := result["data"].([]interface{})
+	total, _ := result["total"].(float64)
+	if int(total) < len(users) {
+		t.Errorf("Expected total of at least %d; got %v", len(users), total)
+	}
+	for _, u := range users {
+		if role := u.(map[string]interface{})["role"]; role != "member" {
+			t.Errorf("Expected only members; got %v", role)
+		}
+	}
+	
+	// Unknown sort fields are rejected
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/users?sort=password_hash", baseURL), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to search users: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Dates must be YYYY-MM-DD
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/users?created_from=yesterday", baseURL), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to search users: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+}
+
+// TestUserImport tests the bulk user import endpoints
+func TestUserImport(t *testing.T) {
+	importURL := fmt.Sprintf("%s/api/v1/admin/users/import", baseURL)
+	csvData := "username,email,first_name,last_name,role\nimportuser1,importuser1@example.com,Import,One,member\nx,not-an-email,,,member\n"
+	
+	upload := func(url, token string) *http.Response {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "users.csv")
+		part.Write([]byte(csvData))
+		writer.Close()
+		
+		req, _ := http.NewRequest("POST", url, &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		
+		resp, err := testClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to upload import file: %v", err)
+		}
+		return resp
+	}
+	
+	// Members may not import users
+	resp := upload(importURL+"?dry_run=true", memberToken)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// A dry run reports the invalid row without creating anyone
+	resp = upload(importURL+"?dry_run=true", adminToken)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var result map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&result)
+	
+	validation, _ := result["data"].(map[string]interface{})
+	if validation["valid"] != false {
+		t.Errorf("Expected the file to be invalid; got %v", validation["valid"])
+	}
+	if errs, _ := validation["errors"].([]interface{}); len(errs) == 0 {
+		t.Error("Expected row errors in the dry run result")
+	}
+	
+	// Importing a file with invalid rows is rejected
+	resp = upload(importURL+"?send_invites=true", adminToken)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Imports have to send invites, the temporary passwords are not handed out anywhere else
+	csvData = "username,email\nimportuser2,importuser2@example.com\n"
+	resp = upload(importURL, adminToken)
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusBadRequest)
+	
+	// Unknown import jobs are not found
+	resp, err := makeAuthenticatedRequest("GET", fmt.Sprintf("%s/999999", importURL), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to get import job: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
+
+// TestUserImpersonation tests the admin impersonation endpoint
+func TestUserImpersonation(t *testing.T) {
+	impersonateURL := fmt.Sprintf("%s/api/v1/admin/users/%d/impersonate", baseURL, 999999)
+	
+	// Members cannot impersonate other users
+	resp, err := makeAuthenticatedRequest("POST", impersonateURL, nil, memberToken)
+	if err != nil {
+		t.Fatalf("Failed to make impersonate request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Librarians cannot impersonate other users
+	resp, err = makeAuthenticatedRequest("POST", impersonateURL, nil, librianToken)
+	if err != nil {
+		t.Fatalf("Failed to make impersonate request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusForbidden)
+	
+	// Impersonating an unknown user returns not found
+	resp, err = makeAuthenticatedRequest("POST", impersonateURL, nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make impersonate request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusNotFound)
+}
+
+// TestImpersonationRestrictedRoutes tests that an impersonation token cannot manage the
+// impersonated user's sign-in or export their data
+func TestImpersonationRestrictedRoutes(t *testing.T) {
+	// Create the member to impersonate
+	userData := map[string]interface{}{
+		"email":     "impersonated.member@example.com",
+		"password":  "TestPassword123!",
+		"firstName": "Impersonated",
+		"lastName":  "Member",
+		"role":      "member",
+	}
+	
+	resp, err := makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/users", baseURL), userData, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusCreated)
+	
+	var createResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&createResp); err != nil {
+		t.Fatalf("Failed to decode create response: %v", err)
+	}
+	
+	data, ok := createResp["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Failed to extract data from response")
+	}
+	
+	userID, ok := data["id"].(float64)
+	if !ok {
+		t.Fatalf("Failed to extract user ID from response")
+	}
+	
+	// Impersonate the member as an admin
+	resp, err = makeAuthenticatedRequest("POST", fmt.Sprintf("%s/api/v1/admin/users/%.0f/impersonate", baseURL, userID), nil, adminToken)
+	if err != nil {
+		t.Fatalf("Failed to make impersonate request: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+	
+	var impersonateResp map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&impersonateResp); err != nil {
+		t.Fatalf("Failed to decode impersonate response: %v", err)
+	}
+	
+	impersonation, ok := impersonateResp["data"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Failed to extract data from response")
+	}
+	
+	impersonationToken, ok := impersonation["access_token"].(string)
+	if !ok {
+		t.Fatalf("Failed to extract access token from response")
+	}
+	
+	// The impersonation token is refused on each restricted route
+	routes := []struct {
+		method string
+		path   string
+		body   interface{}
+	}{
+		{"POST", "/api/v1/auth/2fa/enroll", nil},
+		{"POST", "/api/v1/auth/2fa/confirm", map[string]string{"code": "123456"}},
+		{"GET", "/api/v1/auth/sessions", nil},
+		{"DELETE", "/api/v1/auth/sessions/1", nil},
+		{"GET", "/api/v1/users/me/export", nil},
+	}
+	
+	for _, route := range routes {
+		resp, err := makeAuthenticatedRequest(route.method, baseURL+route.path, route.body, impersonationToken)
+		if err != nil {
+			t.Fatalf("Failed to make %s %s request: %v", route.method, route.path, err)
+		}
+		resp.Body.Close()
+	
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s %s: expected status %d; got %d", route.method, route.path, http.StatusForbidden, resp.StatusCode)
+		}
+	}
+	
+	// The same token still works on ordinary routes
+	resp, err = makeAuthenticatedRequest("GET", fmt.Sprintf("%s/api/v1/users/%.0f", baseURL, userID), nil, impersonationToken)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	defer resp.Body.Close()
+	
+	checkStatusCode(t, resp, http.StatusOK)
+}
+
 // TestUserValidation tests validation of user data
 func TestUserValidation(t *testing.T) {
 	createURL := fmt.Sprintf("%s/api/v1/users", baseURL)